@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/sirupsen/logrus"
+)
+
+// adminRequestTimeout bounds how long an admin request waits for the
+// sensor.
+const adminRequestTimeout = 10 * time.Second
+
+// adminServer serves the sensor's admin API:
+//
+//	GET  /datasources                 status of all the datasources
+//	GET  /datasources/{name}          status of one datasource
+//	POST /datasources/{name}/restart  restart a datasource
+//	POST /datasources/{name}/disable  stop a datasource until enabled
+//	POST /datasources/{name}/enable   undo disable
+//
+// Requests must carry the admin token as a bearer token.
+type adminServer struct {
+	token  []byte
+	sensor atomic.Pointer[device.Sensor]
+}
+
+// readAdminToken reads the admin token from the specified file.
+func readAdminToken(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read admin token: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return nil, fmt.Errorf("admin token file %s is empty", path)
+	}
+	return []byte(token), nil
+}
+
+func (a *adminServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /datasources", a.handle(
+		func(ctx context.Context, s *device.Sensor, r *http.Request) (any, error) {
+			return s.Datasources(ctx)
+		}))
+	mux.HandleFunc("GET /datasources/{name}", a.handle(
+		func(ctx context.Context, s *device.Sensor, r *http.Request) (any, error) {
+			return s.Datasource(ctx, r.PathValue("name"))
+		}))
+	actions := map[string]func(*device.Sensor, context.Context, string) error{
+		"restart": (*device.Sensor).RestartDatasource,
+		"disable": (*device.Sensor).DisableDatasource,
+		"enable":  (*device.Sensor).EnableDatasource,
+	}
+	mux.HandleFunc("POST /datasources/{name}/{action}", a.handle(
+		func(ctx context.Context, s *device.Sensor, r *http.Request) (any, error) {
+			action, ok := actions[r.PathValue("action")]
+			if !ok {
+				return nil, errAdminNotFound
+			}
+			name := r.PathValue("name")
+			if err := action(s, ctx, name); err != nil {
+				return nil, err
+			}
+			return s.Datasource(ctx, name)
+		}))
+	return a.authorize(mux)
+}
+
+var errAdminNotFound = errors.New("not found")
+
+// authorize only lets requests with the admin token through.
+func (a *adminServer) authorize(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), a.token) != 1 {
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="sensor admin"`)
+			http.Error(rw, http.StatusText(http.StatusUnauthorized),
+				http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(rw, r)
+	})
+}
+
+// handle returns a handler that responds with the JSON encoding of
+// the result of f, or with its error.
+func (a *adminServer) handle(f func(ctx context.Context, s *device.Sensor,
+	r *http.Request) (any, error)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		s := a.sensor.Load()
+		if s == nil {
+			http.Error(rw, "sensor is not running", http.StatusServiceUnavailable)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), adminRequestTimeout)
+		defer cancel()
+		result, err := f(ctx, s, r)
+		switch {
+		case err == nil:
+		case errors.Is(err, device.ErrDatasourceNotFound), errors.Is(err, errAdminNotFound):
+			http.Error(rw, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, context.DeadlineExceeded):
+			http.Error(rw, "timed out waiting for sensor", http.StatusGatewayTimeout)
+			return
+		default:
+			http.Error(rw, err.Error(), http.StatusConflict)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(result); err != nil {
+			logrus.Errorf("Failed to write admin response: %v", err)
+		}
+	}
+}
+
+// runAdmin starts serving the admin API on -adminAddr, if it's set,
+// and returns the server so that the sensor can be set on it.
+func runAdmin(ctx context.Context) *adminServer {
+	if *adminAddr == "" {
+		return nil
+	}
+	token, err := readAdminToken(*adminTokenFile)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	a := &adminServer{token: token}
+	listener, err := net.Listen("tcp", *adminAddr)
+	if err != nil {
+		logrus.Fatalf("Failed to listen on admin address %v: %v", *adminAddr, err)
+	}
+	logrus.Infof("Admin API listening on %s", listener.Addr())
+	server := &http.Server{Handler: a.handler()}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("Admin API failed, no longer serving: %v", err)
+		}
+	}()
+	return a
+}
+
+// setSensor makes the admin API control sensor.
+func (a *adminServer) setSensor(sensor *device.Sensor) {
+	if a != nil {
+		a.sensor.Store(sensor)
+	}
+}
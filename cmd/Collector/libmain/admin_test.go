@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAdminServer(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	token, err := readAdminToken(tokenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(token) != "s3cret" {
+		t.Fatalf("expected token s3cret, got %q", token)
+	}
+	if err := os.WriteFile(tokenFile, []byte(" \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readAdminToken(tokenFile); err == nil {
+		t.Error("expected error reading empty token")
+	}
+
+	a := &adminServer{token: token}
+	server := httptest.NewServer(a.handler())
+	defer server.Close()
+
+	for _, tc := range []struct {
+		method, path, token string
+		status              int
+	}{
+		{"GET", "/datasources", "", http.StatusUnauthorized},
+		{"GET", "/datasources", "wrong", http.StatusUnauthorized},
+		{"POST", "/datasources/ds/restart", "s3cret1", http.StatusUnauthorized},
+		// The sensor isn't running yet.
+		{"GET", "/datasources", "s3cret", http.StatusServiceUnavailable},
+		{"GET", "/datasources/ds", "s3cret", http.StatusServiceUnavailable},
+		{"POST", "/datasources/ds/restart", "s3cret", http.StatusServiceUnavailable},
+		{"GET", "/datasources/ds/restart", "s3cret", http.StatusMethodNotAllowed},
+		{"GET", "/other", "s3cret", http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(tc.method, server.URL+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.path,
+				tc.status, resp.StatusCode)
+		}
+		if tc.status == http.StatusUnauthorized &&
+			resp.Header.Get("WWW-Authenticate") == "" {
+			t.Errorf("%s %s: expected WWW-Authenticate header", tc.method, tc.path)
+		}
+	}
+}
@@ -12,22 +12,30 @@ import (
 	"time"
 
 	"github.com/aristanetworks/cloudvision-go/device"
-	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/aristanetworks/cloudvision-go/device/record"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/sirupsen/logrus"
 )
 
+// Formats of -dumpFile.
+const (
+	dumpFormatText   = "text"
+	dumpFormatBinary = "binary"
+)
+
 type dumpInfo struct {
 	writePath string
+	format    string
 	lock      sync.Mutex
 	startTime time.Time
 	timeout   time.Duration
 	doneGroup sync.WaitGroup
 	done      bool
 	file      *os.File
+	recorder  *record.Writer
 }
 
-func (d *dumpInfo) processRequest(ctx context.Context,
+func (d *dumpInfo) processRequest(ctx context.Context, deviceID string,
 	req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -40,14 +48,21 @@ func (d *dumpInfo) processRequest(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
+		d.recorder = record.NewWriter(d.file)
 	}
-	_, err := d.file.WriteString(req.String())
-	if err != nil {
-		return nil, err
-	}
-	_, err = d.file.WriteString("\n")
-	if err != nil {
-		return nil, err
+	if d.format == dumpFormatBinary {
+		if err := d.recorder.Write(record.New(ctx, time.Now(), deviceID, req)); err != nil {
+			return nil, err
+		}
+	} else {
+		_, err := d.file.WriteString(req.String())
+		if err != nil {
+			return nil, err
+		}
+		_, err = d.file.WriteString("\n")
+		if err != nil {
+			return nil, err
+		}
 	}
 	if time.Since(d.startTime) < d.timeout {
 		return nil, nil
@@ -61,6 +76,7 @@ func (d *dumpInfo) processRequest(ctx context.Context,
 func newDumpInfo() *dumpInfo {
 	return &dumpInfo{
 		writePath: *dumpFile,
+		format:    *dumpFormat,
 		startTime: time.Now(),
 		timeout:   *dumpTimeout,
 	}
@@ -69,32 +85,12 @@ func newDumpInfo() *dumpInfo {
 func runDump(ctx context.Context) {
 	dumpInfo := newDumpInfo()
 	dumpInfo.doneGroup.Add(1)
-	inventory := device.NewInventory(ctx,
-		pgnmi.NewSimpleGNMIClient(dumpInfo.processRequest), newCVClient)
-	var cmdDevice *device.Config
-	if *deviceType != "" {
-		cmdDevice = &device.Config{
-			Name:     *deviceName,
-			Device:   *deviceType,
-			NoStream: *noStream,
-			Options:  deviceOptions,
-		}
-	}
-	configs, err := createDeviceConfigs(cmdDevice, *deviceConfigFile)
-	if err != nil {
+	if err := runLocalDevices(ctx, func(ctx context.Context, info *device.Info,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return dumpInfo.processRequest(ctx, info.ID, req)
+	}, nil); err != nil {
 		logrus.Fatal(err)
 	}
-	for _, config := range configs {
-		noop := NewBaseMonitor(logrus.WithField("Device", config.Name))
-		info, err := device.NewDeviceInfo(ctx, config, noop)
-		if err != nil {
-			logrus.Fatalf("Error in device.NewDeviceInfo: %v", err)
-		}
-		err = inventory.Add(info)
-		if err != nil {
-			logrus.Fatalf("Error in inventory.Add: %v", err)
-		}
-	}
 	logrus.Info("Dump Collector is running")
 	dumpInfo.doneGroup.Wait()
 }
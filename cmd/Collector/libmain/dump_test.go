@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/record"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDumpBinary(t *testing.T) {
+	d := &dumpInfo{
+		writePath: filepath.Join(t.TempDir(), "dump"),
+		format:    dumpFormatBinary,
+		startTime: time.Now(),
+		timeout:   time.Hour,
+	}
+	req := &gnmi.SetRequest{
+		Prefix: &gnmi.Path{Origin: "openconfig", Target: "target1"},
+		Update: []*gnmi.Update{pgnmi.Update(pgnmi.Path("foo"), agnmi.TypedValue("bar"))},
+	}
+	before := time.Now()
+	if _, err := d.processRequest(context.Background(), "dev1", req); err != nil {
+		t.Fatal(err)
+	}
+	d.file.Close()
+
+	f, err := os.Open(d.writePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rec, err := record.NewReader(f).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.DeviceID != "dev1" || rec.Origin != "openconfig" || rec.Target != "target1" ||
+		!proto.Equal(rec.Request, req) {
+		t.Errorf("unexpected record %v", rec)
+	}
+	if ts := time.Unix(0, rec.Timestamp); ts.Before(before) || ts.After(time.Now()) {
+		t.Errorf("unexpected timestamp %v", ts)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof" // import all pprof endpoints
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -21,13 +22,17 @@ import (
 
 	"github.com/aristanetworks/cloudvision-go/device"
 	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/batch"
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/queue"
 	v1client "github.com/aristanetworks/cloudvision-go/device/cvclient/v1"
 	v2client "github.com/aristanetworks/cloudvision-go/device/cvclient/v2"
 	_ "github.com/aristanetworks/cloudvision-go/device/devices" // import all registered devices
 	"github.com/aristanetworks/cloudvision-go/device/gen"
+	"github.com/aristanetworks/cloudvision-go/device/plugin"
 	agrpc "github.com/aristanetworks/cloudvision-go/grpc"
 	"github.com/aristanetworks/cloudvision-go/log"
 	"github.com/aristanetworks/cloudvision-go/provider"
+	"github.com/aristanetworks/cloudvision-go/tracing"
 	"github.com/aristanetworks/cloudvision-go/version"
 
 	"github.com/aristanetworks/fsnotify"
@@ -56,17 +61,32 @@ var (
 	deviceOptions    = aflag.Map{}
 	deviceConfigFile *string
 	noStream         *bool
+	plugins          aflag.StringArrayOption
 
 	// MockCollector config
 	mock        *bool
 	mockFeature = aflag.Map{}
 	mockTimeout *time.Duration
+	mockSpec    *string
+	mockJUnit   *string
+
+	// Config validation
+	configSchema    *bool
+	validate        *bool
+	validateDevices *bool
+	validateTimeout *time.Duration
 
 	// Dump Collector config
 	dump        *bool
 	dumpFile    *string
+	dumpFormat  *string
 	dumpTimeout *time.Duration
 
+	// Replay config
+	replay      *bool
+	replayFile  *string
+	replaySpeed *float64
+
 	// gNMI server config
 	gnmiServerAddr *string
 
@@ -77,11 +97,39 @@ var (
 	ingestServerAddr *string
 
 	// local gRPC server config for inventory service
-	grpcAddr *string
+	grpcAddr         *string
+	persistInventory *bool
 
 	// local http monitor server addr
 	monitorAddr *string
 
+	// local sensor admin API config
+	adminAddr      *string
+	adminTokenFile *string
+
+	// Store-and-forward queue config
+	queueDir        *string
+	queueMaxBytes   *int64
+	queueMaxAge     *time.Duration
+	queueDropPolicy *string
+
+	// SetRequest batching config
+	batchMaxSize    *int
+	batchMaxLatency *time.Duration
+
+	// Tracing config
+	traceExporter    *string
+	traceEndpoint    *string
+	traceInsecure    *bool
+	traceSampleRatio *float64
+
+	// how long datasources get to drain on SIGTERM and SIGINT
+	shutdownTimeout *time.Duration
+
+	// YANG validation config
+	yangValidation *string
+	yangSchemas    = aflag.Map{}
+
 	// Sensor Hostname
 	hostname *string
 
@@ -108,6 +156,13 @@ var (
 	sensorFailureMaxBackoff *time.Duration
 	maxClockDelta           *time.Duration
 	metricIntervalTime      *time.Duration
+	crashLoopThreshold      *int
+	crashLoopWindow         *time.Duration
+	quarantineBackoff       *time.Duration
+
+	// Sensor sharding settings
+	shardReplica  *string
+	shardLeaseTTL *time.Duration
 
 	// Datasource monitor settings
 	logRate *float64
@@ -115,6 +170,14 @@ var (
 
 var ready atomic.Bool
 
+// promMetrics serves Prometheus metrics at /metrics on the monitor
+// server, if -monitorAddr is set.
+var promMetrics *prometheusMetrics
+
+// batcher coalesces the SetRequests of devices, if -batchMaxSize is
+// set.
+var batcher *batch.Batcher
+
 // Main is the "real" main.
 func Main(sc device.SensorConfig) {
 	v = flag.Bool("version", false, "Print the version number")
@@ -128,24 +191,58 @@ func Main(sc device.SensorConfig) {
 
 	// Device config
 	deviceName = flag.String("deviceName", "cmd-device", "Device name")
-	deviceType = flag.String("device", "", "Device type (available devices: "+deviceList()+")")
+	deviceType = flag.String("device", "", deviceUsage())
 	deviceOptions = aflag.Map{}
-	deviceConfigFile = flag.String("configFile", "", "Path to the config file for devices")
+	deviceConfigFile = flag.String("configFile", "",
+		"Path to the config file for devices, or to a directory of *.yaml config files")
 	noStream = flag.Bool("nostream", false,
 		"If set, updates aren't streamed for specified device")
+	plugins = aflag.StringArrayOption{}
 
 	// MockCollector config
 	mock = flag.Bool("mock", false, "Run Collector in mock mode")
 	mockFeature = aflag.Map{}
 	mockTimeout = flag.Duration("mockTimeout", 60*time.Second,
 		"Timeout for checking notifications in mock mode")
+	mockSpec = flag.String("mockSpec", "",
+		"Path to a YAML file of assertions on the updates of each device, checked in "+
+			"mock mode instead of -mockFeature")
+	mockJUnit = flag.String("mockJUnit", "",
+		"Path to a file the results of the -mockSpec assertions are written to "+
+			"as JUnit XML")
+
+	// Config validation
+	configSchema = flag.Bool("configSchema", false, "Print a JSON Schema of -configFile "+
+		"files for the registered device types, including those of plugins, and exit")
+	validate = flag.Bool("validate", false, "Check the device configs of -configFile "+
+		"or -device, print the result for each device, and exit, with a non-zero status "+
+		"if any config is invalid. Providers aren't started and nothing is sent to "+
+		"CloudVision.")
+	validateDevices = flag.Bool("validateDevices", false,
+		"In -validate mode, also create each device and check its ID and that it's alive")
+	validateTimeout = flag.Duration("validateTimeout", 10*time.Second,
+		"Timeout for creating and checking each device in -validateDevices mode")
 
 	// Dump Collector config
 	dump = flag.Bool("dump", false, "Run Collector in dump mode")
 	dumpFile = flag.String("dumpFile", "", "Path to output file used to dump gNMI SetRequests")
+	dumpFormat = flag.String("dumpFormat", dumpFormatText,
+		"Format of -dumpFile: text, or binary to record the SetRequests along with "+
+			"the time they were made, their device and their gRPC metadata so that "+
+			"they can be replayed with -replay")
 	dumpTimeout = flag.Duration("dumpTimeout", 20*time.Second,
 		"Timeout for dumping gNMI SetRequests")
 
+	// Replay config
+	replay = flag.Bool("replay", false,
+		"Run Collector in replay mode, sending the SetRequests recorded in -replayFile "+
+			"to the gNMI server")
+	replayFile = flag.String("replayFile", "",
+		"Path to a recording made with -dump -dumpFormat binary, replayed in replay mode")
+	replaySpeed = flag.Float64("replaySpeed", 1,
+		"Speed at which -replay sends SetRequests, relative to the pace at which they "+
+			"were recorded. If 0, they're sent as fast as possible.")
+
 	// gNMI server config
 	gnmiServerAddr = flag.String("gnmiServerAddr", "",
 		"Address of gNMI server(deprecated; use grpcServerAddr")
@@ -161,12 +258,67 @@ func Main(sc device.SensorConfig) {
 	// local gRPC server config for inventory service
 	grpcAddr = flag.String("grpcAddr", "",
 		"Collector gRPC server address (if unspecified, server will not run)")
+	persistInventory = flag.Bool("persistInventory", false,
+		"If set, devices added, updated or deleted through the -grpcAddr inventory service "+
+			"are saved to -configFile. Secrets aren't saved in clear text, so secret options "+
+			"and credentials must be references to environment variables kept from the file")
 
 	// local http monitor server addr
 	monitorAddr = flag.String("monitorAddr", "",
-		"The address for the monitor server. If empty, monitor is not started. "+
+		"The address for the monitor server, which serves Prometheus metrics at /metrics. "+
+			"If empty, monitor is not started. "+
 			"Example: 0.0.0.0:0 or localhost:6060. Port 0 will select one automatically.")
 
+	// local sensor admin API
+	adminAddr = flag.String("adminAddr", "",
+		"The address for the sensor admin HTTP API, used to list, restart and disable "+
+			"datasources locally. If empty, the admin API is not started.")
+	adminTokenFile = flag.String("adminTokenFile", "",
+		"Path to a file with the bearer token required by the -adminAddr admin API")
+
+	// store-and-forward queue
+	queueDir = flag.String("queueDir", "",
+		"Directory in which SetRequests are queued while CloudVision can't be reached, "+
+			"to be replayed once it can. If empty, SetRequests made meanwhile are lost. "+
+			"CloudVision timestamps replayed data with the time it's replayed, so the "+
+			"history of the data still has a gap for the time it couldn't be reached.")
+	queueMaxBytes = flag.Int64("queueMaxBytes", 16<<20,
+		"Maximum size in bytes of the -queueDir SetRequests of each device, 0 for no limit")
+	queueMaxAge = flag.Duration("queueMaxAge", 24*time.Hour,
+		"Maximum age of the -queueDir SetRequests, 0 for no limit")
+	queueDropPolicy = flag.String("queueDropPolicy", "oldest",
+		"SetRequests to drop when the -queueDir SetRequests of a device reach "+
+			"-queueMaxBytes (oldest or newest)")
+
+	// SetRequest batching
+	batchMaxSize = flag.Int("batchMaxSize", 0,
+		"Maximum number of updates and deletes in a batch of SetRequests coalesced "+
+			"before being sent to CloudVision. If 0, SetRequests are sent as they're made.")
+	batchMaxLatency = flag.Duration("batchMaxLatency", 100*time.Millisecond,
+		"Defines how long SetRequests can wait in a -batchMaxSize batch")
+
+	// tracing
+	traceExporter = flag.String("traceExporter", "",
+		"OpenTelemetry exporter to export traces with (otlp or stdout). "+
+			"If empty, tracing is disabled.")
+	traceEndpoint = flag.String("traceEndpoint", "",
+		"host:port of the OTLP collector traces are exported to. "+
+			"Defaults to $OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317")
+	traceInsecure = flag.Bool("traceInsecure", false,
+		"Export traces to the OTLP collector without TLS")
+	traceSampleRatio = flag.Float64("traceSampleRatio", 1,
+		"Ratio of traces to sample, between 0 and 1")
+
+	yangValidation = flag.String("yangValidation", "",
+		"Check the updates of the v2 client against the -yangSchema schemas of their "+
+			"origin, either logging invalid ones (log) or rejecting them (strict). "+
+			"If empty, updates aren't checked.")
+
+	shutdownTimeout = flag.Duration("shutdownTimeout", 20*time.Second,
+		"On SIGTERM or SIGINT, how long datasources get to finish their in-flight polls, "+
+			"flush their updates and publish their final state. The process exits with "+
+			"a non-zero status if they don't make it in time")
+
 	// local hostname and IP of the sensor
 	hostname = flag.String("hostname", "",
 		"The hostname that can be used for logging into the sensor")
@@ -192,6 +344,14 @@ func Main(sc device.SensorConfig) {
 		"Defines interval of sensor heartbeats")
 	sensorFailureMaxBackoff = flag.Duration("failureBackoffMax", 1*time.Hour,
 		"Defines maximum backoff for datasource failure retries")
+	crashLoopThreshold = flag.Int("crashLoopThreshold", 0,
+		"Number of unexpected stops within -crashLoopWindow after which a datasource "+
+			"is quarantined. 0 disables crash-loop detection")
+	crashLoopWindow = flag.Duration("crashLoopWindow", time.Minute,
+		"Defines the window in which datasource stops count towards -crashLoopThreshold. "+
+			"Runs lasting longer release datasources from quarantine")
+	quarantineBackoff = flag.Duration("quarantineBackoff", 6*time.Hour,
+		"Defines how long quarantined datasources wait before being retried")
 
 	logRate = flag.Float64("logRate", 100.0, "Log rate limit (times per minute)"+
 		" for datasource monitor")
@@ -202,16 +362,34 @@ func Main(sc device.SensorConfig) {
 	metricIntervalTime = flag.Duration("metricIntervalTime", 1*time.Minute,
 		"Defines the time interval at which metric data is published to server")
 
+	shardReplica = flag.String("shardReplica", "",
+		"Name of this sensor replica, unique among the replicas sharing the datasources "+
+			"of -sensor. If set, each datasource is run by only one replica, coordinating "+
+			"through the ShardCoordinator of the collector's SensorConfig.")
+	shardLeaseTTL = flag.Duration("shardLeaseTTL", 15*time.Second,
+		"Defines how long a sensor replica can go without renewing its leases "+
+			"before its datasources are taken over")
+
 	flag.Var(mockFeature, "mockFeature",
 		"<feature>=<path> option for mock mode, where <path> is a path that, "+
 			"if present in the Collector output, signifies that the target device supports "+
 			"the feature described in <feature>")
+	flag.Var(yangSchemas, "yangSchema",
+		"<origin>=<dirs> option for -yangValidation, where <dirs> is a list of directories "+
+			"separated by '"+string(filepath.ListSeparator)+"' in which the YANG modules "+
+			"of <origin> are found. May be repeated to check multiple origins.")
 	flag.Var(deviceOptions, "deviceoption", "<key>=<value> option for the Device. "+
 		"May be repeated to set multiple Device options.")
+	flag.Var(&plugins, "plugin", "Path to a device plugin executable, or unix://<path> "+
+		"of the socket of a running plugin. May be repeated to load multiple plugins.")
 	flag.BoolVar(help, "h", false, "Print program options")
 
 	flag.Parse()
 
+	// Plugins are loaded before anything else so that their device
+	// types can be used and show up in the help output.
+	loadPlugins(context.Background())
+
 	// Print version.
 	if *v {
 		vs := []string{version.CollectorVersion, runtime.Version()}
@@ -225,6 +403,15 @@ func Main(sc device.SensorConfig) {
 		return
 	}
 
+	if *configSchema {
+		schema, err := device.ConfigJSONSchema()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Println(string(schema))
+		return
+	}
+
 	// Print help, including device-specific help,
 	// if requested.
 	if *help {
@@ -243,7 +430,18 @@ func Main(sc device.SensorConfig) {
 
 	initLogging()
 
+	if *validate {
+		if !runValidate(context.Background(), os.Stdout) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	runMonitor()
+	setupYANGValidation()
+
+	shutdownTracing := setupTracing(context.Background())
+	defer shutdownTracing()
 
 	if *mock {
 		runMock(context.Background())
@@ -253,7 +451,18 @@ func Main(sc device.SensorConfig) {
 		runDump(context.Background())
 		return
 	}
-	runMain(context.Background(), sc)
+	if *replay {
+		if err := runReplay(context.Background()); err != nil {
+			logrus.Error(err)
+			shutdownTracing()
+			os.Exit(1)
+		}
+		return
+	}
+	if err := runMain(context.Background(), sc); err != nil {
+		shutdownTracing()
+		os.Exit(1)
+	}
 }
 
 func runMonitor() {
@@ -274,6 +483,8 @@ func runMonitor() {
 			http.NotFound(rw, r)
 		}
 	}))
+	promMetrics = newPrometheusMetrics()
+	http.Handle("/metrics", promMetrics)
 	monitorListener, err := net.Listen("tcp", *monitorAddr)
 	if err != nil {
 		logrus.Fatalf("Failed to listen on monitor address %v: %v", *monitorAddr, err)
@@ -310,13 +521,32 @@ func newCVClient(gc gnmi.GNMIClient, info *device.Info) cvclient.CVClient {
 	if _, ok := info.Device.(device.Manager); ok {
 		isManager = true
 	}
+	if storeQueue != nil {
+		gc = storeQueue.Client(info.ID)
+	}
+	var client cvclient.CVClient
 	if protoVersion != nil && *protoVersion == "v2" {
-		return v2client.NewV2Client(gc, info)
+		var opts []v2client.Option
+		if yangValidator != nil {
+			opts = append(opts, v2client.WithValidator(yangValidator))
+		}
+		client = v2client.NewV2Client(gc, info, opts...)
+	} else {
+		var opts []v1client.Option
+		if mp, ok := info.Device.(device.MetadataProvider); ok {
+			opts = append(opts, v1client.WithMetadataProvider(mp))
+		}
+		client = v1client.NewV1Client(gc, info.ID, isManager, opts...)
+	}
+	if batcher != nil {
+		client = batcher.Client(client)
 	}
-	return v1client.NewV1Client(gc, info.ID, isManager)
+	return client
 }
 
-func runMain(ctx context.Context, sc device.SensorConfig) {
+// newGNMIConfig returns the config of the connection to the gNMI
+// server, as set by flags.
+func newGNMIConfig() *agnmi.Config {
 	agent := *grpcAgent
 	if len(agent) <= 0 { // use default value if not set
 		agent = fmt.Sprintf("cvsensor-%v", version.CollectorVersion)
@@ -337,14 +567,7 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 		gnmiCfg.Addr = *grpcServerAddr
 	}
 
-	if *ingestServerAddr == "" {
-		ingestServerAddr = grpcServerAddr
-	}
-
 	var noAuth agrpc.Auth
-	opts := []device.InventoryOption{
-		device.WithClientFactory(newCVClient),
-	}
 	if *authInfo != noAuth {
 		gnmiCfg.TLS = true
 		gnmiCfg.CAFile = authInfo.CAFile()
@@ -357,6 +580,22 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 		}
 		gnmiCfg.DialOptions = append(gnmiCfg.DialOptions, clientCreds...)
 	}
+	return gnmiCfg
+}
+
+// runMain runs the collector until it fails, or until it's shut down by
+// a signal, in which case it returns the error of draining datasources.
+func runMain(ctx context.Context, sc device.SensorConfig) error {
+	gnmiCfg := newGNMIConfig()
+
+	if *ingestServerAddr == "" {
+		ingestServerAddr = grpcServerAddr
+	}
+
+	var noAuth agrpc.Auth
+	opts := []device.InventoryOption{
+		device.WithClientFactory(newCVClient),
+	}
 
 	var grpcConn *grpc.ClientConn
 	if *grpcServerAddr != "" {
@@ -397,11 +636,35 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 
 	opts = append(opts, device.WithGNMIClient(gnmiClient))
 
+	if *batchMaxSize > 0 {
+		batcher, err = batch.New(batch.WithMaxSize(*batchMaxSize),
+			batch.WithMaxLatency(*batchMaxLatency),
+			batch.WithMetricCollector(newExpvarMetricCollector("setBatching")))
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	if *queueDir != "" {
+		storeQueue, err = openStoreQueue(gnmiClient)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
 	// Create inventory.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	inventory := device.NewInventoryWithOptions(ctx, opts...)
+	var currentSensor atomic.Pointer[device.Sensor]
+	drained := handleShutdown(ctx, cancel, currentSensor.Load, inventory)
 
 	group, ctx := errgroup.WithContext(ctx)
 
+	if storeQueue != nil {
+		group.Go(func() error { return storeQueue.Run(ctx) })
+	}
+
 	var cmdDevice *device.Config
 	if *deviceType != "" {
 		// Keep the group alive when running with cmd line config
@@ -441,6 +704,8 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 			device.WithSensorGNMIClient(gnmiClient),
 			device.WithSensorMaxClockDelta(*maxClockDelta),
 			device.WithSensorMetricIntervalTime(*metricIntervalTime),
+			device.WithSensorCrashLoopDetection(*crashLoopThreshold, *crashLoopWindow),
+			device.WithSensorQuarantineBackoff(*quarantineBackoff),
 			device.WithSensorClientFactory(newCVClient),
 			device.WithSensorConfigChan(configCh),
 			device.WithSensorGRPCConn(conn),
@@ -467,12 +732,30 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 				device.WithSensorConnectorAddress(*ingestServerAddr),
 				device.WithSensorStandaloneStatus(*standalone))
 		}
+		if *shardReplica != "" {
+			var coordinator device.ShardCoordinator
+			if sc.ShardCoordinatorCreator != nil {
+				var err error
+				if coordinator, err = sc.ShardCoordinatorCreator(conn); err != nil {
+					logrus.Fatal(err)
+				}
+			} else {
+				logrus.Fatal("-shardReplica requires a shard coordinator, " +
+					"which this collector doesn't provide")
+			}
+			opts = append(opts, device.WithSensorSharding(coordinator, *shardReplica),
+				device.WithSensorShardLeaseTTL(*shardLeaseTTL))
+		}
 		if *hostname != "" {
 			opts = append(opts, device.WithSensorHostname(*hostname))
 		}
 		if *ip != "" {
 			opts = append(opts, device.WithSensorIP(*ip))
 		}
+		if promMetrics != nil {
+			opts = append(opts, device.WithMetricTracker(promMetrics))
+		}
+		admin := runAdmin(ctx)
 		group.Go(func() error {
 			logrus.Infof("Starting sensor %v", *sensorName)
 
@@ -485,6 +768,9 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 				case <-backoffTimer.Wait():
 					waitForGNMIConnectivity(gnmiClient)
 					sensor := device.NewSensor(*sensorName, *logRate, opts...)
+					admin.setSensor(sensor)
+					currentSensor.Store(sensor)
+					promMetrics.setSensor(sensor)
 					err := sensor.Run(ctx)
 					// Sensor failed, schedule retry with backoff.
 					// This is done before logging the error so we can log a precise retry delay.
@@ -507,6 +793,13 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 					if !ok {
 						return nil
 					}
+					if !cfg.IsDeleted() && inInventory(inventory, cfg) {
+						// Don't restart devices whose config hasn't
+						// changed, such as when the inventory service
+						// rewrites the config file or when other
+						// members of a device group are edited.
+						continue
+					}
 					noop := NewBaseMonitor(logrus.WithField("Device", cfg.Name))
 					info, err := device.NewDeviceInfo(ctx, cfg, noop)
 					if err != nil {
@@ -534,7 +827,12 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 	}
 
 	if *grpcAddr != "" {
-		grpcServer, listener, err := newGRPCServer(*grpcAddr, inventory)
+		var serviceOpts []device.InventoryServiceOption
+		if *persistInventory {
+			serviceOpts = append(serviceOpts,
+				device.WithInventoryServiceConfigFile(*deviceConfigFile))
+		}
+		grpcServer, listener, err := newGRPCServer(*grpcAddr, inventory, serviceOpts...)
 		if err != nil {
 			logrus.Fatalf("Failed to start gRPC server: %v", err)
 		}
@@ -551,10 +849,29 @@ func runMain(ctx context.Context, sc device.SensorConfig) {
 
 	ready.Store(true)
 	logrus.Info("Collector is running")
-	if err := group.Wait(); err != nil {
+	err = group.Wait()
+	select {
+	case err := <-drained:
+		logrus.Infof("Collector is finished")
+		return err
+	default:
+	}
+	if err != nil {
 		logrus.Fatalf("group returned with error: %v", err)
 	}
 	logrus.Infof("Collector is finished")
+	return nil
+}
+
+// inInventory returns true if a device with the specified config is
+// in the inventory.
+func inInventory(inventory device.Inventory, cfg *device.Config) bool {
+	for _, info := range inventory.List() {
+		if info.Config.Equal(cfg) {
+			return true
+		}
+	}
+	return false
 }
 
 func waitForGNMIConnectivity(gnmiClient gnmi.GNMIClient) {
@@ -583,14 +900,30 @@ func waitForGNMIConnectivity(gnmiClient gnmi.GNMIClient) {
 
 func createDeviceConfigs(cmdDevice *device.Config,
 	deviceConfigFile string) ([]*device.Config, error) {
+	return readDeviceConfigs(cmdDevice, deviceConfigFile, true)
+}
+
+// readDeviceConfigs is createDeviceConfigs, optionally without
+// checking the options of the configs.
+func readDeviceConfigs(cmdDevice *device.Config, deviceConfigFile string,
+	checkOptions bool) ([]*device.Config, error) {
 	configs := []*device.Config{}
 	if cmdDevice != nil {
+		if checkOptions {
+			if err := device.ValidateOptions(cmdDevice); err != nil {
+				return nil, err
+			}
+		}
 		copy := *cmdDevice
 		configs = append(configs, &copy)
 	}
 
 	if deviceConfigFile != "" {
-		readConfigs, err := device.ReadConfigs(deviceConfigFile)
+		read := device.ReadConfigs
+		if !checkOptions {
+			read = device.ReadConfigsUnchecked
+		}
+		readConfigs, err := read(deviceConfigFile)
 		if err != nil {
 			return nil, err
 		}
@@ -608,6 +941,24 @@ func createDeviceConfigs(cmdDevice *device.Config,
 	return configs, nil
 }
 
+// loadPlugins loads the plugins specified with -plugin, registering
+// their device types.
+func loadPlugins(ctx context.Context) {
+	if len(plugins) == 0 {
+		return
+	}
+	for _, location := range plugins {
+		if _, err := plugin.Load(ctx, location); err != nil {
+			logrus.Fatalf("Failed to load plugin: %v", err)
+		}
+	}
+	flag.Lookup("device").Usage = deviceUsage()
+}
+
+func deviceUsage() string {
+	return "Device type (available devices: " + deviceList() + ")"
+}
+
 // Return a formatted list of available devices.
 func deviceList() string {
 	dl := device.Registered()
@@ -647,14 +998,54 @@ func validateConfig() {
 		logrus.Fatal("-mockFeature is only valid in mock mode")
 	}
 
+	if !*mock && (*mockSpec != "" || *mockJUnit != "") {
+		logrus.Fatal("-mockSpec and -mockJUnit are only valid in mock mode")
+	}
+
+	if *mockSpec != "" && len(mockFeature) > 0 {
+		logrus.Fatal("-mockSpec and -mockFeature should not be both specified")
+	}
+
+	if *mockJUnit != "" && *mockSpec == "" {
+		logrus.Fatal("-mockJUnit requires -mockSpec")
+	}
+
 	if *mock && *dump {
 		logrus.Fatal("-mock and -dump should not be both specified")
 	}
 
+	if *validate && (*mock || *dump) {
+		logrus.Fatal("-validate should not be specified with -mock or -dump")
+	}
+
+	if *validate && *deviceConfigFile == "" && *deviceType == "" {
+		logrus.Fatal("-validate requires -configFile or -device")
+	}
+
 	if *dump && *dumpFile == "" {
 		logrus.Fatal("-dumpFile must be specified in dump mode")
 	}
 
+	if *dumpFormat != dumpFormatText && *dumpFormat != dumpFormatBinary {
+		logrus.Fatal("-dumpFormat must be either 'text' or 'binary'")
+	}
+
+	if *replay && (*mock || *dump || *validate) {
+		logrus.Fatal("-replay should not be specified with -mock, -dump or -validate")
+	}
+
+	if *replay && *replayFile == "" {
+		logrus.Fatal("-replayFile must be specified in replay mode")
+	}
+
+	if *replay && *gnmiServerAddr == "" && *grpcServerAddr == "" {
+		logrus.Fatal("-replay requires -grpcServerAddr or -gnmiServerAddr")
+	}
+
+	if *replaySpeed < 0 {
+		logrus.Fatal("-replaySpeed should not be negative")
+	}
+
 	if *protoVersion != "v1" && *protoVersion != "v2" {
 		logrus.Fatal("Protocol version must be either 'v1' or 'v2'")
 	}
@@ -663,6 +1054,68 @@ func validateConfig() {
 		logrus.Fatal("-ingestServerAddr must be specified in case of sensor not running standalone")
 	}
 
+	if *persistInventory && (*grpcAddr == "" || *deviceConfigFile == "") {
+		logrus.Fatal("-persistInventory requires -grpcAddr and -configFile")
+	}
+
+	if *shardReplica != "" && *sensorName == "" {
+		logrus.Fatal("-shardReplica requires -sensor")
+	}
+
+	if *shardLeaseTTL < time.Second {
+		logrus.Fatal("-shardLeaseTTL should be at least 1 second")
+	}
+
+	if *adminAddr != "" && (*sensorName == "" || *adminTokenFile == "") {
+		logrus.Fatal("-adminAddr requires -sensor and -adminTokenFile")
+	}
+
+	if _, err := queue.ParseDropPolicy(*queueDropPolicy); err != nil {
+		logrus.Fatal(err)
+	}
+
+	if *traceExporter != "" && *traceExporter != tracing.ExporterOTLP &&
+		*traceExporter != tracing.ExporterStdout {
+		logrus.Fatal("-traceExporter must be either 'otlp' or 'stdout'")
+	}
+
+	if *yangValidation != "" {
+		if _, err := v2client.ParseValidationMode(*yangValidation); err != nil {
+			logrus.Fatal("-yangValidation must be either 'log' or 'strict'")
+		}
+		if *protoVersion != "v2" {
+			logrus.Fatal("-yangValidation requires -protoversion v2")
+		}
+		if len(yangSchemas) == 0 {
+			logrus.Fatal("-yangValidation requires -yangSchema")
+		}
+	}
+
+	if len(yangSchemas) > 0 && *yangValidation == "" {
+		logrus.Fatal("-yangSchema is only valid with -yangValidation")
+	}
+
+	if *shutdownTimeout <= 0 {
+		logrus.Fatal("-shutdownTimeout should be positive")
+	}
+
+	if *traceSampleRatio < 0 || *traceSampleRatio > 1 {
+		logrus.Fatal("-traceSampleRatio should be between 0 and 1")
+	}
+
+	if *batchMaxSize < 0 || *batchMaxLatency <= 0 {
+		logrus.Fatal("-batchMaxSize should not be negative and -batchMaxLatency should be positive")
+	}
+
+	if *queueMaxBytes < 0 || *queueMaxAge < 0 {
+		logrus.Fatal("-queueMaxBytes and -queueMaxAge should not be negative")
+	}
+
+	if info, err := os.Stat(*deviceConfigFile); *persistInventory && err == nil &&
+		info.IsDir() {
+		logrus.Fatal("-persistInventory requires -configFile to be a file")
+	}
+
 	if *metricIntervalTime < 30*time.Second {
 		logrus.Fatal("-metricIntervalTime should be at least 30 seconds or longer")
 	}
@@ -682,7 +1135,33 @@ func watchConfig(ctx context.Context, cmdDevice *device.Config, configPath strin
 
 	logrus.Infof("Monitoring %s config file for changes", configPath)
 
-	configDir, _ := filepath.Split(configPath)
+	// sources holds the paths and patterns of the files the configs
+	// are read from, and watched the directories being watched.
+	var sources []string
+	watched := map[string]bool{}
+	watchSources := func() error {
+		_, sources, _ = device.ReadConfigsWithSources(configPath)
+		for _, source := range sources {
+			// we have to watch the entire directory to pick up changes to symlinks
+			dir := filepath.Dir(source)
+			if watched[dir] {
+				continue
+			}
+			if err := watcher.Add(dir); err != nil {
+				if os.IsNotExist(err) {
+					logrus.Debugf("Not watching missing config directory %s", dir)
+					continue
+				}
+				return err
+			}
+			watched[dir] = true
+		}
+		return nil
+	}
+	if err := watchSources(); err != nil {
+		return err
+	}
+
 	group, ctx := errgroup.WithContext(ctx)
 	existingConfigs := map[string]struct{}{}
 
@@ -700,7 +1179,7 @@ func watchConfig(ctx context.Context, cmdDevice *device.Config, configPath strin
 				if !ok { // 'Events' channel is closed
 					return nil
 				}
-				if event.Name != configPath { // ignore events on other files
+				if !isConfigSource(sources, event.Name) { // ignore events on other files
 					continue
 				}
 				logrus.Debugf("Watcher event: %v", event)
@@ -710,6 +1189,10 @@ func watchConfig(ctx context.Context, cmdDevice *device.Config, configPath strin
 				// Reading no data is undesirable as it would cause a config flap.
 				_ = timer.Reset(redeployDelay)
 			case <-timer.C:
+				// Includes may have changed.
+				if err := watchSources(); err != nil {
+					return err
+				}
 				configs, err := createDeviceConfigs(cmdDevice, configPath)
 				if err != nil {
 					logrus.Errorf("Error creating device configs from watched config: %v", err)
@@ -746,21 +1229,28 @@ func watchConfig(ctx context.Context, cmdDevice *device.Config, configPath strin
 			}
 		}
 	})
-	// we have to watch the entire directory to pick up changes to symlinks
-	if err = watcher.Add(configDir); err != nil {
-		return err
-	}
 	return group.Wait()
 }
 
-func newGRPCServer(address string,
-	inventory device.Inventory) (*grpc.Server, net.Listener, error) {
+// isConfigSource returns true if path matches one of the sources
+// returned by device.ReadConfigsWithSources.
+func isConfigSource(sources []string, path string) bool {
+	for _, source := range sources {
+		if ok, _ := filepath.Match(source, path); ok || source == path {
+			return true
+		}
+	}
+	return false
+}
+
+func newGRPCServer(address string, inventory device.Inventory,
+	opts ...device.InventoryServiceOption) (*grpc.Server, net.Listener, error) {
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		return nil, nil, err
 	}
 	grpcServer := grpc.NewServer()
-	gen.RegisterDeviceInventoryServer(grpcServer, device.NewInventoryService(inventory))
+	gen.RegisterDeviceInventoryServer(grpcServer, device.NewInventoryService(inventory, opts...))
 	reflection.Register(grpcServer)
 	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	return grpcServer, listener, nil
@@ -298,3 +298,40 @@ func TestWatchConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchConfigIncludes(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.yaml")
+	if err := os.WriteFile(main, []byte("- Include: sites/*.yaml\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sites"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	configCh := make(chan *device.Config)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errg, ctx := errgroup.WithContext(ctx)
+	errg.Go(func() error {
+		defer close(configCh)
+		return watchConfig(ctx, nil, main, configCh, 30*time.Millisecond)
+	})
+	<-time.After(40 * time.Millisecond) // need to wait watcher to be ready
+
+	// Both new and changed included files are picked up.
+	for _, content := range []string{"Device: type1", "Device: type2"} {
+		if err := os.WriteFile(filepath.Join(dir, "sites", "a.yaml"),
+			[]byte("- Name: a\n  "+content+"\n"), 0666); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case cfg := <-configCh:
+			if cfg.Name != "a" || "Device: "+cfg.Device != content {
+				t.Fatalf("unexpected config %v", cfg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for config update")
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+)
+
+// localRequestProcessor processes a SetRequest made by a device in
+// place of CloudVision.
+type localRequestProcessor func(ctx context.Context, info *device.Info,
+	req *gnmi.SetRequest) (*gnmi.SetResponse, error)
+
+// runLocalDevices runs the device of the command line and those of
+// -configFile, sending their SetRequests to process rather than to
+// CloudVision, for the modes checking or recording them locally. If
+// added isn't nil, it's called with each device before it's run.
+func runLocalDevices(ctx context.Context, process localRequestProcessor,
+	added func(info *device.Info)) error {
+	// Each device gets its own gNMI client so that its SetRequests are
+	// processed knowing which device made them.
+	inventory := device.NewInventory(ctx, nil,
+		func(_ gnmi.GNMIClient, info *device.Info) cvclient.CVClient {
+			return newCVClient(pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+				req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+				return process(ctx, info, req)
+			}), info)
+		})
+	var cmdDevice *device.Config
+	if *deviceType != "" {
+		cmdDevice = &device.Config{
+			Name:     *deviceName,
+			Device:   *deviceType,
+			NoStream: *noStream,
+			Options:  deviceOptions,
+		}
+	}
+	configs, err := createDeviceConfigs(cmdDevice, *deviceConfigFile)
+	if err != nil {
+		return err
+	}
+	for _, config := range configs {
+		monitor := NewBaseMonitor(logrus.WithField("Device", config.Name))
+		info, err := device.NewDeviceInfo(ctx, config, monitor)
+		if err != nil {
+			return fmt.Errorf("error in device.NewDeviceInfo: %w", err)
+		}
+		if added != nil {
+			added(info)
+		}
+		if err := inventory.Add(info); err != nil {
+			return fmt.Errorf("error in inventory.Add: %w", err)
+		}
+	}
+	return nil
+}
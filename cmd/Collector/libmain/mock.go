@@ -8,15 +8,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"text/tabwriter"
 	"time"
 
 	"github.com/aristanetworks/cloudvision-go/device"
-	v1client "github.com/aristanetworks/cloudvision-go/device/cvclient/v1"
-	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
 	agnmi "github.com/aristanetworks/goarista/gnmi"
 	"github.com/fatih/color"
 	"github.com/openconfig/gnmi/proto/gnmi"
@@ -31,7 +31,7 @@ type mockInfo struct {
 	seenAllUpdates chan struct{}
 }
 
-func (m *mockInfo) processRequest(ctx context.Context,
+func (m *mockInfo) processRequest(ctx context.Context, info *device.Info,
 	req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -48,23 +48,48 @@ func (m *mockInfo) processRequest(ctx context.Context,
 		}()
 		return nil, nil
 	}
-	md, err := v1client.NewMetadataFromOutgoing(ctx)
-	if err != nil {
-		return nil, err
-	}
 	updates := append(req.Replace, req.Update...)
 	for _, update := range updates {
 		path := agnmi.StrPath(update.Path)
 		for _, p := range m.featureToPath {
 			if strings.HasPrefix(path, p) {
-				m.seenUpdates[md.DeviceID][p] = true
+				m.seenUpdates[info.ID][p] = true
 			}
 		}
 	}
 	return nil, nil
 }
 
-func (m *mockInfo) printResults(seenAll bool) {
+// printCapabilities prints the models reported by each device that
+// implements device.CapabilityReporter. It must be called with the
+// lock held.
+func (m *mockInfo) printCapabilities(ctx context.Context, w io.Writer) {
+	ids := make([]string, 0, len(m.idToInfo))
+	for id := range m.idToInfo {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		reporter, ok := m.idToInfo[id].Device.(device.CapabilityReporter)
+		if !ok {
+			continue
+		}
+		caps, err := reporter.Capabilities(ctx)
+		if err != nil {
+			fmt.Fprintf(w, "Capabilities of device %s: %v\n", id, err)
+			continue
+		}
+		fmt.Fprintf(w, "Capabilities of device %s:\n", id)
+		for _, c := range caps {
+			fmt.Fprintf(w, "    %s\t%s\t%s\n", c.Name, c.Organization, c.Version)
+			for _, p := range c.Paths {
+				fmt.Fprintf(w, "        %s\n", p)
+			}
+		}
+	}
+}
+
+func (m *mockInfo) printResults(ctx context.Context, seenAll bool) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	w := new(tabwriter.Writer)
@@ -92,6 +117,7 @@ func (m *mockInfo) printResults(seenAll bool) {
 			}
 		}
 	}
+	m.printCapabilities(ctx, w)
 	w.Flush()
 }
 
@@ -102,17 +128,18 @@ func (m *mockInfo) initDevice(info *device.Info) {
 	m.lock.Unlock()
 }
 
-func (m *mockInfo) waitForUpdates(errChan chan error, timeout time.Duration) error {
+func (m *mockInfo) waitForUpdates(ctx context.Context, errChan chan error,
+	timeout time.Duration) error {
 	to := time.After(timeout)
 	for {
 		select {
 		case err := <-errChan:
 			return err
 		case <-to:
-			m.printResults(false)
+			m.printResults(ctx, false)
 			return errors.New("Insufficient updates seen within timeout")
 		case <-m.seenAllUpdates:
-			m.printResults(true)
+			m.printResults(ctx, true)
 			return nil
 		}
 	}
@@ -128,39 +155,72 @@ func newMockInfo(featureToPath map[string]string) *mockInfo {
 	}
 }
 
-func runMock(ctx context.Context) {
-	mockInfo := newMockInfo(mockFeature)
-	inventory := device.NewInventory(ctx,
-		pgnmi.NewSimpleGNMIClient(mockInfo.processRequest), newCVClient)
-
-	var cmdDevice *device.Config
-	if *deviceType != "" {
-		cmdDevice = &device.Config{
-			Name:     *deviceName,
-			Device:   *deviceType,
-			NoStream: *noStream,
-			Options:  deviceOptions,
-		}
-	}
-	configs, err := createDeviceConfigs(cmdDevice, *deviceConfigFile)
+// runMockSpec runs the devices until the -mockSpec assertions have
+// passed or failed on each of them, or until -mockTimeout, and prints
+// the results and writes them to -mockJUnit. It returns an error if
+// any assertion didn't pass.
+func runMockSpec(ctx context.Context, out io.Writer) error {
+	spec, err := loadMockSpec(*mockSpec)
 	if err != nil {
-		logrus.Fatal(err)
+		return err
+	}
+	checker := newSpecChecker(spec)
+	start := time.Now()
+	if err := runLocalDevices(ctx, func(ctx context.Context, info *device.Info,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		checker.process(info.ID, req)
+		return &gnmi.SetResponse{}, nil
+	}, func(info *device.Info) {
+		checker.initDevice(info.ID)
+	}); err != nil {
+		return err
 	}
-	for _, config := range configs {
-		info, err := device.NewDeviceInfo(ctx, config, nil)
+	logrus.Infof("Mock Collector is checking %d assertions", len(spec.Assertions))
+	select {
+	case <-checker.done:
+	case <-time.After(*mockTimeout):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	results := checker.results()
+	printSpecResults(out, results)
+	if *mockJUnit != "" {
+		f, err := os.Create(*mockJUnit)
 		if err != nil {
-			logrus.Fatal(err)
+			return err
 		}
-		mockInfo.initDevice(info)
-		err = inventory.Add(info)
-		if err != nil {
-			logrus.Fatalf("Error in inventory.Add: %v", err)
+		if err := writeJUnit(f, results, time.Since(start)); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write JUnit results: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	var failures int
+	for _, dr := range results {
+		failures += dr.failures()
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d assertions failed", failures)
+	}
+	return nil
+}
+
+func runMock(ctx context.Context) {
+	if *mockSpec != "" {
+		if err := runMockSpec(ctx, os.Stdout); err != nil {
+			logrus.Fatal(err)
 		}
+		return
+	}
+	mockInfo := newMockInfo(mockFeature)
+	if err := runLocalDevices(ctx, mockInfo.processRequest, mockInfo.initDevice); err != nil {
+		logrus.Fatal(err)
 	}
 	logrus.Info("Mock Collector is running")
 	errChan := make(chan error)
-	err = mockInfo.waitForUpdates(errChan, *mockTimeout)
-	if err != nil {
+	if err := mockInfo.waitForUpdates(ctx, errChan, *mockTimeout); err != nil {
 		logrus.Fatal(err)
 	}
 }
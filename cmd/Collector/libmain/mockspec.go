@@ -0,0 +1,457 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/fatih/color"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/protobuf/proto"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// maxViolations bounds the number of invalid values reported for each
+// assertion of a device.
+const maxViolations = 5
+
+// An assertionSpec is the -mockSpec file of assertions checked against the
+// updates of each device in mock mode. For example:
+//
+//	Assertions:
+//	  - Name: interface oper-status
+//	    Path: /interfaces/interface[name=*]/state/oper-status
+//	    Type: string
+//	    Regex: ^(UP|DOWN|LOWER_LAYER_DOWN)$
+//	  - Name: LLDP neighbors
+//	    Path: /lldp/interfaces/interface/neighbors/neighbor/state/system-name
+//	  - Name: counters
+//	    Path: /interfaces/interface[name=*]/state/counters/in-octets
+//	    Type: uint
+//	    MinCount: 2
+//	    MustChange: true
+type assertionSpec struct {
+	Assertions []*mockAssertion `yaml:"Assertions"`
+}
+
+// A mockAssertion is checked against the updated leaves matching its
+// Path. In Path, * matches any element name or key value, keys that
+// aren't given match any value, and a final ... matches any elements.
+type mockAssertion struct {
+	Name string `yaml:"Name"`
+	Path string `yaml:"Path"`
+	// Type is the type the values must have, one of mockValueTypes.
+	Type string `yaml:"Type,omitempty"`
+	// Regex must match the values.
+	Regex string `yaml:"Regex,omitempty"`
+	// Min and Max bound numeric values.
+	Min *float64 `yaml:"Min,omitempty"`
+	Max *float64 `yaml:"Max,omitempty"`
+	// MinCount is the minimum number of distinct matching leaves, 1 by
+	// default.
+	MinCount int `yaml:"MinCount,omitempty"`
+	// MustChange requires the value of a matching leaf to change, as
+	// that of a counter eventually does.
+	MustChange bool `yaml:"MustChange,omitempty"`
+
+	pattern []*gnmi.PathElem
+	regex   *regexp.Regexp
+}
+
+// mockValueTypes are the types an assertion can require values to
+// have.
+var mockValueTypes = map[string]func(*gnmi.TypedValue) bool{
+	"string": isValue[*gnmi.TypedValue_StringVal],
+	"int":    isValue[*gnmi.TypedValue_IntVal],
+	"uint":   isValue[*gnmi.TypedValue_UintVal],
+	"bool":   isValue[*gnmi.TypedValue_BoolVal],
+	"float": func(v *gnmi.TypedValue) bool {
+		return isValue[*gnmi.TypedValue_FloatVal](v) ||
+			isValue[*gnmi.TypedValue_DoubleVal](v) || isValue[*gnmi.TypedValue_DecimalVal](v)
+	},
+	"bytes":    isValue[*gnmi.TypedValue_BytesVal],
+	"leaflist": isValue[*gnmi.TypedValue_LeaflistVal],
+	"json": func(v *gnmi.TypedValue) bool {
+		return isValue[*gnmi.TypedValue_JsonVal](v) || isValue[*gnmi.TypedValue_JsonIetfVal](v)
+	},
+}
+
+func isValue[T any](v *gnmi.TypedValue) bool {
+	_, ok := v.GetValue().(T)
+	return ok
+}
+
+// loadMockSpec reads and checks a -mockSpec file.
+func loadMockSpec(path string) (*assertionSpec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	spec := &assertionSpec{}
+	if err := dec.Decode(spec); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse mock spec %s: %w", path, err)
+	}
+	if len(spec.Assertions) == 0 {
+		return nil, fmt.Errorf("mock spec %s has no assertions", path)
+	}
+	names := map[string]bool{}
+	for i, a := range spec.Assertions {
+		if a.Name == "" {
+			a.Name = a.Path
+		}
+		if names[a.Name] {
+			return nil, fmt.Errorf("mock spec %s: duplicate assertion %q", path, a.Name)
+		}
+		names[a.Name] = true
+		if err := a.compile(); err != nil {
+			return nil, fmt.Errorf("mock spec %s: assertion %d (%s): %w", path, i, a.Name, err)
+		}
+	}
+	return spec, nil
+}
+
+func (a *mockAssertion) compile() error {
+	if a.Path == "" {
+		return errors.New("no path")
+	}
+	p, err := agnmi.ParseGNMIElements(agnmi.SplitPath(a.Path))
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	for i, e := range p.Elem {
+		if e.Name == "..." && i != len(p.Elem)-1 {
+			return errors.New("... is only valid at the end of the path")
+		}
+	}
+	a.pattern = p.Elem
+	if a.Type != "" {
+		if _, ok := mockValueTypes[a.Type]; !ok {
+			types := make([]string, 0, len(mockValueTypes))
+			for t := range mockValueTypes {
+				types = append(types, t)
+			}
+			sort.Strings(types)
+			return fmt.Errorf("unknown type %q, should be one of %s", a.Type,
+				strings.Join(types, ", "))
+		}
+	}
+	if a.Regex != "" {
+		if a.regex, err = regexp.Compile(a.Regex); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+	if a.Min != nil && a.Max != nil && *a.Min > *a.Max {
+		return errors.New("Min is greater than Max")
+	}
+	if a.MinCount < 0 {
+		return errors.New("MinCount should not be negative")
+	}
+	if a.MinCount == 0 {
+		a.MinCount = 1
+	}
+	return nil
+}
+
+// matches returns true if the elements of a leaf path match the path
+// of the assertion.
+func (a *mockAssertion) matches(elems []*gnmi.PathElem) bool {
+	for i, pe := range a.pattern {
+		if pe.Name == "..." {
+			return true
+		}
+		if i >= len(elems) {
+			return false
+		}
+		e := elems[i]
+		if pe.Name != "*" && pe.Name != e.Name {
+			return false
+		}
+		for k, v := range pe.Key {
+			if ev, ok := e.Key[k]; !ok || (v != "*" && v != ev) {
+				return false
+			}
+		}
+	}
+	return len(elems) == len(a.pattern)
+}
+
+// check returns why val isn't a valid value of the assertion, or "".
+func (a *mockAssertion) check(val *gnmi.TypedValue) string {
+	if a.Type != "" && !mockValueTypes[a.Type](val) {
+		return fmt.Sprintf("value %s is not of type %s", agnmi.StrVal(val), a.Type)
+	}
+	if a.regex != nil && !a.regex.MatchString(agnmi.StrVal(val)) {
+		return fmt.Sprintf("value %s does not match %s", agnmi.StrVal(val), a.Regex)
+	}
+	if a.Min == nil && a.Max == nil {
+		return ""
+	}
+	n, ok := numericValue(val)
+	if !ok {
+		return fmt.Sprintf("value %s is not numeric", agnmi.StrVal(val))
+	}
+	if (a.Min != nil && n < *a.Min) || (a.Max != nil && n > *a.Max) {
+		return fmt.Sprintf("value %s is out of range", agnmi.StrVal(val))
+	}
+	return ""
+}
+
+func numericValue(val *gnmi.TypedValue) (float64, bool) {
+	switch v := val.GetValue().(type) {
+	case *gnmi.TypedValue_IntVal:
+		return float64(v.IntVal), true
+	case *gnmi.TypedValue_UintVal:
+		return float64(v.UintVal), true
+	case *gnmi.TypedValue_FloatVal:
+		return float64(v.FloatVal), true //nolint: staticcheck
+	case *gnmi.TypedValue_DoubleVal:
+		return v.DoubleVal, true
+	case *gnmi.TypedValue_DecimalVal:
+		d := v.DecimalVal //nolint: staticcheck
+		f := float64(d.Digits)
+		for i := uint32(0); i < d.Precision; i++ {
+			f /= 10
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// assertionState is what has been seen of an assertion on a device.
+type assertionState struct {
+	// leaves holds the first value seen of each matching leaf.
+	leaves     map[string]*gnmi.TypedValue
+	changed    bool
+	violations []string
+}
+
+// result returns whether the assertion passed and why it didn't, and
+// whether that's final.
+func (s *assertionState) result(a *mockAssertion) (passed, final bool, reason string) {
+	if len(s.violations) > 0 {
+		return false, true, strings.Join(s.violations, "; ")
+	}
+	if len(s.leaves) < a.MinCount {
+		return false, false, fmt.Sprintf("%d matching leaves seen, expected at least %d",
+			len(s.leaves), a.MinCount)
+	}
+	if a.MustChange && !s.changed {
+		return false, false, "no matching leaf changed"
+	}
+	return true, true, ""
+}
+
+// specChecker checks the updates of devices against an assertionSpec.
+type specChecker struct {
+	spec *assertionSpec
+
+	lock    sync.Mutex
+	devices map[string][]*assertionState
+	// done is closed once the result of every assertion of every
+	// device is final.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func newSpecChecker(spec *assertionSpec) *specChecker {
+	return &specChecker{
+		spec:    spec,
+		devices: map[string][]*assertionState{},
+		done:    make(chan struct{}),
+	}
+}
+
+func (c *specChecker) initDevice(deviceID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	states := make([]*assertionState, len(c.spec.Assertions))
+	for i := range states {
+		states[i] = &assertionState{leaves: map[string]*gnmi.TypedValue{}}
+	}
+	c.devices[deviceID] = states
+}
+
+// process checks the updates of a SetRequest made by a device.
+func (c *specChecker) process(deviceID string, req *gnmi.SetRequest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	states, ok := c.devices[deviceID]
+	if !ok {
+		return
+	}
+	for _, updates := range [][]*gnmi.Update{req.Replace, req.Update} {
+		for _, upd := range updates {
+			elems := append(append([]*gnmi.PathElem{}, req.GetPrefix().GetElem()...),
+				upd.GetPath().GetElem()...)
+			var leaf string
+			for i, a := range c.spec.Assertions {
+				if !a.matches(elems) {
+					continue
+				}
+				if leaf == "" {
+					leaf = agnmi.StrPath(&gnmi.Path{Elem: elems})
+				}
+				c.checkUpdate(a, states[i], leaf, upd.Val)
+			}
+		}
+	}
+	for _, states := range c.devices {
+		for i, s := range states {
+			if _, final, _ := s.result(c.spec.Assertions[i]); !final {
+				return
+			}
+		}
+	}
+	c.doneOnce.Do(func() { close(c.done) })
+}
+
+func (c *specChecker) checkUpdate(a *mockAssertion, s *assertionState, leaf string,
+	val *gnmi.TypedValue) {
+	if reason := a.check(val); reason != "" {
+		if len(s.violations) < maxViolations {
+			s.violations = append(s.violations, leaf+": "+reason)
+		}
+		return
+	}
+	if first, ok := s.leaves[leaf]; !ok {
+		s.leaves[leaf] = val
+	} else if !proto.Equal(first, val) {
+		s.changed = true
+	}
+}
+
+// assertionResult is the result of an assertion on a device.
+type assertionResult struct {
+	name   string
+	passed bool
+	reason string
+}
+
+// deviceResults are the results of the assertions on a device.
+type deviceResults struct {
+	deviceID string
+	results  []assertionResult
+}
+
+func (r deviceResults) failures() int {
+	var n int
+	for _, res := range r.results {
+		if !res.passed {
+			n++
+		}
+	}
+	return n
+}
+
+// results returns the results of the assertions on each device, in
+// the order of their IDs.
+func (c *specChecker) results() []deviceResults {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ids := make([]string, 0, len(c.devices))
+	for id := range c.devices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	all := make([]deviceResults, 0, len(ids))
+	for _, id := range ids {
+		dr := deviceResults{deviceID: id}
+		for i, s := range c.devices[id] {
+			a := c.spec.Assertions[i]
+			passed, _, reason := s.result(a)
+			dr.results = append(dr.results, assertionResult{name: a.Name, passed: passed,
+				reason: reason})
+		}
+		all = append(all, dr)
+	}
+	return all
+}
+
+// printSpecResults prints the results of the assertions on each device.
+func printSpecResults(out io.Writer, results []deviceResults) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 20, 1, 8, ' ', 0)
+	for _, dr := range results {
+		fmt.Fprintf(w, "Device %s:\n", dr.deviceID)
+		for _, r := range dr.results {
+			if r.passed {
+				fmt.Fprintln(w, color.GreenString("    %s\tpassed", r.name))
+			} else {
+				fmt.Fprintln(w, color.RedString("    %s\tfailed: %s", r.name, r.reason))
+			}
+		}
+	}
+	w.Flush()
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes the results of the assertions on each device as
+// JUnit XML, with a test suite per device.
+func writeJUnit(w io.Writer, results []deviceResults, elapsed time.Duration) error {
+	suites := junitTestSuites{}
+	for _, dr := range results {
+		suite := junitTestSuite{
+			Name:     dr.deviceID,
+			Tests:    len(dr.results),
+			Failures: dr.failures(),
+			Time:     fmt.Sprintf("%.3f", elapsed.Seconds()),
+		}
+		for _, r := range dr.results {
+			tc := junitTestCase{Name: r.name, ClassName: dr.deviceID}
+			if !r.passed {
+				tc.Failure = &junitFailure{Message: r.reason, Text: r.reason}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
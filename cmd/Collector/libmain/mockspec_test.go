@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const testSpec = `
+Assertions:
+  - Name: oper-status
+    Path: /interfaces/interface[name=*]/state/oper-status
+    Type: string
+    Regex: ^(UP|DOWN)$
+  - Name: LLDP neighbors
+    Path: /lldp/interfaces/interface/neighbors/neighbor/...
+    MinCount: 2
+  - Name: in-octets
+    Path: /interfaces/interface[name=Ethernet1]/state/counters/in-octets
+    Type: uint
+    Min: 0
+    Max: 1000
+    MustChange: true
+`
+
+func writeSpec(t *testing.T, spec string) string {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMockSpec(t *testing.T) {
+	spec, err := loadMockSpec(writeSpec(t, testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Assertions) != 3 || spec.Assertions[0].MinCount != 1 {
+		t.Errorf("unexpected assertions %+v", spec.Assertions)
+	}
+	for name, tc := range map[string]struct {
+		spec string
+		err  string
+	}{
+		"empty":         {spec: "", err: "no assertions"},
+		"unknown field": {spec: "Assertions:\n  - Path: /a\n    Foo: 1\n", err: "not found"},
+		"no path":       {spec: "Assertions:\n  - Name: a\n", err: "no path"},
+		"bad type":      {spec: "Assertions:\n  - Path: /a\n    Type: int8\n", err: "unknown type"},
+		"bad regex": {spec: "Assertions:\n  - Path: /a\n    Regex: '('\n",
+			err: "invalid regex"},
+		"bad range": {spec: "Assertions:\n  - Path: /a\n    Min: 2\n    Max: 1\n",
+			err: "Min is greater than Max"},
+		"bad ellipsis": {spec: "Assertions:\n  - Path: /a/.../b\n", err: "only valid at the end"},
+		"duplicate": {spec: "Assertions:\n  - Path: /a\n  - Path: /a\n",
+			err: "duplicate assertion"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadMockSpec(writeSpec(t, tc.spec))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func update(path string, val interface{}) *gnmi.Update {
+	return pgnmi.Update(pgnmi.PathFromString(path), agnmi.TypedValue(val))
+}
+
+func TestSpecChecker(t *testing.T) {
+	spec, err := loadMockSpec(writeSpec(t, testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newSpecChecker(spec)
+	c.initDevice("dev1")
+	c.initDevice("dev2")
+	neighbor := func(intf int) string {
+		return fmt.Sprintf(
+			"/lldp/interfaces/interface[name=Ethernet%d]/neighbors/neighbor[id=1]/state/id", intf)
+	}
+
+	// Paths are relative to the prefix.
+	c.process("dev1", &gnmi.SetRequest{
+		Prefix: pgnmi.PathFromString("/interfaces/interface[name=Ethernet1]"),
+		Update: []*gnmi.Update{
+			update("/state/oper-status", "UP"),
+			update("/state/counters/in-octets", uint64(10)),
+		},
+	})
+	c.process("dev1", &gnmi.SetRequest{Update: []*gnmi.Update{
+		update(neighbor(1), "a"),
+		update(neighbor(2), "b"),
+	}})
+	select {
+	case <-c.done:
+		t.Fatal("done before counter changed and dev2 was checked")
+	default:
+	}
+	c.process("dev1", &gnmi.SetRequest{Update: []*gnmi.Update{
+		update("/interfaces/interface[name=Ethernet1]/state/counters/in-octets", uint64(20)),
+	}})
+
+	c.process("dev2", &gnmi.SetRequest{Update: []*gnmi.Update{
+		update("/interfaces/interface[name=Ethernet1]/state/oper-status", "SIDEWAYS"),
+		update("/interfaces/interface[name=Ethernet1]/state/counters/in-octets", int64(5)),
+		update("/interfaces/interface[name=Ethernet2]/state/counters/in-octets", "x"),
+		update(neighbor(1), "a"),
+	}})
+	// dev2's in-octets has a non-final result.
+	select {
+	case <-c.done:
+		t.Fatal("done before all results were final")
+	default:
+	}
+
+	results := c.results()
+	expected := []deviceResults{{
+		deviceID: "dev1",
+		results: []assertionResult{
+			{name: "oper-status", passed: true},
+			{name: "LLDP neighbors", passed: true},
+			{name: "in-octets", passed: true},
+		},
+	}, {
+		deviceID: "dev2",
+		results: []assertionResult{
+			{name: "oper-status", reason: "/interfaces/interface[name=Ethernet1]/state/" +
+				"oper-status: value SIDEWAYS does not match ^(UP|DOWN)$"},
+			{name: "LLDP neighbors",
+				reason: "1 matching leaves seen, expected at least 2"},
+			{name: "in-octets", reason: "/interfaces/interface[name=Ethernet1]/state/" +
+				"counters/in-octets: value 5 is not of type uint"},
+		},
+	}}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, results)
+	}
+	for i := range expected {
+		if results[i].deviceID != expected[i].deviceID {
+			t.Fatalf("expected %v, got %v", expected, results)
+		}
+		for j, r := range expected[i].results {
+			if results[i].results[j] != r {
+				t.Errorf("device %s: expected %+v, got %+v", expected[i].deviceID, r,
+					results[i].results[j])
+			}
+		}
+	}
+
+	c.process("dev2", &gnmi.SetRequest{Update: []*gnmi.Update{
+		update(neighbor(2), "b"),
+	}})
+	select {
+	case <-c.done:
+	default:
+		t.Error("not done after all results were final")
+	}
+
+	var out bytes.Buffer
+	printSpecResults(&out, results)
+	if !strings.Contains(out.String(), "Device dev2:") ||
+		!strings.Contains(out.String(), "failed: 1 matching leaves seen") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results := []deviceResults{{
+		deviceID: "dev1",
+		results: []assertionResult{
+			{name: "a", passed: true},
+			{name: "b", reason: "value <x> is out of range"},
+		},
+	}}
+	var buf bytes.Buffer
+	if err := writeJUnit(&buf, results, 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("invalid XML %s: %v", buf.String(), err)
+	}
+	if len(suites.Suites) != 1 {
+		t.Fatalf("expected 1 test suite, got %+v", suites)
+	}
+	s := suites.Suites[0]
+	if s.Name != "dev1" || s.Tests != 2 || s.Failures != 1 || s.Time != "1.500" {
+		t.Errorf("unexpected test suite %+v", s)
+	}
+	if len(s.Cases) != 2 || s.Cases[0].Failure != nil || s.Cases[1].Failure == nil ||
+		s.Cases[1].Failure.Message != "value <x> is out of range" {
+		t.Errorf("unexpected test cases %+v", s.Cases)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/sirupsen/logrus"
+)
+
+// prometheusNamespace prefixes the names of the metrics served at
+// /metrics on the monitor server.
+const prometheusNamespace = "cvsensor_"
+
+// prometheusMetrics is a device.MetricTracker serving its metrics, the
+// metrics of the sensor's datasources and the sensor's runtime stats
+// in the Prometheus text exposition format.
+type prometheusMetrics struct {
+	sensor atomic.Pointer[device.Sensor]
+
+	lock        sync.Mutex
+	datasources int64
+	// errors is keyed by datasource type and error type.
+	errors      map[[2]string]int64
+	deploys     map[string]int64
+	restarts    map[string]int64
+	quarantines map[string]int64
+}
+
+func newPrometheusMetrics() *prometheusMetrics {
+	return &prometheusMetrics{
+		errors:      map[[2]string]int64{},
+		deploys:     map[string]int64{},
+		restarts:    map[string]int64{},
+		quarantines: map[string]int64{},
+	}
+}
+
+// setSensor sets the sensor whose datasource metrics are served.
+func (p *prometheusMetrics) setSensor(sensor *device.Sensor) {
+	if p != nil {
+		p.sensor.Store(sensor)
+	}
+}
+
+func (p *prometheusMetrics) TrackDatasources(ctx context.Context, numDatasource int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.datasources += int64(numDatasource)
+}
+
+func (p *prometheusMetrics) TrackDatasourceErrors(ctx context.Context, typ string,
+	errorType string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.errors[[2]string{typ, errorType}]++
+}
+
+func (p *prometheusMetrics) TrackDatasourceDeploys(ctx context.Context, typ string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.deploys[typ]++
+}
+
+func (p *prometheusMetrics) TrackDatasourceRestarts(ctx context.Context, typ string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.restarts[typ]++
+}
+
+func (p *prometheusMetrics) TrackDatasourceQuarantines(ctx context.Context, typ string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.quarantines[typ]++
+}
+
+// promSample is a sample of a Prometheus metric.
+type promSample struct {
+	labels [][2]string
+	value  any
+}
+
+// promFamily is a Prometheus metric with its samples.
+type promFamily struct {
+	name, help, typ string
+	samples         []promSample
+}
+
+// promName turns s into a valid Prometheus metric name.
+func promName(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if !(c == '_' || c == ':' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' ||
+			'0' <= c && c <= '9' && i > 0) {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+var (
+	promHelpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	promLabelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func (f *promFamily) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, promHelpEscaper.Replace(f.help),
+		f.name, f.typ)
+	for _, s := range f.samples {
+		fmt.Fprint(w, f.name)
+		if len(s.labels) > 0 {
+			labels := make([]string, len(s.labels))
+			for i, l := range s.labels {
+				labels[i] = fmt.Sprintf(`%s="%s"`, l[0], promLabelEscaper.Replace(l[1]))
+			}
+			fmt.Fprintf(w, "{%s}", strings.Join(labels, ","))
+		}
+		fmt.Fprintf(w, " %v\n", s.value)
+	}
+}
+
+// counterFamily returns a counter family with one sample per type.
+func counterFamily(name, help string, counts map[string]int64) *promFamily {
+	f := &promFamily{name: prometheusNamespace + name, help: help, typ: "counter"}
+	types := make([]string, 0, len(counts))
+	for typ := range counts {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		f.samples = append(f.samples, promSample{
+			labels: [][2]string{{"type", typ}},
+			value:  counts[typ],
+		})
+	}
+	return f
+}
+
+// trackerFamilies returns the metrics tracked as a MetricTracker.
+func (p *prometheusMetrics) trackerFamilies() []*promFamily {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	errors := &promFamily{
+		name: prometheusNamespace + "datasource_errors_total",
+		help: "Errors encountered by datasources, by datasource type and error type.",
+		typ:  "counter",
+	}
+	keys := make([][2]string, 0, len(p.errors))
+	for key := range p.errors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		errors.samples = append(errors.samples, promSample{
+			labels: [][2]string{{"type", key[0]}, {"error_type", key[1]}},
+			value:  p.errors[key],
+		})
+	}
+	return []*promFamily{{
+		name:    prometheusNamespace + "datasources",
+		help:    "Datasources of the sensor.",
+		typ:     "gauge",
+		samples: []promSample{{value: p.datasources}},
+	}, errors,
+		counterFamily("datasource_deploys_total", "Deploys of datasources, by type.",
+			p.deploys),
+		counterFamily("datasource_restarts_total", "Restarts of datasources, by type.",
+			p.restarts),
+		counterFamily("datasource_quarantines_total",
+			"Quarantines of crash looping datasources, by type.", p.quarantines),
+	}
+}
+
+// metricHelp returns the help text of a metric.
+func metricHelp(m device.Metric) string {
+	if m.Unit == "" {
+		return m.Description
+	}
+	return fmt.Sprintf("%s (%s)", m.Description, m.Unit)
+}
+
+// datasourceFamilies mirrors the metrics of datasources, labelled
+// with the name and type of their datasource. String metrics become
+// info metrics, with their value as a label.
+func datasourceFamilies(metrics []device.DatasourceMetrics) []*promFamily {
+	families := map[string]*promFamily{}
+	for _, ds := range metrics {
+		for _, m := range ds.Metrics {
+			labels := [][2]string{{"datasource", ds.Name}, {"type", ds.Type}}
+			name := prometheusNamespace + "datasource_" + promName(m.Name)
+			var value any
+			switch v := m.Value.(type) {
+			case int64, float64:
+				value = v
+			case string:
+				name += "_info"
+				labels = append(labels, [2]string{"value", v})
+				value = 1
+			default:
+				continue
+			}
+			f, ok := families[name]
+			if !ok {
+				f = &promFamily{name: name, help: metricHelp(m), typ: "gauge"}
+				families[name] = f
+			}
+			f.samples = append(f.samples, promSample{labels: labels, value: value})
+		}
+	}
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sorted := make([]*promFamily, len(names))
+	for i, name := range names {
+		sorted[i] = families[name]
+	}
+	return sorted
+}
+
+func (p *prometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed)
+		return
+	}
+	families := p.trackerFamilies()
+	for _, m := range device.SensorPodMetrics() {
+		families = append(families, &promFamily{
+			name:    prometheusNamespace + promName(strings.TrimPrefix(m.Name, "sensor_")),
+			help:    metricHelp(m),
+			typ:     "gauge",
+			samples: []promSample{{value: m.Value}},
+		})
+	}
+	if sensor := p.sensor.Load(); sensor != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+		metrics, err := sensor.DatasourceMetrics(ctx)
+		if err != nil {
+			logrus.Errorf("Failed to get datasource metrics: %v", err)
+		}
+		families = append(families, datasourceFamilies(metrics)...)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	if err := bw.Flush(); err != nil {
+		logrus.Debugf("Failed to write metrics: %v", err)
+	}
+}
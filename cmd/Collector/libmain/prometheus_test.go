@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	ctx := context.Background()
+	p := newPrometheusMetrics()
+	var _ device.MetricTracker = p
+	p.TrackDatasources(ctx, 2)
+	p.TrackDatasources(ctx, -1)
+	p.TrackDatasourceErrors(ctx, "snmp", "timeout")
+	p.TrackDatasourceErrors(ctx, "snmp", "timeout")
+	p.TrackDatasourceDeploys(ctx, "gnmi")
+	p.TrackDatasourceRestarts(ctx, "snmp")
+	p.TrackDatasourceQuarantines(ctx, "snmp")
+
+	server := httptest.NewServer(p)
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(b)
+	for _, expected := range []string{
+		"# TYPE cvsensor_datasources gauge\ncvsensor_datasources 1\n",
+		`cvsensor_datasource_errors_total{type="snmp",error_type="timeout"} 2` + "\n",
+		`cvsensor_datasource_deploys_total{type="gnmi"} 1` + "\n",
+		`cvsensor_datasource_restarts_total{type="snmp"} 1` + "\n",
+		`cvsensor_datasource_quarantines_total{type="snmp"} 1` + "\n",
+		"# TYPE cvsensor_go_routines gauge\n",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected %q in metrics:\n%s", expected, body)
+		}
+	}
+
+	families := datasourceFamilies([]device.DatasourceMetrics{{
+		Name: "dev1",
+		Type: "snmp",
+		Metrics: []device.Metric{
+			{Name: "poll-time", Unit: "ms", Description: "poll time", Value: int64(5)},
+			{Name: "state", Description: "state", Value: "up"},
+			{Name: "unset", Description: "never set"},
+		},
+	}, {
+		Name:    "dev2",
+		Type:    "snmp",
+		Metrics: []device.Metric{{Name: "poll-time", Unit: "ms", Value: 1.5}},
+	}})
+	var sb strings.Builder
+	for _, f := range families {
+		f.write(&sb)
+	}
+	expected := `# HELP cvsensor_datasource_poll_time poll time (ms)
+# TYPE cvsensor_datasource_poll_time gauge
+cvsensor_datasource_poll_time{datasource="dev1",type="snmp"} 5
+cvsensor_datasource_poll_time{datasource="dev2",type="snmp"} 1.5
+# HELP cvsensor_datasource_state_info state
+# TYPE cvsensor_datasource_state_info gauge
+cvsensor_datasource_state_info{datasource="dev1",type="snmp",value="up"} 1
+`
+	if sb.String() != expected {
+		t.Errorf("expected datasource metrics:\n%s\ngot:\n%s", expected, sb.String())
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"expvar" // serves /debug/vars on the monitor server
+	"fmt"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/queue"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// storeQueue is the store-and-forward queue the SetRequests of
+// devices go through, if -queueDir is set.
+var storeQueue *queue.Queue
+
+// expvarMetricCollector is a MetricCollector publishing its metrics
+// as expvars, which the -monitorAddr server serves at /debug/vars.
+type expvarMetricCollector struct {
+	vars *expvar.Map
+}
+
+func newExpvarMetricCollector(name string) *expvarMetricCollector {
+	return &expvarMetricCollector{vars: expvar.NewMap(name)}
+}
+
+func (m *expvarMetricCollector) SetMetricString(name string, value string) error {
+	v := new(expvar.String)
+	v.Set(value)
+	m.vars.Set(name, v)
+	return nil
+}
+
+func (m *expvarMetricCollector) SetMetricFloat(name string, value float64) error {
+	v := new(expvar.Float)
+	v.Set(value)
+	m.vars.Set(name, v)
+	return nil
+}
+
+func (m *expvarMetricCollector) SetMetricInt(name string, value int64) error {
+	v := new(expvar.Int)
+	v.Set(value)
+	m.vars.Set(name, v)
+	return nil
+}
+
+func (m *expvarMetricCollector) IncMetricInt(name string, value int64) error {
+	m.vars.Add(name, value)
+	return nil
+}
+
+func (m *expvarMetricCollector) CreateMetric(name string, valueUnit string,
+	description string) error {
+	return nil
+}
+
+// openStoreQueue opens the -queueDir store-and-forward queue in front
+// of gnmiClient.
+func openStoreQueue(gnmiClient gnmi.GNMIClient) (*queue.Queue, error) {
+	policy, err := queue.ParseDropPolicy(*queueDropPolicy)
+	if err != nil {
+		return nil, err
+	}
+	q, err := queue.Open(*queueDir, gnmiClient,
+		queue.WithMaxBytes(*queueMaxBytes),
+		queue.WithMaxAge(*queueMaxAge),
+		queue.WithDropPolicy(policy),
+		queue.WithMetricCollector(newExpvarMetricCollector("storeForwardQueue")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store-and-forward queue: %w", err)
+	}
+	return q, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/record"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+)
+
+// runReplay sends the SetRequests of the -replayFile recording, made
+// with -dump -dumpFormat binary, to the gNMI server at -replaySpeed.
+func runReplay(ctx context.Context) error {
+	f, err := os.Open(*replayFile)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	gnmiCfg := newGNMIConfig()
+	logrus.Infof("Connecting to gNMI server %+v", gnmiCfg)
+	conn, err := agnmi.DialContextConn(ctx, gnmiCfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	gnmiClient := gnmi.NewGNMIClient(conn)
+	waitForGNMIConnectivity(gnmiClient)
+	logrus.Info("Connected to gNMI service")
+
+	speed := "maximum speed"
+	if *replaySpeed > 0 {
+		speed = fmt.Sprintf("%gx speed", *replaySpeed)
+	}
+	logrus.Infof("Replaying %s at %s", *replayFile, speed)
+	start := time.Now()
+	n, err := record.Replay(ctx, record.NewReader(f), gnmiClient, *replaySpeed)
+	if err != nil {
+		return fmt.Errorf("replay stopped after %d SetRequests: %w", n, err)
+	}
+	logrus.Infof("Replayed %d SetRequests in %v", n, time.Since(start))
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/sirupsen/logrus"
+)
+
+// drainer is shut down gracefully on SIGTERM and SIGINT.
+type drainer interface {
+	Shutdown(ctx context.Context) error
+}
+
+// drain shuts the sensor, if there's one, and the inventory down
+// gracefully within -shutdownTimeout.
+func drain(sensor *device.Sensor, inventory device.Inventory) error {
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	var drainers []drainer
+	if sensor != nil {
+		drainers = append(drainers, sensor)
+	}
+	if d, ok := inventory.(device.InventoryDrainer); ok {
+		drainers = append(drainers, d)
+	}
+	var err error
+	for _, d := range drainers {
+		err = errors.Join(err, d.Shutdown(ctx))
+	}
+	return err
+}
+
+// handleShutdown drains the current sensor and the inventory on
+// SIGTERM or SIGINT before calling cancel, which stops everything
+// else. A second signal calls cancel right away. The returned channel
+// gets the result of the drain before cancel is called.
+func handleShutdown(ctx context.Context, cancel context.CancelFunc,
+	sensor func() *device.Sensor, inventory device.Inventory) <-chan error {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	drained := make(chan error, 1)
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case sig := <-signals:
+			logrus.Infof("Got %v, shutting down within %v", sig, *shutdownTimeout)
+		case <-ctx.Done():
+			return
+		}
+		go func() {
+			select {
+			case sig := <-signals:
+				logrus.Warnf("Got %v again, stopping without draining", sig)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := drain(sensor(), inventory)
+		if err != nil {
+			logrus.Errorf("Shutdown did not complete: %v", err)
+		} else {
+			logrus.Info("Shutdown complete")
+		}
+		drained <- err
+		cancel()
+	}()
+	return drained
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/tracing"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTracing exports traces as configured by the -trace flags, if
+// -traceExporter is set, and returns a function flushing them.
+func setupTracing(ctx context.Context) func() {
+	if *traceExporter == "" {
+		return func() {}
+	}
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    *traceExporter,
+		Endpoint:    *traceEndpoint,
+		Insecure:    *traceInsecure,
+		SampleRatio: *traceSampleRatio,
+	})
+	if err != nil {
+		logrus.Fatalf("Failed to set up tracing: %v", err)
+	}
+	logrus.Infof("Exporting traces with %s exporter", *traceExporter)
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logrus.Errorf("Failed to flush traces: %v", err)
+		}
+	}
+}
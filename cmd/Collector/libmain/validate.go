@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxConcurrentValidations bounds the number of devices created at
+// once in -validateDevices mode.
+const maxConcurrentValidations = 16
+
+// validationResult is the result of validating a device config.
+type validationResult struct {
+	config *device.Config
+	// err is why the config is invalid, if it is.
+	err error
+	// detail describes a valid config.
+	detail string
+}
+
+// isRegistered returns true if the device type is registered.
+func isRegistered(deviceType string) bool {
+	for _, name := range device.Registered() {
+		if name == deviceType {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDeviceConfig checks the options of a device config against
+// its device type and, if createDevice is set, creates the device and
+// checks its ID and that it's alive within timeout. It never starts
+// the device's providers. The device check is added to checks, which
+// waits for it to return even if it timed out.
+func validateDeviceConfig(ctx context.Context, config *device.Config, createDevice bool,
+	timeout time.Duration, checks *sync.WaitGroup) validationResult {
+	result := validationResult{config: config, detail: "options valid"}
+	if !isRegistered(config.Device) {
+		result.err = fmt.Errorf("device type '%s' is not registered", config.Device)
+		return result
+	}
+	if err := device.ValidateOptions(config); err != nil {
+		result.err = err
+		return result
+	}
+	if !createDevice {
+		return result
+	}
+
+	// Devices don't always honor their context, so give up on the
+	// ones that hang, and cancel their context once given up on.
+	checkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan validationResult, 1)
+	checks.Add(1)
+	go func(result validationResult) {
+		defer checks.Done()
+		done <- checkDevice(checkCtx, result)
+	}(result)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case result = <-done:
+	case <-timer.C:
+		result.err = fmt.Errorf("timed out after %v creating and checking device", timeout)
+	case <-ctx.Done():
+		result.err = ctx.Err()
+	}
+	return result
+}
+
+// waitForChecks waits for the device checks that timed out to return
+// now that their context is canceled, for at most timeout, so that
+// they don't outlive validation unless they hang regardless.
+func waitForChecks(checks *sync.WaitGroup, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		checks.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		logrus.Warnf("Device checks still running %v after timing out", timeout)
+	}
+}
+
+// checkDevice creates the device of a valid config, checks it, and
+// closes it.
+func checkDevice(ctx context.Context, result validationResult) validationResult {
+	monitor := NewBaseMonitor(logrus.WithField("Device", result.config.Name))
+	info, err := device.NewDeviceInfo(ctx, result.config, monitor)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer func() {
+		if err := device.CloseDevice(ctx, info.Device); err != nil {
+			logrus.Errorf("Error closing device %s: %v", result.config.Name, err)
+		}
+	}()
+	alive, err := info.Device.Alive(ctx)
+	if err == nil && !alive {
+		err = errors.New("device is not alive")
+	}
+	if err != nil {
+		result.err = fmt.Errorf("device ID %s: %w", info.ID, err)
+		return result
+	}
+	result.detail = fmt.Sprintf("device ID %s, alive", info.ID)
+	return result
+}
+
+// writeValidationResults writes a table of validation results to w
+// and returns the number of invalid configs.
+func writeValidationResults(w io.Writer, results []validationResult) (int, error) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDEVICE\tRESULT\tDETAIL")
+	failed := 0
+	for _, r := range results {
+		status, detail := "PASS", r.detail
+		if r.err != nil {
+			status, detail = "FAIL", r.err.Error()
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.config.Name, r.config.Device, status, detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return failed, err
+	}
+	_, err := fmt.Fprintf(w, "%d of %d device configs valid\n",
+		len(results)-failed, len(results))
+	return failed, err
+}
+
+// runValidate validates the device configs specified with -configFile
+// or -device, writes the results to w, and returns true if they're
+// all valid.
+func runValidate(ctx context.Context, w io.Writer) bool {
+	var cmdDevice *device.Config
+	if *deviceType != "" {
+		cmdDevice = &device.Config{
+			Name:     *deviceName,
+			Device:   *deviceType,
+			NoStream: *noStream,
+			Options:  deviceOptions,
+		}
+	}
+	configs, err := readDeviceConfigs(cmdDevice, *deviceConfigFile, false)
+	if err != nil {
+		fmt.Fprintf(w, "Invalid device configs: %v\n", err)
+		return false
+	}
+
+	results := make([]validationResult, len(configs))
+	var checks sync.WaitGroup
+	group := errgroup.Group{}
+	group.SetLimit(maxConcurrentValidations)
+	for i, config := range configs {
+		i, config := i, config
+		group.Go(func() error {
+			results[i] = validateDeviceConfig(ctx, config, *validateDevices,
+				*validateTimeout, &checks)
+			return nil
+		})
+	}
+	group.Wait()
+	waitForChecks(&checks, *validateTimeout)
+
+	failed, err := writeValidationResults(w, results)
+	if err != nil {
+		logrus.Errorf("Failed to write validation results: %v", err)
+		return false
+	}
+	return failed == 0
+}
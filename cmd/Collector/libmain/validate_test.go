@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/provider"
+)
+
+// validateTestDevice is alive unless its state option says otherwise,
+// and hangs until its context is canceled if it's "hung".
+type validateTestDevice struct {
+	testDevice
+	state string
+}
+
+func (d validateTestDevice) Alive(ctx context.Context) (bool, error) {
+	if d.state == "hung" {
+		<-ctx.Done()
+		return false, ctx.Err()
+	}
+	return d.state != "dead", nil
+}
+
+func newValidateTestDevice(ctx context.Context, opts map[string]string,
+	monitor provider.Monitor) (device.Device, error) {
+	return validateTestDevice{state: opts["state"]}, nil
+}
+
+func TestValidate(t *testing.T) {
+	device.Register("validatetest", newValidateTestDevice, map[string]device.Option{
+		"state": {
+			Description: "device state",
+			Type:        device.OptionTypeEnum,
+			Values:      []string{"ok", "dead", "hung"},
+			Default:     "ok",
+		},
+	})
+	defer device.Unregister("validatetest")
+
+	configFile := filepath.Join(t.TempDir(), "configs.yaml")
+	if err := os.WriteFile(configFile, []byte(`
+- Name: good
+  Device: validatetest
+- Name: dead
+  Device: validatetest
+  Options:
+    state: dead
+- Name: hung
+  Device: validatetest
+  Options:
+    state: hung
+- Name: typo
+  Device: validatetest
+  Options:
+    state: gone
+- Name: unknown
+  Device: nosuchdevice
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	empty, no := "", false
+	timeout := 100 * time.Millisecond
+	deviceType, deviceConfigFile, noStream, validateTimeout = &empty, &configFile, &no, &timeout
+	for _, tc := range []struct {
+		createDevices bool
+		expect        []string
+	}{{
+		createDevices: false,
+		expect: []string{
+			"NAME     DEVICE        RESULT  DETAIL",
+			"good     validatetest  PASS    options valid",
+			"dead     validatetest  PASS    options valid",
+			"hung     validatetest  PASS    options valid",
+			"typo     validatetest  FAIL    Config 'typo' for device 'validatetest': " +
+				"Value for option 'state' ('gone') is not one of ok, dead, hung",
+			"unknown  nosuchdevice  FAIL    device type 'nosuchdevice' is not registered",
+			"3 of 5 device configs valid",
+		},
+	}, {
+		createDevices: true,
+		expect: []string{
+			"NAME     DEVICE        RESULT  DETAIL",
+			"good     validatetest  PASS    device ID aaa, alive",
+			"dead     validatetest  FAIL    device ID aaa: device is not alive",
+			"hung     validatetest  FAIL    timed out after 100ms creating and checking device",
+			"typo     validatetest  FAIL    Config 'typo' for device 'validatetest': " +
+				"Value for option 'state' ('gone') is not one of ok, dead, hung",
+			"unknown  nosuchdevice  FAIL    device type 'nosuchdevice' is not registered",
+			"1 of 5 device configs valid",
+		},
+	}} {
+		validateDevices = &tc.createDevices
+		var out bytes.Buffer
+		if runValidate(context.Background(), &out) {
+			t.Error("expected validation to fail")
+		}
+		expect := strings.Join(tc.expect, "\n") + "\n"
+		if out.String() != expect {
+			t.Errorf("expected output:\n%s\ngot:\n%s", expect, out.String())
+		}
+	}
+
+	// The command-line device is validated too.
+	deviceType, deviceConfigFile = &[]string{"validatetest"}[0], &empty
+	name := "cmd-device"
+	deviceName = &name
+	deviceOptions = map[string]string{}
+	var out bytes.Buffer
+	if !runValidate(context.Background(), &out) ||
+		!strings.Contains(out.String(), "1 of 1 device configs valid") {
+		t.Errorf("expected valid command-line device, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package libmain
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	v2client "github.com/aristanetworks/cloudvision-go/device/cvclient/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// yangValidator checks the SetRequests of v2 clients against the
+// -yangSchema schemas, if -yangValidation is set.
+var yangValidator *v2client.Validator
+
+// newYANGValidator compiles the -yangSchema schemas into a Validator
+// working in the -yangValidation mode.
+func newYANGValidator() (*v2client.Validator, error) {
+	mode, err := v2client.ParseValidationMode(*yangValidation)
+	if err != nil {
+		return nil, err
+	}
+	origins := make([]string, 0, len(yangSchemas))
+	for origin := range yangSchemas {
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+	schemas := map[string]*v2client.Schema{}
+	for _, origin := range origins {
+		schema, err := v2client.CompileSchema(filepath.SplitList(yangSchemas[origin])...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile schema of origin %q: %w", origin, err)
+		}
+		schemas[origin] = schema
+		logrus.Infof("Validating updates of origin %q in %s mode", origin, *yangValidation)
+	}
+	return v2client.NewValidator(mode, schemas), nil
+}
+
+// setupYANGValidation sets yangValidator up if -yangValidation is set.
+func setupYANGValidation() {
+	if *yangValidation == "" {
+		return
+	}
+	var err error
+	if yangValidator, err = newYANGValidator(); err != nil {
+		logrus.Fatal(err)
+	}
+}
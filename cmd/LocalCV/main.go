@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// LocalCV is a local stand-in for the CloudVision ingest gNMI server,
+// to run the Collector against on a laptop:
+//
+//	LocalCV -addr localhost:6030 -sensor local -configFile devices.yaml
+//	Collector -grpcServerAddr localhost:6030 -sensor local
+//
+// It takes the Sets of v1 and v2 clients, keeps the state of each
+// device, which can be read back with Get and Subscribe, and serves
+// the datasource configs of the sensor from -configFile, which is
+// reloaded on SIGHUP.
+package main
+
+import (
+	"flag"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aristanetworks/cloudvision-go/cmd/LocalCV/server"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+var (
+	addr       = flag.String("addr", "localhost:6030", "Address to serve gNMI on")
+	sensorName = flag.String("sensor", "",
+		"Name of the sensor to serve the datasource configs of -configFile to")
+	configFile = flag.String("configFile", "",
+		"Path to the config file for devices, or to a directory of *.yaml config files")
+	originalTimestamps = flag.Bool("originalTimestamps", false,
+		"Timestamp the SetRequests replayed from a Collector -queueDir with the time "+
+			"they were made, rather than the time they're received like CloudVision does")
+	logLevel = flag.String("logLevel", "info", "Log level verbosity "+
+		"(available levels: trace, debug, info, warning, error, fatal, panic)")
+)
+
+func main() {
+	flag.Parse()
+	level, err := logrus.ParseLevel(*logLevel)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.SetLevel(level)
+	if (*sensorName == "") != (*configFile == "") {
+		logrus.Fatal("-sensor and -configFile must be given together")
+	}
+
+	var opts []server.Option
+	if *originalTimestamps {
+		opts = append(opts, server.WithOriginalTimestamps())
+	}
+	s := server.New(opts...)
+	if *configFile != "" {
+		configs := server.NewSensorConfigs(s, *sensorName)
+		if err := configs.Load(*configFile); err != nil {
+			logrus.Fatal(err)
+		}
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				if err := configs.Load(*configFile); err != nil {
+					logrus.Errorf("Failed to reload %s: %v", *configFile, err)
+				}
+			}
+		}()
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	gnmi.RegisterGNMIServer(grpcServer, s)
+	logrus.Infof("Serving gNMI on %s", listener.Addr())
+	if err := grpcServer.Serve(listener); err != nil {
+		logrus.Fatal(err)
+	}
+}
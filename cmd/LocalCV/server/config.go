@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// The target and origin of the datasource configs and states of
+// sensors.
+const (
+	sensorTarget = "cv"
+	sensorOrigin = "arista"
+)
+
+// SensorConfigs publishes the datasource configs of a sensor, read from
+// a file of device configs, where the sensor subscribes to them.
+type SensorConfigs struct {
+	server *Server
+	sensor string
+	// leaves are the leaves of each datasource last published.
+	leaves map[string]map[string]*gnmi.Update
+}
+
+// NewSensorConfigs returns a SensorConfigs publishing the datasource
+// configs of sensor to server.
+func NewSensorConfigs(server *Server, sensor string) *SensorConfigs {
+	return &SensorConfigs{
+		server: server,
+		sensor: sensor,
+		leaves: map[string]map[string]*gnmi.Update{},
+	}
+}
+
+// Load reads the device configs of path and publishes them as the
+// datasource configs of the sensor. As with the Collector's -configFile,
+// the datasources are all enabled. Only the changes since the last Load
+// are published, so the sensor only restarts the datasources changed.
+func (c *SensorConfigs) Load(path string) error {
+	configs, err := device.ReadConfigsUnchecked(path)
+	if err != nil {
+		return err
+	}
+	leaves := map[string]map[string]*gnmi.Update{}
+	for _, cfg := range configs {
+		if cfg.Name == "" {
+			return fmt.Errorf("datasource config of type %q has no name", cfg.Device)
+		}
+		if _, ok := leaves[cfg.Name]; ok {
+			return fmt.Errorf("duplicate datasource config %q", cfg.Name)
+		}
+		leaves[cfg.Name] = c.datasourceLeaves(cfg)
+	}
+
+	var deletes []*gnmi.Path
+	for name, old := range c.leaves {
+		cur, ok := leaves[name]
+		if !ok {
+			// The sensor removes a datasource when its source is
+			// deleted, rather than its leaves.
+			deletes = append(deletes, c.path(pgnmi.ListWithKey("source", "name", name)))
+			continue
+		}
+		for k, upd := range old {
+			if _, ok := cur[k]; !ok {
+				deletes = append(deletes, upd.Path)
+			}
+		}
+	}
+	// The id of the sensor creates it.
+	updates := []*gnmi.Update{pgnmi.Update(c.path("id"), agnmi.TypedValue(c.sensor))}
+	for name, cur := range leaves {
+		old := c.leaves[name]
+		for k, upd := range cur {
+			if o, ok := old[k]; !ok || !proto.Equal(o.Val, upd.Val) {
+				updates = append(updates, upd)
+			}
+		}
+	}
+	sort.Slice(deletes, func(i, j int) bool {
+		return agnmi.StrPath(deletes[i]) < agnmi.StrPath(deletes[j])
+	})
+	sort.Slice(updates, func(i, j int) bool {
+		return agnmi.StrPath(updates[i].Path) < agnmi.StrPath(updates[j].Path)
+	})
+
+	logrus.Infof("Loaded %d datasource configs of sensor %s from %s: %d deletes, %d updates",
+		len(configs), c.sensor, path, len(deletes), len(updates)-1)
+	c.server.Publish(sensorTarget, sensorOrigin, deletes, updates)
+	c.leaves = leaves
+	return nil
+}
+
+// path returns the path of a config element of the sensor.
+func (c *SensorConfigs) path(element ...string) *gnmi.Path {
+	prefix := []string{"datasource", "config", pgnmi.ListWithKey("sensor", "id", c.sensor)}
+	return pgnmi.Path(append(prefix, element...)...)
+}
+
+// datasourceLeaves returns the leaves of the datasource config of cfg,
+// by path.
+func (c *SensorConfigs) datasourceLeaves(cfg *device.Config) map[string]*gnmi.Update {
+	source := pgnmi.ListWithKey("source", "name", cfg.Name)
+	leaves := map[string]*gnmi.Update{}
+	add := func(val interface{}, element ...string) {
+		p := c.path(append([]string{source}, element...)...)
+		leaves[agnmi.StrPath(p)] = pgnmi.Update(p, agnmi.TypedValue(val))
+	}
+	logLevel := cfg.LogLevel
+	if logLevel == "" {
+		logLevel = "LOG_LEVEL_INFO"
+	}
+	add(cfg.Device, "type")
+	add(true, "enabled")
+	add(logLevel, "log-level")
+	add(cfg.ForceUpdate, "force-update")
+	for k, v := range cfg.Options {
+		add(v, pgnmi.ListWithKey("option", "key", k), "value")
+	}
+	for k, v := range cfg.Credentials {
+		add(v, pgnmi.ListWithKey("credential", "key", k), "value")
+	}
+	return leaves
+}
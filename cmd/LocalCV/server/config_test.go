@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	v2 "github.com/aristanetworks/cloudvision-go/device/cvclient/v2"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func writeConfigs(t *testing.T, path, configs string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(configs), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSensorConfigs(t *testing.T) {
+	s := New()
+	c := NewSensorConfigs(s, "local")
+	path := filepath.Join(t.TempDir(), "configs.yaml")
+	writeConfigs(t, path, `
+- Name: ds1
+  Device: test
+  Options:
+    a: "1"
+    b: "2"
+- Name: ds2
+  Device: test
+  LogLevel: LOG_LEVEL_DEBUG
+`)
+	if err := c.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	root := "/datasource/config/sensor[id=local]"
+	ds1 := root + "/source[name=ds1]"
+	ds2 := root + "/source[name=ds2]"
+	configs := func() map[string]string {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		n := s.snapshot(treeKey{target: sensorTarget, origin: sensorOrigin}, nil)
+		return leaves([]*gnmi.Notification{n})
+	}
+	checkLeaves(t, map[string]string{
+		root + "/id":                 "local",
+		ds1 + "/type":                "test",
+		ds1 + "/enabled":             "true",
+		ds1 + "/log-level":           "LOG_LEVEL_INFO",
+		ds1 + "/force-update":        "0",
+		ds1 + "/option[key=a]/value": "1",
+		ds1 + "/option[key=b]/value": "2",
+		ds2 + "/type":                "test",
+		ds2 + "/enabled":             "true",
+		ds2 + "/log-level":           "LOG_LEVEL_DEBUG",
+		ds2 + "/force-update":        "0",
+	}, configs())
+
+	sub := &subscriber{
+		patterns: []pattern{{key: treeKey{target: sensorTarget, origin: sensorOrigin}}},
+		ch:       make(chan *gnmi.Notification, 10),
+	}
+	s.subscribers[sub] = struct{}{}
+	writeConfigs(t, path, `
+- Name: ds1
+  Device: test
+  Options:
+    a: "3"
+`)
+	if err := c.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	// Only the changes are published, and removed datasources are
+	// deleted as a whole.
+	n := <-sub.ch
+	var deletes []string
+	for _, p := range n.Delete {
+		deletes = append(deletes, agnmi.StrPath(p))
+	}
+	if len(deletes) != 2 || deletes[0] != ds1+"/option[key=b]/value" || deletes[1] != ds2 {
+		t.Errorf("unexpected deletes %v", deletes)
+	}
+	checkLeaves(t, map[string]string{
+		root + "/id":                 "local",
+		ds1 + "/option[key=a]/value": "3",
+	}, leaves([]*gnmi.Notification{n}))
+	checkLeaves(t, map[string]string{
+		root + "/id":                 "local",
+		ds1 + "/type":                "test",
+		ds1 + "/enabled":             "true",
+		ds1 + "/log-level":           "LOG_LEVEL_INFO",
+		ds1 + "/force-update":        "0",
+		ds1 + "/option[key=a]/value": "3",
+	}, configs())
+
+	writeConfigs(t, path, "- Device: test\n")
+	if err := c.Load(path); err == nil {
+		t.Error("expected error loading config without a name")
+	}
+}
+
+type testProvider struct {
+	gc gnmi.GNMIClient
+}
+
+func (p *testProvider) InitGNMI(client gnmi.GNMIClient) { p.gc = client }
+func (p *testProvider) OpenConfig() bool                { return true }
+func (p *testProvider) Origin() string                  { return "openconfig" }
+
+func (p *testProvider) Run(ctx context.Context) error {
+	if _, err := p.gc.Set(ctx, &gnmi.SetRequest{Update: []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("system", "state", "hostname"), agnmi.TypedValue("dev1")),
+	}}); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+type testDevice struct {
+	id string
+}
+
+func (d *testDevice) Alive(ctx context.Context) (bool, error)      { return true, nil }
+func (d *testDevice) DeviceID(ctx context.Context) (string, error) { return d.id, nil }
+func (d *testDevice) Type() string                                 { return "" }
+func (d *testDevice) IPAddr(ctx context.Context) (string, error)   { return "", nil }
+func (d *testDevice) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{&testProvider{}}, nil
+}
+
+func newTestDevice(ctx context.Context, options map[string]string,
+	monitor provider.Monitor) (device.Device, error) {
+	return &testDevice{id: options["id"]}, nil
+}
+
+// TestSensor runs a sensor against the Server, with the datasource
+// configs of a file.
+func TestSensor(t *testing.T) {
+	device.Register("localcvtest", newTestDevice, map[string]device.Option{
+		"id": {Description: "device ID", Required: true},
+	})
+	defer device.Unregister("localcvtest")
+
+	s := New()
+	gc := serve(t, s)
+	path := filepath.Join(t.TempDir(), "configs.yaml")
+	writeConfigs(t, path, `
+- Name: ds1
+  Device: localcvtest
+  Options:
+    id: dev1
+`)
+	configs := NewSensorConfigs(s, "local")
+	if err := configs.Load(path); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	sensor := device.NewSensor("local", 100.0,
+		device.WithSensorGNMIClient(gc),
+		device.WithSensorHeartbeatInterval(50*time.Millisecond),
+		device.WithSensorClientFactory(func(gc gnmi.GNMIClient,
+			info *device.Info) cvclient.CVClient {
+			return v2.NewV2Client(gc, info)
+		}))
+	errc := make(chan error, 1)
+	go func() { errc <- sensor.Run(ctx) }()
+
+	state := prefix(sensorTarget, sensorOrigin, "datasource", "state",
+		pgnmi.ListWithKey("sensor", "id", "local"))
+	ds1 := "/datasource/state/sensor[id=local]/source[name=ds1]"
+	waitFor := func(what string, p *gnmi.Path, cond func(map[string]string) bool) {
+		t.Helper()
+		for !cond(get(t, gc, p)) {
+			select {
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for %s: %v", what, get(t, gc, p))
+			case err := <-errc:
+				t.Fatalf("sensor stopped waiting for %s: %v", what, err)
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+	}
+	waitFor("datasource state", state, func(leaves map[string]string) bool {
+		_, ok := leaves[ds1+"/streaming-start"]
+		return ok && leaves["/datasource/state/sensor[id=local]/last-error"] == "Sensor started"
+	})
+	waitFor("device state", prefix("dev1", "openconfig"), func(leaves map[string]string) bool {
+		return leaves["/system/state/hostname"] == "dev1"
+	})
+
+	// Removing the datasource from the file stops it and deletes its
+	// state.
+	writeConfigs(t, path, "")
+	if err := configs.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("datasource state deletion", state, func(leaves map[string]string) bool {
+		for k := range leaves {
+			if strings.HasPrefix(k, ds1+"/") {
+				return false
+			}
+		}
+		return true
+	})
+	cancel()
+	<-errc
+}
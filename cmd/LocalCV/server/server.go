@@ -0,0 +1,357 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package server implements a local stand-in for the CloudVision
+// ingest gNMI server, to develop and test the Collector against. It
+// keeps the state set by each target in memory and serves it back
+// with Get and Subscribe.
+package server
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/queue"
+	v1client "github.com/aristanetworks/cloudvision-go/device/cvclient/v1"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataPrefix is where the device metadata sent by v1 clients is
+// kept, as v2 clients set it.
+var metadataPrefix = []string{"device-metadata", "state", "metadata"}
+
+// maxPending bounds the number of notifications a Subscribe stream can
+// fall behind by before it's closed.
+const maxPending = 10000
+
+// treeKey identifies a tree of state.
+type treeKey struct {
+	target string
+	origin string
+}
+
+// leaf is a leaf of a tree of state.
+type leaf struct {
+	path      *gnmi.Path
+	val       *gnmi.TypedValue
+	timestamp int64
+}
+
+// Server is a gNMI server keeping the state set by each target, and
+// origin, in a tree of its own.
+type Server struct {
+	gnmi.UnimplementedGNMIServer
+
+	originalTimestamps bool
+
+	lock        sync.Mutex
+	trees       map[treeKey]map[string]*leaf
+	subscribers map[*subscriber]struct{}
+}
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithOriginalTimestamps makes the Server timestamp the SetRequests
+// replayed by a store-and-forward queue with the time they were
+// originally made. CloudVision ignores that time, and timestamps them
+// with the time it receives them, as the Server does by default.
+func WithOriginalTimestamps() Option {
+	return func(s *Server) { s.originalTimestamps = true }
+}
+
+// New returns a Server with no state.
+func New(opts ...Option) *Server {
+	s := &Server{
+		trees:       map[treeKey]map[string]*leaf{},
+		subscribers: map[*subscriber]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Capabilities returns no models: the Server takes any path.
+func (s *Server) Capabilities(ctx context.Context,
+	req *gnmi.CapabilityRequest) (*gnmi.CapabilityResponse, error) {
+	return &gnmi.CapabilityResponse{
+		SupportedEncodings: []gnmi.Encoding{gnmi.Encoding_JSON, gnmi.Encoding_PROTO},
+		GNMIVersion:        "0.7.0",
+	}, nil
+}
+
+// resolve returns the target and origin of path, relative to prefix,
+// and its full elements.
+func resolve(prefix, path *gnmi.Path) (treeKey, []*gnmi.PathElem) {
+	key := treeKey{target: prefix.GetTarget(), origin: prefix.GetOrigin()}
+	if path.GetTarget() != "" {
+		key.target = path.GetTarget()
+	}
+	if path.GetOrigin() != "" {
+		key.origin = path.GetOrigin()
+	}
+	elems := append(append([]*gnmi.PathElem{}, prefix.GetElem()...), path.GetElem()...)
+	return key, elems
+}
+
+// under returns true if path is pattern or one of its descendants. A
+// pattern element without keys, or with * as its name or the value of
+// a key, matches any element of the same name, or any element.
+func under(path, pattern []*gnmi.PathElem) bool {
+	if len(path) < len(pattern) {
+		return false
+	}
+	for i, pe := range pattern {
+		e := path[i]
+		if pe.Name != "*" && pe.Name != e.Name {
+			return false
+		}
+		for k, v := range pe.Key {
+			if ev, ok := e.Key[k]; !ok || (v != "*" && v != ev) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Set applies the deletes, replaces and updates of req in that order.
+// The target of v1 clients is the device ID in their metadata. Like
+// CloudVision, the Server timestamps the Notifications with the time it
+// receives req, unless it was made WithOriginalTimestamps.
+func (s *Server) Set(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+	timestamp := time.Now().UnixNano()
+	prefix := req.Prefix
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ts := md.Get(queue.TimestampMetadata); len(ts) == 1 && s.originalTimestamps {
+		t, err := strconv.ParseInt(ts[0], 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v",
+				queue.TimestampMetadata, err)
+		}
+		timestamp = t
+	}
+	var v1Deletes []*gnmi.Path
+	var v1Updates []*gnmi.Update
+	if v1md, err := v1client.NewMetadataFromIncoming(ctx); err == nil &&
+		prefix.GetTarget() == "" {
+		prefix = &gnmi.Path{Target: v1md.DeviceID, Origin: prefix.GetOrigin(),
+			Elem: prefix.GetElem()}
+		if prefix.Origin == "" && v1md.OpenConfig {
+			prefix.Origin = "openconfig"
+		}
+		v1Deletes, v1Updates = v1MetadataChanges(v1md, timestamp)
+	}
+
+	logrus.Debugf("Set: %v", req)
+
+	// The Notifications of each tree changed, in the order they were
+	// first changed. Replaces are deletes followed by updates, which
+	// Notifications apply in that order, so a replace following an
+	// update of the same tree starts a new Notification.
+	resp := &gnmi.SetResponse{Prefix: req.Prefix, Timestamp: timestamp}
+	var keys []treeKey
+	notifs := map[treeKey][]*gnmi.Notification{}
+	notif := func(key treeKey, forDelete bool) *gnmi.Notification {
+		ns := notifs[key]
+		if len(ns) == 0 || (forDelete && len(ns[len(ns)-1].Update) > 0) {
+			if len(ns) == 0 {
+				keys = append(keys, key)
+			}
+			ns = append(ns, &gnmi.Notification{Timestamp: timestamp,
+				Prefix: &gnmi.Path{Target: key.target, Origin: key.origin}})
+			notifs[key] = ns
+		}
+		return ns[len(ns)-1]
+	}
+	for _, p := range req.Delete {
+		key, elems := resolve(prefix, p)
+		n := notif(key, true)
+		n.Delete = append(n.Delete, &gnmi.Path{Elem: elems})
+		resp.Response = append(resp.Response,
+			&gnmi.UpdateResult{Path: p, Op: gnmi.UpdateResult_DELETE})
+	}
+	for _, upd := range req.Replace {
+		key, elems := resolve(prefix, upd.Path)
+		n := notif(key, true)
+		n.Delete = append(n.Delete, &gnmi.Path{Elem: elems})
+		n.Update = append(n.Update, &gnmi.Update{Path: &gnmi.Path{Elem: elems}, Val: upd.Val})
+		resp.Response = append(resp.Response,
+			&gnmi.UpdateResult{Path: upd.Path, Op: gnmi.UpdateResult_REPLACE})
+	}
+	for _, upd := range req.Update {
+		key, elems := resolve(prefix, upd.Path)
+		n := notif(key, false)
+		n.Update = append(n.Update, &gnmi.Update{Path: &gnmi.Path{Elem: elems}, Val: upd.Val})
+		resp.Response = append(resp.Response,
+			&gnmi.UpdateResult{Path: upd.Path, Op: gnmi.UpdateResult_UPDATE})
+	}
+	if len(v1Deletes) > 0 || len(v1Updates) > 0 {
+		key := treeKey{target: prefix.Target, origin: "arista"}
+		n := notif(key, len(v1Deletes) > 0)
+		n.Delete = append(n.Delete, v1Deletes...)
+		n.Update = append(n.Update, v1Updates...)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, key := range keys {
+		for _, n := range notifs[key] {
+			s.apply(key, n)
+		}
+	}
+	return resp, nil
+}
+
+// v1MetadataChanges returns the deletes and updates of the device
+// metadata sent by a v1 client, at the paths v2 clients set it.
+func v1MetadataChanges(md v1client.Metadata, timestamp int64) ([]*gnmi.Path,
+	[]*gnmi.Update) {
+	var deletes []*gnmi.Path
+	var updates []*gnmi.Update
+	path := func(element ...string) *gnmi.Path {
+		return pgnmi.Path(append(append([]string{}, metadataPrefix...), element...)...)
+	}
+	add := func(val interface{}, element ...string) {
+		updates = append(updates, pgnmi.Update(path(element...), agnmi.TypedValue(val)))
+	}
+	if md.DeviceType != nil {
+		add(*md.DeviceType, "type")
+	}
+	if md.CollectorVersion != "" {
+		add(md.CollectorVersion, "collector-version")
+	}
+	if md.Alive != nil && *md.Alive {
+		add(timestamp, "last-seen")
+	}
+	if dm := md.DeviceMetadata; dm != nil {
+		deletes = append(deletes, path("labels"))
+		for _, f := range []struct {
+			name string
+			val  string
+		}{
+			{"hostname", dm.Hostname},
+			{"vendor", dm.Vendor},
+			{"model", dm.Model},
+			{"serial-number", dm.SerialNumber},
+			{"software-version", dm.SoftwareVersion},
+		} {
+			if f.val == "" {
+				deletes = append(deletes, path(f.name))
+			} else {
+				add(f.val, f.name)
+			}
+		}
+		for k, v := range dm.Labels {
+			add(v, "labels", pgnmi.ListWithKey("label", "name", k), "value")
+		}
+	}
+	return deletes, updates
+}
+
+// delete deletes the leaves under elems. It must be called with the
+// lock held.
+func (s *Server) delete(key treeKey, elems []*gnmi.PathElem) {
+	tree := s.trees[key]
+	for k, l := range tree {
+		if under(l.path.Elem, elems) {
+			delete(tree, k)
+		}
+	}
+}
+
+// update sets a leaf. It must be called with the lock held.
+func (s *Server) update(key treeKey, elems []*gnmi.PathElem, val *gnmi.TypedValue,
+	timestamp int64) {
+	tree, ok := s.trees[key]
+	if !ok {
+		tree = map[string]*leaf{}
+		s.trees[key] = tree
+	}
+	p := &gnmi.Path{Elem: elems}
+	tree[agnmi.StrPath(p)] = &leaf{path: p, val: val, timestamp: timestamp}
+}
+
+// snapshot returns a Notification of the leaves under elems in a tree,
+// or nil if there are none. It must be called with the lock held.
+func (s *Server) snapshot(key treeKey, elems []*gnmi.PathElem) *gnmi.Notification {
+	var leaves []*leaf
+	for _, l := range s.trees[key] {
+		if under(l.path.Elem, elems) {
+			leaves = append(leaves, l)
+		}
+	}
+	if len(leaves) == 0 {
+		return nil
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return agnmi.StrPath(leaves[i].path) < agnmi.StrPath(leaves[j].path)
+	})
+	n := &gnmi.Notification{Prefix: &gnmi.Path{Target: key.target, Origin: key.origin}}
+	for _, l := range leaves {
+		if l.timestamp > n.Timestamp {
+			n.Timestamp = l.timestamp
+		}
+		n.Update = append(n.Update, &gnmi.Update{Path: l.path, Val: l.val})
+	}
+	return n
+}
+
+// Get returns a Notification of the leaves under each path.
+func (s *Server) Get(ctx context.Context, req *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	resp := &gnmi.GetResponse{}
+	for _, p := range req.Path {
+		key, elems := resolve(req.Prefix, p)
+		n := s.snapshot(key, elems)
+		if n == nil {
+			return nil, status.Errorf(codes.NotFound, "no state at %s",
+				agnmi.StrPath(&gnmi.Path{Target: key.target, Origin: key.origin,
+					Elem: elems}))
+		}
+		resp.Notification = append(resp.Notification, n)
+	}
+	return resp, nil
+}
+
+// Publish deletes the leaves under deletes and sets updates in the
+// tree of target and origin, as a SetRequest would, and sends them to
+// subscribers.
+func (s *Server) Publish(target, origin string, deletes []*gnmi.Path,
+	updates []*gnmi.Update) {
+	key := treeKey{target: target, origin: origin}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.apply(key, &gnmi.Notification{
+		Timestamp: time.Now().UnixNano(),
+		Prefix:    &gnmi.Path{Target: target, Origin: origin},
+		Delete:    deletes,
+		Update:    updates,
+	})
+}
+
+// apply applies the deletes and then the updates of n, whose paths are
+// full, to a tree, and sends n to subscribers. It must be called with
+// the lock held.
+func (s *Server) apply(key treeKey, n *gnmi.Notification) {
+	for _, p := range n.Delete {
+		s.delete(key, p.Elem)
+	}
+	for _, upd := range n.Update {
+		s.update(key, upd.Path.Elem, upd.Val, n.Timestamp)
+	}
+	s.publish(key, n)
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/queue"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serve serves s on a local port and returns a client of it.
+func serve(t *testing.T, s *Server) gnmi.GNMIClient {
+	grpcServer := grpc.NewServer()
+	gnmi.RegisterGNMIServer(grpcServer, s)
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	conn, err := grpc.Dial(listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return gnmi.NewGNMIClient(conn)
+}
+
+func prefix(target, origin string, element ...string) *gnmi.Path {
+	p := pgnmi.Path(element...)
+	p.Target, p.Origin = target, origin
+	return p
+}
+
+// leaves returns the values of the leaves of notifs by path.
+func leaves(notifs []*gnmi.Notification) map[string]string {
+	out := map[string]string{}
+	for _, n := range notifs {
+		for _, upd := range n.Update {
+			out[agnmi.StrPath(pgnmi.PathJoin(n.Prefix, upd.Path))] = agnmi.StrVal(upd.Val)
+		}
+	}
+	return out
+}
+
+func get(t *testing.T, c gnmi.GNMIClient, p *gnmi.Path) map[string]string {
+	t.Helper()
+	resp, err := c.Get(context.Background(), &gnmi.GetRequest{Path: []*gnmi.Path{p}})
+	if status.Code(err) == codes.NotFound {
+		return map[string]string{}
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return leaves(resp.Notification)
+}
+
+func checkLeaves(t *testing.T, expected, got map[string]string) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for k, v := range expected {
+		if got[k] != v {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestSetGet(t *testing.T) {
+	c := serve(t, New())
+	ctx := context.Background()
+	if _, err := c.Set(ctx, &gnmi.SetRequest{
+		Prefix: prefix("dev1", "openconfig", "interfaces"),
+		Update: []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path(pgnmi.ListWithKey("interface", "name", "eth0"),
+				"state", "mtu"), agnmi.TypedValue(uint32(1500))),
+			pgnmi.Update(pgnmi.Path(pgnmi.ListWithKey("interface", "name", "eth1"),
+				"state", "mtu"), agnmi.TypedValue(uint32(9000))),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	checkLeaves(t, map[string]string{
+		"/interfaces/interface[name=eth0]/state/mtu": "1500",
+		"/interfaces/interface[name=eth1]/state/mtu": "9000",
+	}, get(t, c, prefix("dev1", "openconfig", "interfaces")))
+	// Each target and origin has a tree of its own.
+	checkLeaves(t, map[string]string{}, get(t, c, prefix("dev2", "openconfig")))
+	checkLeaves(t, map[string]string{}, get(t, c, prefix("dev1", "arista")))
+
+	// Deletes apply before replaces, which apply before updates.
+	if _, err := c.Set(ctx, &gnmi.SetRequest{
+		Prefix: prefix("dev1", "openconfig"),
+		Delete: []*gnmi.Path{pgnmi.Path("interfaces")},
+		Replace: []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path("system", "state"), &gnmi.TypedValue{
+				Value: &gnmi.TypedValue_JsonVal{JsonVal: []byte(`{"hostname":"a"}`)}}),
+		},
+		Update: []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path("interfaces", pgnmi.ListWithKey("interface", "name",
+				"eth0"), "state", "mtu"), agnmi.TypedValue(uint32(1400))),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	checkLeaves(t, map[string]string{
+		"/interfaces/interface[name=eth0]/state/mtu": "1400",
+		"/system/state": `{"hostname":"a"}`,
+	}, get(t, c, prefix("dev1", "openconfig")))
+}
+
+func TestSetV1(t *testing.T) {
+	// Original timestamps make last-seen known.
+	c := serve(t, New(WithOriginalTimestamps()))
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"deviceID", "dev1", "openConfig", "true", "typeCheck", "false",
+		"deviceType", "target", "deviceLiveness", "true",
+		"collectorVersion", "1.2.3", "hostname", "dev1", "vendor", "Arista",
+		"model", "", "deviceLabel", "role=spine", queue.TimestampMetadata, "42")
+	if _, err := c.Set(ctx, &gnmi.SetRequest{Update: []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("system", "state", "hostname"), agnmi.TypedValue("a")),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	checkLeaves(t, map[string]string{"/system/state/hostname": "a"},
+		get(t, c, prefix("dev1", "openconfig")))
+	checkLeaves(t, map[string]string{
+		"/device-metadata/state/metadata/type":                          "target",
+		"/device-metadata/state/metadata/collector-version":             "1.2.3",
+		"/device-metadata/state/metadata/last-seen":                     "42",
+		"/device-metadata/state/metadata/hostname":                      "dev1",
+		"/device-metadata/state/metadata/vendor":                        "Arista",
+		"/device-metadata/state/metadata/labels/label[name=role]/value": "spine",
+	}, get(t, c, prefix("dev1", "arista")))
+
+}
+
+// Replayed Sets are timestamped on receipt, like CloudVision does,
+// unless the server is asked to use their original timestamp.
+func TestSetOriginalTimestamp(t *testing.T) {
+	for _, tc := range []struct {
+		opts     []Option
+		original bool
+	}{
+		{opts: nil, original: false},
+		{opts: []Option{WithOriginalTimestamps()}, original: true},
+	} {
+		c := serve(t, New(tc.opts...))
+		ctx := metadata.AppendToOutgoingContext(context.Background(),
+			queue.TimestampMetadata, "42")
+		p := prefix("dev1", "openconfig")
+		if _, err := c.Set(ctx, &gnmi.SetRequest{Prefix: p, Update: []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path("system", "state", "hostname"), agnmi.TypedValue("a")),
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.Get(context.Background(), &gnmi.GetRequest{Path: []*gnmi.Path{p}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ts := resp.Notification[0].Timestamp; (ts == 42) != tc.original {
+			t.Errorf("original timestamps %t: unexpected timestamp %d", tc.original, ts)
+		}
+	}
+}
+
+func recv(t *testing.T, sc gnmi.GNMI_SubscribeClient) *gnmi.SubscribeResponse {
+	t.Helper()
+	resp, err := sc.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestSubscribe(t *testing.T) {
+	s := New()
+	c := serve(t, s)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Publish("dev1", "openconfig", nil, []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("interfaces", pgnmi.ListWithKey("interface", "name", "eth0"),
+			"state", "mtu"), agnmi.TypedValue(uint32(1500))),
+		pgnmi.Update(pgnmi.Path("system", "state", "hostname"), agnmi.TypedValue("a")),
+	})
+
+	sc, err := c.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := agnmi.NewSubscribeRequest(&agnmi.SubscribeOptions{
+		Origin: "openconfig",
+		Target: "dev1",
+		Paths:  [][]string{{"interfaces", "interface", "state"}},
+		Mode:   "stream",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Send(req); err != nil {
+		t.Fatal(err)
+	}
+	checkLeaves(t, map[string]string{"/interfaces/interface[name=eth0]/state/mtu": "1500"},
+		leaves([]*gnmi.Notification{recv(t, sc).GetUpdate()}))
+	if !recv(t, sc).GetSyncResponse() {
+		t.Fatal("expected sync response")
+	}
+
+	// Only the changes under the subscribed paths are streamed, and
+	// deletes of their ancestors too.
+	s.Publish("dev1", "openconfig", nil, []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("system", "state", "hostname"), agnmi.TypedValue("b")),
+	})
+	s.Publish("dev2", "openconfig", nil, []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("interfaces", pgnmi.ListWithKey("interface", "name", "eth0"),
+			"state", "mtu"), agnmi.TypedValue(uint32(1500))),
+	})
+	s.Publish("dev1", "openconfig", nil, []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("interfaces", pgnmi.ListWithKey("interface", "name", "eth1"),
+			"state", "mtu"), agnmi.TypedValue(uint32(9000))),
+	})
+	s.Publish("dev1", "openconfig", []*gnmi.Path{pgnmi.Path("interfaces")}, nil)
+	n := recv(t, sc).GetUpdate()
+	checkLeaves(t, map[string]string{"/interfaces/interface[name=eth1]/state/mtu": "9000"},
+		leaves([]*gnmi.Notification{n}))
+	n = recv(t, sc).GetUpdate()
+	if len(n.Delete) != 1 || agnmi.StrPath(n.Delete[0]) != "/interfaces" {
+		t.Errorf("expected delete of /interfaces, got %v", n)
+	}
+}
+
+func TestSubscribeOnce(t *testing.T) {
+	s := New()
+	c := serve(t, s)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Publish("cv", "arista", nil, []*gnmi.Update{
+		pgnmi.Update(pgnmi.Path("a", pgnmi.ListWithKey("b", "id", "1"), "name"),
+			agnmi.TypedValue("x")),
+		pgnmi.Update(pgnmi.Path("a", pgnmi.ListWithKey("b", "id", "2"), "name"),
+			agnmi.TypedValue("y")),
+	})
+	respCh := make(chan *gnmi.SubscribeResponse, 10)
+	if err := agnmi.SubscribeErr(ctx, c, &agnmi.SubscribeOptions{
+		Origin: "arista",
+		Target: "cv",
+		Paths:  [][]string{agnmi.SplitPath("/a/b[id=2]")},
+		Mode:   "once",
+	}, respCh); err != nil {
+		t.Fatal(err)
+	}
+	var notifs []*gnmi.Notification
+	for resp := range respCh {
+		if n := resp.GetUpdate(); n != nil {
+			notifs = append(notifs, n)
+		}
+	}
+	checkLeaves(t, map[string]string{"/a/b[id=2]/name": "y"}, leaves(notifs))
+}
+
+func TestSubscribeFallsBehind(t *testing.T) {
+	s := New()
+	sub := &subscriber{
+		patterns: []pattern{{key: treeKey{target: "dev1"}}},
+		ch:       make(chan *gnmi.Notification, 1),
+	}
+	s.subscribers[sub] = struct{}{}
+	for i := 0; i < 2; i++ {
+		s.Publish("dev1", "", nil, []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path("a"), agnmi.TypedValue(i)),
+		})
+	}
+	if len(s.subscribers) != 0 {
+		t.Error("subscriber not removed after falling behind")
+	}
+	<-sub.ch
+	if _, ok := <-sub.ch; ok {
+		t.Error("channel of subscriber not closed after falling behind")
+	}
+}
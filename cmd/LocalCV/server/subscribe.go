@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package server
+
+import (
+	"io"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pattern is a subscription path, which selects the leaves under it in
+// a tree.
+type pattern struct {
+	key   treeKey
+	elems []*gnmi.PathElem
+}
+
+// subscriber is a Subscribe stream in STREAM mode. Its channel is
+// closed when it falls too far behind.
+type subscriber struct {
+	patterns []pattern
+	ch       chan *gnmi.Notification
+}
+
+// filter returns the part of n, a change of the tree of key, selected
+// by the patterns of sub, or nil if there's none. Deletes of the
+// ancestors of a pattern are selected too.
+func (sub *subscriber) filter(key treeKey, n *gnmi.Notification) *gnmi.Notification {
+	out := &gnmi.Notification{Timestamp: n.Timestamp, Prefix: n.Prefix}
+	for _, p := range n.Delete {
+		for _, pat := range sub.patterns {
+			if pat.key == key && (under(p.Elem, pat.elems) || under(pat.elems, p.Elem)) {
+				out.Delete = append(out.Delete, p)
+				break
+			}
+		}
+	}
+	for _, upd := range n.Update {
+		for _, pat := range sub.patterns {
+			if pat.key == key && under(upd.Path.Elem, pat.elems) {
+				out.Update = append(out.Update, upd)
+				break
+			}
+		}
+	}
+	if len(out.Delete) == 0 && len(out.Update) == 0 {
+		return nil
+	}
+	return out
+}
+
+// publish sends n, a change of the tree of key, to the subscribers it
+// concerns. It must be called with the lock held.
+func (s *Server) publish(key treeKey, n *gnmi.Notification) {
+	for sub := range s.subscribers {
+		out := sub.filter(key, n)
+		if out == nil {
+			continue
+		}
+		select {
+		case sub.ch <- out:
+		default:
+			close(sub.ch)
+			delete(s.subscribers, sub)
+		}
+	}
+}
+
+// patterns returns the patterns of the subscriptions of sl. The target
+// of a subscription is that of the prefix unless its path has one, and
+// likewise for its origin.
+func patterns(sl *gnmi.SubscriptionList) []pattern {
+	var pats []pattern
+	for _, sub := range sl.Subscription {
+		key, elems := resolve(sl.Prefix, sub.Path)
+		pats = append(pats, pattern{key: key, elems: elems})
+	}
+	return pats
+}
+
+// snapshots returns the Notifications of the leaves selected by pats.
+// It must be called with the lock held.
+func (s *Server) snapshots(pats []pattern) []*gnmi.Notification {
+	var ns []*gnmi.Notification
+	for _, pat := range pats {
+		if n := s.snapshot(pat.key, pat.elems); n != nil {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+func sendAll(stream gnmi.GNMI_SubscribeServer, ns []*gnmi.Notification) error {
+	for _, n := range ns {
+		if err := stream.Send(&gnmi.SubscribeResponse{
+			Response: &gnmi.SubscribeResponse_Update{Update: n},
+		}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&gnmi.SubscribeResponse{
+		Response: &gnmi.SubscribeResponse_SyncResponse{SyncResponse: true},
+	})
+}
+
+// Subscribe sends the leaves under the subscribed paths, followed by a
+// sync response. In POLL mode it does so again on each poll; in STREAM
+// mode it then sends the changes made to them, until the client goes
+// away or falls too far behind.
+func (s *Server) Subscribe(stream gnmi.GNMI_SubscribeServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	sl := req.GetSubscribe()
+	if sl == nil {
+		return status.Error(codes.InvalidArgument,
+			"first SubscribeRequest must be a SubscriptionList")
+	}
+	logrus.Debugf("Subscribe: %v", sl)
+	pats := patterns(sl)
+
+	switch sl.Mode {
+	case gnmi.SubscriptionList_ONCE:
+		s.lock.Lock()
+		ns := s.snapshots(pats)
+		s.lock.Unlock()
+		return sendAll(stream, ns)
+
+	case gnmi.SubscriptionList_POLL:
+		for {
+			s.lock.Lock()
+			ns := s.snapshots(pats)
+			s.lock.Unlock()
+			if err := sendAll(stream, ns); err != nil {
+				return err
+			}
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if req.GetPoll() == nil {
+				return status.Error(codes.InvalidArgument,
+					"only polls may follow the SubscriptionList in POLL mode")
+			}
+		}
+	}
+
+	// Registering the subscriber along with taking the snapshot makes
+	// sure no change is missed or sent twice.
+	sub := &subscriber{patterns: pats, ch: make(chan *gnmi.Notification, maxPending)}
+	s.lock.Lock()
+	var ns []*gnmi.Notification
+	if !sl.UpdatesOnly {
+		ns = s.snapshots(pats)
+	}
+	s.subscribers[sub] = struct{}{}
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		delete(s.subscribers, sub)
+		s.lock.Unlock()
+	}()
+
+	if err := sendAll(stream, ns); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n, ok := <-sub.ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted,
+					"subscription fell too far behind")
+			}
+			if err := stream.Send(&gnmi.SubscribeResponse{
+				Response: &gnmi.SubscribeResponse_Update{Update: n},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
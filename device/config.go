@@ -5,11 +5,16 @@
 package device
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
-	yaml "gopkg.in/yaml.v2"
+	yaml "gopkg.in/yaml.v3"
 )
 
 // Config represents a single device configuration.
@@ -57,11 +62,13 @@ func (c *Config) IsDeleted() bool {
 	return c.deleted
 }
 
-func (c *Config) validate() error {
-	if c.Device == "" {
-		return fmt.Errorf("Device in config cannot be empty")
-	}
-	return nil
+// String returns a description of the config with its secret options
+// and credentials redacted.
+func (c *Config) String() string {
+	return fmt.Sprintf("name: %s, device: %s, options: %v, credentials: %v, "+
+		"loglevel: %s, enabled: %t", c.Name, c.Device,
+		RedactedOptions(c.Device, c.Options), RedactedCredentials(c.Credentials),
+		c.LogLevel, c.Enabled)
 }
 
 // Equal returns true if the two configs are config equal
@@ -83,36 +90,529 @@ func (c *Config) Equal(o *Config) bool {
 		c.ForceUpdate == o.ForceUpdate
 }
 
-// ReadConfigs generates device configs from the config file at the specified path.
+// ReadConfigs generates device configs from the config file or
+// directory at the specified path.
+//
+// A config file holds a list of configs. An entry of the list can
+// instead be an Include directive naming another config file, a
+// directory, or a glob pattern of files, or a list of them, relative
+// to the including file:
+//
+//	# Read the configs of each site.
+//	- Include: sites/*.yaml
+//
+// When the path is a directory, or an included one, the configs of
+// all its *.yaml and *.yml files are read in lexical order. Config
+// names must be unique across all the files read. References to
+// environment variables, written ${NAME}, are replaced by their
+// values. Errors name the file and line they come from.
+//
+// An entry can also be a device group, whose configs share a
+// template and differ by the address of their device:
+//
+//	# The core switches.
+//	- Group: core
+//	  Device: snmp
+//	  Options:
+//	    c: ${COMMUNITY}
+//	    mibs: /usr/share/snmp/mibs
+//	  Members:
+//	  - 10.0.0.1
+//	  - switch-1.example.com
+//	  - 10.0.1.10-10.0.1.20
+//	  - 10.0.2.0/24
+//	  - Address: 10.0.2.7
+//	    Options:
+//	      pollInterval: 1m
+//
+// Each member, an address, hostname, address range or CIDR prefix,
+// is expanded into configs named after the group and their address,
+// such as core-10.0.0.1, whose address option is set to that
+// address. The address option is AddressOption if the group sets it,
+// and otherwise the only address option of the device type, or
+// "address". The network and broadcast addresses of IPv4 prefixes are
+// left out. Members can override the options, credentials, log level
+// and NoStream of the group, and later members override earlier ones
+// for the addresses they share.
 func ReadConfigs(configPath string) ([]*Config, error) {
-	data, err := os.ReadFile(configPath)
-	if err != nil {
+	configs, _, err := ReadConfigsWithSources(configPath)
+	return configs, err
+}
+
+// ReadConfigsWithSources is like ReadConfigs but also returns the
+// absolute paths and glob patterns of the files the configs are read
+// from, including the files that could be included or added to a
+// config directory, so that callers can watch them for changes. The
+// sources found so far are returned even if reading fails.
+func ReadConfigsWithSources(configPath string) ([]*Config, []string, error) {
+	r := newConfigReader()
+	if err := r.readPath(configPath); err != nil {
+		return nil, r.sources, err
+	}
+	return r.configs, r.sources, nil
+}
+
+// ReadConfigsUnchecked is like ReadConfigs but doesn't check the
+// options of the configs against their device type, so that callers
+// can check each config themselves.
+func ReadConfigsUnchecked(configPath string) ([]*Config, error) {
+	r := newConfigReader()
+	r.unchecked = true
+	if err := r.readPath(configPath); err != nil {
 		return nil, err
 	}
-	return readConfigsFromBytes(data)
+	return r.configs, nil
 }
 
 func readConfigsFromBytes(data []byte) ([]*Config, error) {
-	configs := []*Config{}
-	err := yaml.Unmarshal(data, &configs)
+	r := newConfigReader()
+	if err := r.readBytes("config", data); err != nil {
+		return nil, err
+	}
+	return r.configs, nil
+}
+
+const includeKey = "Include"
+
+// envReference matches the references to environment variables
+// expanded in config files.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// configReader reads configs from config files, following includes.
+type configReader struct {
+	configs []*Config
+	sources []string
+	// reading holds the files being read, to detect include cycles.
+	reading map[string]bool
+	// defined maps the names of the configs read so far to where
+	// they're defined.
+	defined map[string]configSource
+	// unchecked is set if options aren't checked.
+	unchecked bool
+}
+
+func newConfigReader() *configReader {
+	return &configReader{
+		configs: []*Config{},
+		reading: map[string]bool{},
+		defined: map[string]configSource{},
+	}
+}
+
+// configSource is where a config is defined.
+type configSource struct {
+	// file is the name of the config file defining the config, and
+	// line the line of its entry.
+	file string
+	line int
+	// group is the name of the device group the config is a member
+	// of, if any.
+	group string
+}
+
+func (s configSource) String() string {
+	return fmt.Sprintf("%s:%d", s.file, s.line)
+}
+
+// configFileError returns err, which happened at the specified line
+// of a config file, prefixed with the file name and line.
+func configFileError(name string, line int, err error) error {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		// Decoding errors already start with "line N: ".
+		return fmt.Errorf("%s:%s", name, strings.TrimPrefix(typeErr.Errors[0], "line "))
+	}
+	return fmt.Errorf("%s:%d: %w", name, line, err)
+}
+
+// isConfigFile returns true if a file in a config directory holds
+// configs.
+func isConfigFile(name string) bool {
+	ext := filepath.Ext(name)
+	return !strings.HasPrefix(name, ".") && (ext == ".yaml" || ext == ".yml")
+}
+
+// readPath reads the configs of a config file or directory.
+func (r *configReader) readPath(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		r.sources = append(r.sources, path)
+		return err
+	}
+	if !info.IsDir() {
+		return r.readFile(path)
+	}
+	r.sources = append(r.sources, filepath.Join(path, "*.yaml"), filepath.Join(path, "*.yml"))
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isConfigFile(entry.Name()) {
+			continue
+		}
+		if err := r.readFile(filepath.Join(path, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *configReader) readFile(path string) error {
+	r.sources = append(r.sources, path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	r.reading[path] = true
+	defer delete(r.reading, path)
+	return r.readBytes(path, data)
+}
+
+// readBytes reads the configs in data, which comes from the file with
+// the specified name.
+func (r *configReader) readBytes(name string, data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+		return nil
+	}
+	list := doc.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return configFileError(name, list.Line, errors.New("expected a list of configs"))
+	}
+	for _, entry := range list.Content {
+		if err := expandEnv(name, entry); err != nil {
+			return err
+		}
+		if include := mappingValue(entry, includeKey); include != nil {
+			if len(entry.Content) > 2 {
+				return configFileError(name, entry.Line,
+					errors.New("Include can't be combined with other keys"))
+			}
+			if err := r.include(name, include); err != nil {
+				return err
+			}
+			continue
+		}
+		if mappingValue(entry, groupKey) != nil {
+			if err := r.readGroup(name, entry); err != nil {
+				return err
+			}
+			continue
+		}
+		config := &Config{}
+		if err := entry.Decode(config); err != nil {
+			return configFileError(name, entry.Line, err)
+		}
+		if err := r.add(configSource{file: name, line: entry.Line}, config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add adds a config defined at the specified source.
+func (r *configReader) add(source configSource, config *Config) error {
+	if config.Device == "" {
+		return configFileError(source.file, source.line,
+			errors.New("Device in config cannot be empty"))
+	}
+	if !r.unchecked {
+		if err := ValidateOptions(config); err != nil {
+			return configFileError(source.file, source.line, err)
+		}
+	}
+	if config.Name != "" {
+		if previous, ok := r.defined[config.Name]; ok {
+			return configFileError(source.file, source.line,
+				fmt.Errorf("duplicate config name '%s', already defined at %s",
+					config.Name, previous))
+		}
+		r.defined[config.Name] = source
+	}
+	r.configs = append(r.configs, config)
+	return nil
+}
+
+// include reads the configs included by the file with the specified
+// name.
+func (r *configReader) include(name string, include *yaml.Node) error {
+	var patterns []string
+	if include.Kind == yaml.SequenceNode {
+		if err := include.Decode(&patterns); err != nil {
+			return configFileError(name, include.Line, err)
+		}
+	} else {
+		var pattern string
+		if err := include.Decode(&pattern); err != nil {
+			return configFileError(name, include.Line, err)
+		}
+		patterns = []string{pattern}
+	}
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(name), pattern)
+		}
+		paths := []string{pattern}
+		if strings.ContainsAny(pattern, `*?[\`) {
+			var err error
+			if paths, err = filepath.Glob(pattern); err != nil {
+				return configFileError(name, include.Line, err)
+			}
+			if abs, err := filepath.Abs(pattern); err == nil {
+				r.sources = append(r.sources, abs)
+			}
+		}
+		for _, path := range paths {
+			if abs, err := filepath.Abs(path); err == nil && r.reading[abs] {
+				return configFileError(name, include.Line,
+					fmt.Errorf("include cycle through %s", abs))
+			}
+			if err := r.readPath(path); err != nil {
+				return configFileError(name, include.Line, err)
+			}
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil if
+// node isn't a mapping or doesn't have the key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// expandEnv replaces references to environment variables in the
+// scalars of node, which comes from the file with the specified name.
+func expandEnv(name string, node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var err error
+		node.Value = envReference.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			variable := envReference.FindStringSubmatch(ref)[1]
+			value, ok := os.LookupEnv(variable)
+			if !ok && err == nil {
+				err = configFileError(name, node.Line,
+					fmt.Errorf("environment variable %s is not set", variable))
+			}
+			return value
+		})
+		return err
+	}
+	for _, child := range node.Content {
+		if err := expandEnv(name, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newConfigFileEncoder returns an encoder writing configs the way
+// config files are usually written.
+func newConfigFileEncoder(f *os.File) *yaml.Encoder {
+	enc := yaml.NewEncoder(f)
+	enc.SetIndent(2)
+	return enc
+}
+
+// createConfigFile truncates or creates the config file at the
+// specified path, making it only readable by its owner.
+func createConfigFile(configPath string) (*os.File, error) {
+	f, err := os.OpenFile(configPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return nil, err
 	}
+	// OpenFile doesn't change the mode of an existing file.
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// WriteConfigs writes a list of Config to the specified path, only
+// readable by its owner. Secrets aren't written in clear text: secret
+// options and credentials must be references to environment
+// variables, written ${NAME}, or nothing is written.
+func WriteConfigs(configPath string, configs []*Config) error {
 	for _, config := range configs {
-		err := config.validate()
-		if err != nil {
+		if err := checkSecretReferences(config); err != nil {
+			return err
+		}
+	}
+	f, err := createConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return newConfigFileEncoder(f).Encode(&configs)
+}
+
+// checkSecretReferences returns an error if a secret option or a
+// credential of config isn't a reference to an environment variable,
+// so that it would be written to a config file in clear text.
+func checkSecretReferences(config *Config) error {
+	for _, k := range sortedKeys(config.Options) {
+		if v := config.Options[k]; v != "" && IsSecretOption(config.Device, k) &&
+			!envReference.MatchString(v) {
+			return fmt.Errorf("secret option %s of config %s can't be written in clear text: "+
+				"it must reference an environment variable, written ${NAME}", k, config.Name)
+		}
+	}
+	for _, k := range sortedKeys(config.Credentials) {
+		if v := config.Credentials[k]; v != "" && !envReference.MatchString(v) {
+			return fmt.Errorf("credential %s of config %s can't be written in clear text: "+
+				"it must reference an environment variable, written ${NAME}", k, config.Name)
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// withReferences returns the values of a map of config with the
+// references to environment variables that the corresponding mapping
+// of a config file entry has for them put back.
+func withReferences(values map[string]string, entry *yaml.Node) map[string]string {
+	if len(values) == 0 || entry == nil {
+		return values
+	}
+	withRefs := make(map[string]string, len(values))
+	for k, v := range values {
+		withRefs[k] = v
+		raw := mappingValue(entry, k)
+		if raw == nil || raw.Kind != yaml.ScalarNode {
+			continue
+		}
+		expanded := envReference.ReplaceAllStringFunc(raw.Value, func(ref string) string {
+			return os.Getenv(envReference.FindStringSubmatch(ref)[1])
+		})
+		if expanded == v {
+			withRefs[k] = raw.Value
+		}
+	}
+	return withRefs
+}
+
+// findConfig returns where the config with the specified name is
+// defined among the files read from the config file or directory at
+// configPath. The source is empty if no file defines it.
+func findConfig(configPath, name string) (configSource, error) {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return configSource{}, nil
+	}
+	r := newConfigReader()
+	r.unchecked = true
+	if err := r.readPath(configPath); err != nil {
+		return configSource{}, err
+	}
+	return r.defined[name], nil
+}
+
+// A configFileUpdate is an update of a config file, prepared by
+// prepareConfigFileUpdate so that it can be checked before it's made.
+type configFileUpdate struct {
+	path string
+	doc  *yaml.Node
+}
+
+// prepareConfigFileUpdate prepares the update replacing the config
+// with the specified name in the config file at configPath with
+// config, or removing it if config is nil. The config is appended if
+// the file doesn't have it among its own entries, so configs defined
+// in included files must be updated in those, as found by findConfig.
+// Configs of device groups aren't entries of their file and can't be
+// updated. The other entries of the file, including Include
+// directives and references to environment variables, are kept as
+// they are, and so are the references of the replaced entry for the
+// values config keeps. The update fails if it would write secrets in
+// clear text, as for WriteConfigs.
+func prepareConfigFileUpdate(configPath, name string,
+	config *Config) (*configFileUpdate, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{
+			{Kind: yaml.SequenceNode, Tag: "!!seq"}}}
+	}
+	list := doc.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return nil, configFileError(configPath, list.Line,
+			errors.New("expected a list of configs"))
+	}
+
+	index := -1
+	for i, entry := range list.Content {
+		if n := mappingValue(entry, "Name"); n != nil && n.Value == name {
+			index = i
+			break
+		}
+	}
+	var replacement *yaml.Node
+	if config != nil {
+		written := *config
+		if index >= 0 {
+			entry := list.Content[index]
+			written.Options = withReferences(config.Options, mappingValue(entry, "Options"))
+			written.Credentials = withReferences(config.Credentials,
+				mappingValue(entry, "Credentials"))
+		}
+		if err := checkSecretReferences(&written); err != nil {
+			return nil, err
+		}
+		replacement = &yaml.Node{}
+		if err := replacement.Encode(&written); err != nil {
 			return nil, err
 		}
 	}
-	return configs, nil
+	var entries []*yaml.Node
+	for i, entry := range list.Content {
+		if n := mappingValue(entry, "Name"); n == nil || n.Value != name {
+			entries = append(entries, entry)
+		} else if i == index && replacement != nil {
+			entries = append(entries, replacement)
+		}
+	}
+	if index < 0 && replacement != nil {
+		entries = append(entries, replacement)
+	}
+	list.Content = entries
+	return &configFileUpdate{path: configPath, doc: &doc}, nil
 }
 
-// WriteConfigs writes a list of Config to the specified path.
-func WriteConfigs(configPath string, configs []*Config) error {
-	f, err := os.Create(configPath)
+// write makes the update.
+func (u *configFileUpdate) write() error {
+	f, err := createConfigFile(u.path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	return yaml.NewEncoder(f).Encode(&configs)
+	return newConfigFileEncoder(f).Encode(u.doc)
 }
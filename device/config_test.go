@@ -5,7 +5,10 @@
 package device
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -36,7 +39,20 @@ func TestReadConfigsFromBytes(t *testing.T) {
                c: d`),
 			err: errors.New("Device must be specified"),
 		},
+		{description: "bad option for registered device",
+			input: []byte(`
+         -  Name: typo
+            Device: typed
+            Options:
+               interval: 20`),
+			err: errors.New("Config 'typo' for device 'typed': Value for option " +
+				"'interval' ('20') is not a valid duration: time: missing unit " +
+				"in duration \"20\""),
+		},
 	}
+	Register(typedTestDeviceInfo.name, typedTestDeviceInfo.creator,
+		typedTestDeviceInfo.options)
+	defer Unregister(typedTestDeviceInfo.name)
 	for _, testCase := range testCases {
 		t.Run(testCase.description, func(t *testing.T) {
 			configs, err := readConfigsFromBytes(testCase.input)
@@ -53,3 +69,215 @@ func TestReadConfigsFromBytes(t *testing.T) {
 		})
 	}
 }
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadConfigs(t *testing.T) {
+	t.Setenv("CONFIG_TEST_PASSWORD", "secret")
+	t.Setenv("CONFIG_TEST_SITE", "site1")
+	for _, tc := range []struct {
+		description string
+		files       map[string]string
+		path        string
+		expect      []string
+		sources     []string
+		err         string
+	}{{
+		description: "directory",
+		files: map[string]string{
+			"conf.d/b.yaml":       "- Name: b\n  Device: test\n",
+			"conf.d/a.yml":        "- Name: a\n  Device: test\n",
+			"conf.d/c.txt":        "not a config",
+			"conf.d/.hidden.yaml": "- Name: hidden\n  Device: test\n",
+			"conf.d/empty.yaml":   "",
+		},
+		path:   "conf.d",
+		expect: []string{"a", "b"},
+		sources: []string{"conf.d/*.yaml", "conf.d/*.yml", "conf.d/a.yml",
+			"conf.d/b.yaml", "conf.d/empty.yaml"},
+	}, {
+		description: "includes and environment variables",
+		files: map[string]string{
+			"main.yaml": `
+- Name: main
+  Device: test
+  Options:
+    password: ${CONFIG_TEST_PASSWORD}
+- Include: ${CONFIG_TEST_SITE}/*.yaml
+- Include:
+  - extra
+`,
+			"site1/a.yaml":    "- Name: site-a\n  Device: test\n",
+			"site2/a.yaml":    "- Name: other-site\n  Device: test\n",
+			"extra/more.yaml": "- Name: more\n  Device: test\n",
+		},
+		path:   "main.yaml",
+		expect: []string{"main", "site-a", "more"},
+		sources: []string{"main.yaml", "site1/*.yaml", "site1/a.yaml",
+			"extra/*.yaml", "extra/*.yml", "extra/more.yaml"},
+	}, {
+		description: "duplicate names",
+		files: map[string]string{
+			"conf.d/a.yaml": "- Name: a\n  Device: test\n",
+			"conf.d/b.yaml": "- Name: b\n  Device: test\n- Name: a\n  Device: test\n",
+		},
+		path: "conf.d",
+		err: "conf.d/b.yaml:3: duplicate config name 'a', already defined at " +
+			"DIR/conf.d/a.yaml:1",
+	}, {
+		description: "unset environment variable",
+		files: map[string]string{
+			"main.yaml": "- Name: a\n  Device: test\n  Options:\n    x: ${CONFIG_TEST_UNSET}\n",
+		},
+		path: "main.yaml",
+		err:  "main.yaml:4: environment variable CONFIG_TEST_UNSET is not set",
+	}, {
+		description: "invalid config in included file",
+		files: map[string]string{
+			"main.yaml": "- Name: a\n  Device: test\n\n- Include: bad.yaml\n",
+			"bad.yaml":  "- Name: b\n- Name: c\n  Device: test\n  Options: [x]\n",
+		},
+		path: "main.yaml",
+		err:  "main.yaml:4: DIR/bad.yaml:1: Device in config cannot be empty",
+	}, {
+		description: "bad value",
+		files: map[string]string{
+			"main.yaml": "- Name: c\n  Device: test\n  Options: [x]\n",
+		},
+		path: "main.yaml",
+		err:  "main.yaml:3: cannot unmarshal !!seq into map[string]string",
+	}, {
+		description: "missing include",
+		files: map[string]string{
+			"main.yaml": "- Include: missing.yaml\n",
+		},
+		path: "main.yaml",
+		err:  "main.yaml:1: stat DIR/missing.yaml: no such file or directory",
+	}, {
+		description: "include cycle",
+		files: map[string]string{
+			"main.yaml":  "- Include: other.yaml\n",
+			"other.yaml": "- Include: main.yaml\n",
+		},
+		path: "main.yaml",
+		err:  "main.yaml:1: DIR/other.yaml:1: include cycle through DIR/main.yaml",
+	}, {
+		description: "include with other keys",
+		files: map[string]string{
+			"main.yaml": "- Include: other.yaml\n  Device: test\n",
+		},
+		path: "main.yaml",
+		err:  "main.yaml:1: Include can't be combined with other keys",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFiles(t, dir, tc.files)
+			configs, sources, err := ReadConfigsWithSources(filepath.Join(dir, tc.path))
+			if tc.err != "" {
+				expected := strings.ReplaceAll(tc.err, "DIR", dir)
+				if err == nil || !strings.HasSuffix(err.Error(), expected) {
+					t.Fatalf("expected error ending with %q, got %v", expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, c := range configs {
+				names = append(names, c.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expect) {
+				t.Errorf("expected configs %v, got %v", tc.expect, names)
+			}
+			var expectedSources []string
+			for _, source := range tc.sources {
+				expectedSources = append(expectedSources, filepath.Join(dir, source))
+			}
+			if !reflect.DeepEqual(sources, expectedSources) {
+				t.Errorf("expected sources %v, got %v", expectedSources, sources)
+			}
+			if tc.path == "main.yaml" && configs[0].Options["password"] != "secret" {
+				t.Errorf("environment variable not expanded in %v", configs[0].Options)
+			}
+		})
+	}
+}
+
+func TestUpdateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"main.yaml": `# Devices
+
+- Name: a
+  Device: test
+  Options:
+    password: ${CONFIG_TEST_PASSWORD}
+- Include: other.yaml
+- Name: b
+  Device: test
+- Name: c
+  Device: test
+`,
+	})
+	updateConfigFile := func(path, name string, config *Config) error {
+		update, err := prepareConfigFileUpdate(path, name, config)
+		if err != nil {
+			return err
+		}
+		return update.write()
+	}
+	path := filepath.Join(dir, "main.yaml")
+	if err := updateConfigFile(path, "b", &Config{Name: "b", Device: "test",
+		Options: map[string]string{"x": "y"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateConfigFile(path, "d", &Config{Name: "d", Device: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateConfigFile(path, "c", nil); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Comments, includes and references to environment variables are
+	// kept.
+	expected := `# Devices
+
+- Name: a
+  Device: test
+  Options:
+    password: ${CONFIG_TEST_PASSWORD}
+- Include: other.yaml
+- Name: b
+  Device: test
+  Options:
+    x: "y"
+- Name: d
+  Device: test
+`
+	if string(data) != expected {
+		t.Fatalf("expected config file:\n%s\ngot:\n%s", expected, data)
+	}
+
+	path = filepath.Join(dir, "new.yaml")
+	if err := updateConfigFile(path, "a", &Config{Name: "a", Device: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "- Name: a\n  Device: test\n" {
+		t.Fatalf("unexpected new config file %q (%v)", data, err)
+	}
+}
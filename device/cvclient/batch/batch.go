@@ -0,0 +1,457 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package batch implements a CVClient wrapper that coalesces the
+// SetRequests of a device into larger ones, to cut down on the number
+// of RPCs made to CloudVision.
+//
+// SetRequests sharing a prefix, which includes their origin and
+// target, are merged into a batch until it reaches a maximum size or
+// a maximum latency. Within a batch, later updates of a path supersede
+// earlier ones. Since gNMI applies the deletes of a SetRequest before
+// its replaces, and its replaces before its updates, a SetRequest is
+// only merged into a batch when that preserves the order of its
+// operations; otherwise the batch is flushed first.
+//
+// A batch is sent with the gRPC call options of all the SetRequests
+// merged into it, in order, so of options overriding each other, such
+// as per-RPC credentials, the last one takes effect.
+//
+// Batching makes acknowledgement asynchronous: Set returns once a
+// SetRequest is batched, before it's sent. An error sending a batch is
+// returned by the next Set or Flush of its client instead, so callers
+// needing to know their SetRequests were applied must Flush.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/provider"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// FlushReason says why a batch was sent.
+type FlushReason string
+
+const (
+	// FlushSize is for batches that reached the maximum size.
+	FlushSize FlushReason = "size"
+	// FlushLatency is for batches that reached the maximum latency.
+	FlushLatency FlushReason = "latency"
+	// FlushPrefix is for batches flushed ahead of a SetRequest with
+	// another prefix.
+	FlushPrefix FlushReason = "prefix"
+	// FlushOrder is for batches flushed ahead of a SetRequest whose
+	// operations would have been reordered by merging it.
+	FlushOrder FlushReason = "order"
+	// FlushExplicit is for batches sent by Flush.
+	FlushExplicit FlushReason = "explicit"
+)
+
+var flushReasons = []FlushReason{FlushSize, FlushLatency, FlushPrefix, FlushOrder,
+	FlushExplicit}
+
+// Metric names, as set on the MetricCollector of a Batcher. Flushes
+// are counted per reason, under MetricFlushes + "_" + reason.
+const (
+	MetricRequests      = "batch_set_requests"
+	MetricBatches       = "batch_batches"
+	MetricCoalesced     = "batch_coalesced_updates"
+	MetricLastBatchSize = "batch_last_size"
+	MetricFlushes       = "batch_flushes"
+)
+
+// Stats are the statistics of a Batcher.
+type Stats struct {
+	// Requests is the number of SetRequests merged into batches.
+	Requests int64
+	// Batches is the number of batches sent.
+	Batches int64
+	// Operations is the number of deletes, replaces and updates sent
+	// in batches.
+	Operations int64
+	// Coalesced is the number of updates superseded by later ones.
+	Coalesced int64
+	// Flushes is the number of batches sent, per flush reason.
+	Flushes map[FlushReason]int64
+}
+
+// Option configures a Batcher.
+type Option func(b *Batcher)
+
+// WithMaxSize sets the maximum number of deletes, replaces and
+// updates in a batch. It's 1000 by default.
+func WithMaxSize(n int) Option {
+	return func(b *Batcher) { b.maxSize = n }
+}
+
+// WithMaxLatency sets how long a SetRequest can wait in a batch. It's
+// 100 milliseconds by default.
+func WithMaxLatency(d time.Duration) Option {
+	return func(b *Batcher) { b.maxLatency = d }
+}
+
+// WithMetricCollector makes the batcher report its statistics to m.
+func WithMetricCollector(m provider.MetricCollector) Option {
+	return func(b *Batcher) { b.metrics = m }
+}
+
+// Batcher holds the settings and statistics shared by the batching
+// clients it creates.
+type Batcher struct {
+	maxSize    int
+	maxLatency time.Duration
+	metrics    provider.MetricCollector
+
+	lock  sync.Mutex
+	stats Stats
+}
+
+// New returns a new Batcher.
+func New(opts ...Option) (*Batcher, error) {
+	b := &Batcher{
+		maxSize:    1000,
+		maxLatency: 100 * time.Millisecond,
+		stats:      Stats{Flushes: map[FlushReason]int64{}},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.metrics != nil {
+		metrics := []struct{ name, unit, description string }{
+			{MetricRequests, "Number", "SetRequests merged into batches"},
+			{MetricBatches, "Number", "batches sent"},
+			{MetricCoalesced, "Number", "updates superseded by later ones in a batch"},
+			{MetricLastBatchSize, "Number", "operations in the last batch sent"},
+		}
+		for _, reason := range flushReasons {
+			metrics = append(metrics, struct{ name, unit, description string }{
+				MetricFlushes + "_" + string(reason), "Number",
+				"batches flushed for reason " + string(reason),
+			})
+		}
+		for _, m := range metrics {
+			if err := b.metrics.CreateMetric(m.name, m.unit, m.description); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// Stats returns the statistics of the batcher.
+func (b *Batcher) Stats() Stats {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	stats := b.stats
+	stats.Flushes = make(map[FlushReason]int64, len(b.stats.Flushes))
+	for reason, n := range b.stats.Flushes {
+		stats.Flushes[reason] = n
+	}
+	return stats
+}
+
+func (b *Batcher) setMetric(name string, v int64, inc bool) {
+	if b.metrics == nil {
+		return
+	}
+	var err error
+	if inc {
+		err = b.metrics.IncMetricInt(name, v)
+	} else {
+		err = b.metrics.SetMetricInt(name, v)
+	}
+	if err != nil {
+		logrus.Debugf("Failed to set metric %s: %v", name, err)
+	}
+}
+
+func (b *Batcher) trackRequest(coalesced int) {
+	b.lock.Lock()
+	b.stats.Requests++
+	b.stats.Coalesced += int64(coalesced)
+	b.lock.Unlock()
+	b.setMetric(MetricRequests, 1, true)
+	if coalesced > 0 {
+		b.setMetric(MetricCoalesced, int64(coalesced), true)
+	}
+}
+
+func (b *Batcher) trackFlush(reason FlushReason, size int) {
+	b.lock.Lock()
+	b.stats.Batches++
+	b.stats.Operations += int64(size)
+	b.stats.Flushes[reason]++
+	b.lock.Unlock()
+	b.setMetric(MetricBatches, 1, true)
+	b.setMetric(MetricLastBatchSize, int64(size), false)
+	b.setMetric(MetricFlushes+"_"+string(reason), 1, true)
+}
+
+// Client returns a CVClient batching the SetRequests made through it
+// before sending them with c. Its Set returns as soon as the
+// SetRequest is batched, and its call options are kept for sending
+// the batch. An error sending a batch is returned by the next Set,
+// which then doesn't batch its SetRequest, or Flush.
+func (b *Batcher) Client(c cvclient.CVClient) cvclient.CVClient {
+	return &client{CVClient: c, batcher: b}
+}
+
+// The rank of the operations of a SetRequest, in the order gNMI
+// applies them.
+const (
+	rankNone = iota - 1
+	rankDelete
+	rankReplace
+	rankUpdate
+)
+
+// pendingBatch is a batch being built.
+type pendingBatch struct {
+	ctx context.Context
+	// opts are the call options of the SetRequests of the batch.
+	opts    []grpc.CallOption
+	prefix  *gnmi.Path
+	deletes []*gnmi.Path
+	replace []*gnmi.Update
+	// update holds the updates of the batch, with nil in place of
+	// superseded ones, and updateIndex their index by path.
+	update      []*gnmi.Update
+	updateIndex map[string]int
+	size        int
+	// rank is the rank of the last operations of the batch.
+	rank  int
+	timer *time.Timer
+}
+
+func (p *pendingBatch) request() *gnmi.SetRequest {
+	req := &gnmi.SetRequest{
+		Prefix:  p.prefix,
+		Delete:  p.deletes,
+		Replace: p.replace,
+	}
+	for _, upd := range p.update {
+		if upd != nil {
+			req.Update = append(req.Update, upd)
+		}
+	}
+	return req
+}
+
+// firstRank returns the rank of the first operations of req.
+func firstRank(req *gnmi.SetRequest) int {
+	switch {
+	case len(req.Delete) > 0:
+		return rankDelete
+	case len(req.Replace) > 0:
+		return rankReplace
+	case len(req.Update) > 0:
+		return rankUpdate
+	}
+	return rankNone
+}
+
+// lastRank returns the rank of the last operations of req.
+func lastRank(req *gnmi.SetRequest) int {
+	switch {
+	case len(req.Update) > 0:
+		return rankUpdate
+	case len(req.Replace) > 0:
+		return rankReplace
+	case len(req.Delete) > 0:
+		return rankDelete
+	}
+	return rankNone
+}
+
+type client struct {
+	cvclient.CVClient
+	batcher *Batcher
+
+	// sendLock serializes sending batches, so that they're sent in
+	// order.
+	sendLock sync.Mutex
+	// lock protects pending, err and providers.
+	lock    sync.Mutex
+	pending *pendingBatch
+	// err is the first error sending a batch not yet returned by Set
+	// or Flush.
+	err error
+	// providers are the clients returned by ForProvider.
+	providers []*client
+}
+
+// take removes the pending batch, if it's the expected one, and
+// returns it. c.lock must be held.
+func (c *client) take(expected *pendingBatch) *pendingBatch {
+	p := c.pending
+	if p == nil || (expected != nil && p != expected) {
+		return nil
+	}
+	p.timer.Stop()
+	c.pending = nil
+	return p
+}
+
+// send sends batch p with ctx. c.sendLock must be held.
+func (c *client) send(ctx context.Context, p *pendingBatch, reason FlushReason) error {
+	c.batcher.trackFlush(reason, p.size)
+	if _, err := c.CVClient.Set(ctx, p.request(), p.opts...); err != nil {
+		logrus.Errorf("Error on Set of batch of %d operations: %v", p.size, err)
+		err = fmt.Errorf("Set of batch of %d operations failed: %w", p.size, err)
+		c.lock.Lock()
+		if c.err == nil {
+			c.err = err
+		}
+		c.lock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// takeErr returns the error sending a batch not yet returned, if any,
+// and forgets it. c.lock must be held.
+func (c *client) takeErr() error {
+	err := c.err
+	c.err = nil
+	return err
+}
+
+// flushLater flushes p once it reaches the maximum latency, unless it
+// was flushed already.
+func (c *client) flushLater(p *pendingBatch) {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	c.lock.Lock()
+	p = c.take(p)
+	c.lock.Unlock()
+	if p != nil {
+		_ = c.send(p.ctx, p, FlushLatency)
+	}
+}
+
+func (c *client) Set(ctx context.Context, req *gnmi.SetRequest,
+	opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	c.lock.Lock()
+	if err := c.takeErr(); err != nil {
+		c.lock.Unlock()
+		return nil, err
+	}
+	if p := c.pending; p != nil {
+		var reason FlushReason
+		if !proto.Equal(p.prefix, req.Prefix) {
+			reason = FlushPrefix
+		} else if first := firstRank(req); first != rankNone && first < p.rank {
+			reason = FlushOrder
+		}
+		if reason != "" {
+			c.take(p)
+			c.lock.Unlock()
+			_ = c.send(p.ctx, p, reason)
+			c.lock.Lock()
+		}
+	}
+	p := c.pending
+	if p == nil {
+		var prefix *gnmi.Path
+		if req.Prefix != nil {
+			// The client may set the target and origin of the
+			// prefix it sends.
+			prefix = proto.Clone(req.Prefix).(*gnmi.Path)
+		}
+		p = &pendingBatch{
+			// The batch may outlive the SetRequest that started it.
+			ctx:         context.WithoutCancel(ctx),
+			prefix:      prefix,
+			updateIndex: map[string]int{},
+			rank:        rankNone,
+		}
+		p.timer = time.AfterFunc(c.batcher.maxLatency, func() { c.flushLater(p) })
+		c.pending = p
+	}
+	coalesced := p.merge(req)
+	p.opts = append(p.opts, opts...)
+	var full *pendingBatch
+	if p.size >= c.batcher.maxSize {
+		full = c.take(p)
+	}
+	c.lock.Unlock()
+	c.batcher.trackRequest(coalesced)
+	if full != nil {
+		// The error is returned by the next Set or Flush, as for
+		// batches sent later.
+		_ = c.send(full.ctx, full, FlushSize)
+	}
+	return &gnmi.SetResponse{}, nil
+}
+
+// merge merges req into p and returns the number of updates of p it
+// superseded.
+func (p *pendingBatch) merge(req *gnmi.SetRequest) int {
+	var coalesced int
+	p.deletes = append(p.deletes, req.Delete...)
+	p.replace = append(p.replace, req.Replace...)
+	p.size += len(req.Delete) + len(req.Replace)
+	for _, upd := range req.Update {
+		key := upd.Path.GetOrigin() + ":" + agnmi.StrPath(upd.Path)
+		if i, ok := p.updateIndex[key]; ok {
+			// Drop the superseded update rather than overwrite it, so
+			// that the new one stays after any update of an enclosing
+			// path made in between.
+			p.update[i] = nil
+			p.size--
+			coalesced++
+		}
+		p.updateIndex[key] = len(p.update)
+		p.update = append(p.update, upd)
+		p.size++
+	}
+	if last := lastRank(req); last > p.rank {
+		p.rank = last
+	}
+	return coalesced
+}
+
+func (c *client) ForProvider(p provider.GNMIProvider) cvclient.CVClient {
+	pc := &client{CVClient: c.CVClient.ForProvider(p), batcher: c.batcher}
+	c.lock.Lock()
+	c.providers = append(c.providers, pc)
+	c.lock.Unlock()
+	return pc
+}
+
+// Flush sends the pending batches of c and of the clients it returned
+// for providers with ctx, and returns the first error sending them or
+// any batch since the last Set or Flush.
+func (c *client) Flush(ctx context.Context) error {
+	c.sendLock.Lock()
+	c.lock.Lock()
+	p := c.take(nil)
+	providers := c.providers
+	c.lock.Unlock()
+	if p != nil {
+		_ = c.send(ctx, p, FlushExplicit)
+	}
+	c.lock.Lock()
+	err := c.takeErr()
+	c.lock.Unlock()
+	c.sendLock.Unlock()
+	for _, pc := range providers {
+		if perr := pc.Flush(ctx); err == nil {
+			err = perr
+		}
+	}
+	return err
+}
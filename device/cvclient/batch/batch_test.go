@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/mock"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/golang/mock/gomock"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func update(path string, v int) *gnmi.Update {
+	return pgnmi.Update(pgnmi.Path(path), agnmi.TypedValue(v))
+}
+
+func TestBatching(t *testing.T) {
+	prefix := pgnmi.Path("interfaces")
+	otherPrefix := pgnmi.Path("system")
+	for _, tc := range []struct {
+		name     string
+		maxSize  int
+		requests []*gnmi.SetRequest
+		expected []*gnmi.SetRequest
+		flushes  map[FlushReason]int64
+	}{{
+		name:    "coalesce updates",
+		maxSize: 100,
+		requests: []*gnmi.SetRequest{
+			{Prefix: prefix, Update: []*gnmi.Update{update("a", 1), update("b", 1)}},
+			{Prefix: prefix, Update: []*gnmi.Update{update("a", 2)}},
+			{Prefix: prefix, Update: []*gnmi.Update{update("c", 1)}},
+		},
+		expected: []*gnmi.SetRequest{
+			{Prefix: prefix, Update: []*gnmi.Update{update("b", 1), update("a", 2),
+				update("c", 1)}},
+		},
+		flushes: map[FlushReason]int64{FlushLatency: 1},
+	}, {
+		name:    "max size",
+		maxSize: 2,
+		requests: []*gnmi.SetRequest{
+			{Prefix: prefix, Update: []*gnmi.Update{update("a", 1)}},
+			{Prefix: prefix, Update: []*gnmi.Update{update("b", 1)}},
+			{Prefix: prefix, Update: []*gnmi.Update{update("c", 1)}},
+		},
+		expected: []*gnmi.SetRequest{
+			{Prefix: prefix, Update: []*gnmi.Update{update("a", 1), update("b", 1)}},
+			{Prefix: prefix, Update: []*gnmi.Update{update("c", 1)}},
+		},
+		flushes: map[FlushReason]int64{FlushSize: 1, FlushLatency: 1},
+	}, {
+		name:    "other prefix",
+		maxSize: 100,
+		requests: []*gnmi.SetRequest{
+			{Prefix: prefix, Update: []*gnmi.Update{update("a", 1)}},
+			{Prefix: otherPrefix, Update: []*gnmi.Update{update("a", 1)}},
+		},
+		expected: []*gnmi.SetRequest{
+			{Prefix: prefix, Update: []*gnmi.Update{update("a", 1)}},
+			{Prefix: otherPrefix, Update: []*gnmi.Update{update("a", 1)}},
+		},
+		flushes: map[FlushReason]int64{FlushPrefix: 1, FlushLatency: 1},
+	}, {
+		name:    "deletes keep their order",
+		maxSize: 100,
+		requests: []*gnmi.SetRequest{
+			{Prefix: prefix, Delete: []*gnmi.Path{pgnmi.Path("a")}},
+			{Prefix: prefix, Delete: []*gnmi.Path{pgnmi.Path("b")},
+				Update: []*gnmi.Update{update("a", 1)}},
+			{Prefix: prefix, Delete: []*gnmi.Path{pgnmi.Path("a")}},
+			{Prefix: prefix, Replace: []*gnmi.Update{update("a", 2)}},
+		},
+		expected: []*gnmi.SetRequest{
+			{Prefix: prefix, Delete: []*gnmi.Path{pgnmi.Path("a"), pgnmi.Path("b")},
+				Update: []*gnmi.Update{update("a", 1)}},
+			{Prefix: prefix, Delete: []*gnmi.Path{pgnmi.Path("a")},
+				Replace: []*gnmi.Update{update("a", 2)}},
+		},
+		flushes: map[FlushReason]int64{FlushOrder: 1, FlushLatency: 1},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cvc := mock.NewMockCVClient(ctrl)
+			sent := make(chan *gnmi.SetRequest, len(tc.expected))
+			cvc.EXPECT().Set(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *gnmi.SetRequest,
+					opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+					sent <- req
+					return &gnmi.SetResponse{}, nil
+				}).Times(len(tc.expected))
+
+			b, err := New(WithMaxSize(tc.maxSize), WithMaxLatency(50*time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := b.Client(cvc)
+			for _, req := range tc.requests {
+				if _, err := c.Set(context.Background(), req); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for i, expected := range tc.expected {
+				select {
+				case req := <-sent:
+					if !proto.Equal(req, expected) {
+						t.Errorf("expected batch %d to be %v, got %v", i, expected, req)
+					}
+				case <-time.After(10 * time.Second):
+					t.Fatalf("timed out waiting for batch %d", i)
+				}
+			}
+			stats := b.Stats()
+			if stats.Requests != int64(len(tc.requests)) ||
+				stats.Batches != int64(len(tc.expected)) {
+				t.Errorf("unexpected stats %+v", stats)
+			}
+			for reason, n := range tc.flushes {
+				if stats.Flushes[reason] != n {
+					t.Errorf("expected %d %s flushes, got %+v", n, reason, stats)
+				}
+			}
+		})
+	}
+}
+
+func TestSendError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cvc := mock.NewMockCVClient(ctrl)
+	setErr := errors.New("unavailable")
+	sent := make(chan *gnmi.SetRequest, 3)
+	failing := true
+	cvc.EXPECT().Set(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *gnmi.SetRequest,
+			opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+			sent <- req
+			if failing {
+				return nil, setErr
+			}
+			return &gnmi.SetResponse{}, nil
+		}).Times(3)
+
+	b, err := New(WithMaxSize(1), WithMaxLatency(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := b.Client(cvc)
+	ctx := context.Background()
+	prefix := pgnmi.Path("interfaces")
+	req := &gnmi.SetRequest{Prefix: prefix, Update: []*gnmi.Update{update("a", 1)}}
+	// The batch is full, so it's sent by Set, but its error is only
+	// returned by the next Set, which doesn't batch its SetRequest.
+	if _, err := c.Set(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	<-sent
+	if _, err := c.Set(ctx, req); !errors.Is(err, setErr) {
+		t.Fatalf("expected error %v, got %v", setErr, err)
+	}
+	if err := cvclient.Flush(ctx, c); err != nil {
+		t.Fatalf("expected error to be returned once, got %v", err)
+	}
+
+	// Flush returns the error of the batch it sends.
+	if _, err := c.Set(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	<-sent
+	if err := cvclient.Flush(ctx, c); !errors.Is(err, setErr) {
+		t.Fatalf("expected error %v, got %v", setErr, err)
+	}
+
+	failing = false
+	if _, err := c.Set(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	<-sent
+	if err := cvclient.Flush(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if stats := b.Stats(); stats.Batches != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cvc := mock.NewMockCVClient(ctrl)
+	waitForReady, maxSize := grpc.WaitForReady(true), grpc.MaxCallSendMsgSize(1<<20)
+	cvc.EXPECT().Set(gomock.Any(), gomock.Any(), waitForReady, maxSize).Return(
+		&gnmi.SetResponse{}, nil)
+
+	b, err := New(WithMaxLatency(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := b.Client(cvc)
+	ctx := context.Background()
+	prefix := pgnmi.Path("interfaces")
+	// The batch is sent with the call options of all its SetRequests.
+	for _, req := range []struct {
+		update *gnmi.Update
+		opts   []grpc.CallOption
+	}{
+		{update("a", 1), []grpc.CallOption{waitForReady}},
+		{update("b", 1), nil},
+		{update("c", 1), []grpc.CallOption{maxSize}},
+	} {
+		if _, err := c.Set(ctx, &gnmi.SetRequest{Prefix: prefix,
+			Update: []*gnmi.Update{req.update}}, req.opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cvclient.Flush(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -31,3 +31,31 @@ type CVClient interface {
 	// is pushed in the next heartbeat cycle.
 	SetManagedDevices([]string)
 }
+
+// A Flusher is a CVClient that holds back some of the SetRequests made
+// through it, such as to batch them.
+type Flusher = provider.Flusher
+
+// Flush flushes c if it's a Flusher.
+func Flush(ctx context.Context, c CVClient) error {
+	return provider.Flush(ctx, c)
+}
+
+// DeviceMetadata describes a device beyond its ID and type. Fields
+// left empty aren't known.
+type DeviceMetadata struct {
+	Hostname        string
+	Vendor          string
+	Model           string
+	SerialNumber    string
+	SoftwareVersion string
+	// Labels are arbitrary key-value pairs describing the device.
+	Labels map[string]string
+}
+
+// A MetadataProvider reports the DeviceMetadata of a device. Clients
+// send it along with the rest of the device metadata, and check it
+// again periodically to send it again when it changes.
+type MetadataProvider interface {
+	Metadata(ctx context.Context) (*DeviceMetadata, error)
+}
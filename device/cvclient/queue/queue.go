@@ -0,0 +1,572 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package queue implements a disk-backed store-and-forward queue for
+// the SetRequests sent to CloudVision. While CloudVision can't be
+// reached, the SetRequests of each device are saved to disk in order,
+// and they're replayed once it can be reached again.
+//
+// gNMI SetRequests have no timestamp, and CloudVision timestamps the
+// data it ingests with the time it receives it, so replayed data is
+// timestamped with the time it's replayed rather than the time it was
+// made: the queue keeps the data of an outage from being lost, but
+// doesn't fill the gap it leaves in the history of the data, which
+// would take an ingest API timestamping data from its payload.
+package queue
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/gen"
+	"github.com/aristanetworks/cloudvision-go/device/record"
+	"github.com/aristanetworks/cloudvision-go/provider"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TimestampMetadata is the gRPC metadata key under which replayed
+// SetRequests carry the time they were originally made, in
+// nanoseconds since the Unix epoch. CloudVision ignores it: only
+// servers asked to, such as LocalCV with -originalTimestamps, use it
+// to timestamp the data.
+const TimestampMetadata = "cv-original-timestamp"
+
+// Metric names, as set on the MetricCollector of a Queue.
+const (
+	MetricRequests = "store_forward_queue_requests"
+	MetricBytes    = "store_forward_queue_bytes"
+	MetricDropped  = "store_forward_dropped_requests"
+	MetricReplayed = "store_forward_replayed_requests"
+)
+
+// DropPolicy says which SetRequests are dropped when a device's queue
+// is full.
+type DropPolicy int
+
+const (
+	// DropOldest drops the oldest SetRequests to make room for new
+	// ones.
+	DropOldest DropPolicy = iota
+	// DropNewest drops new SetRequests until there's room for them.
+	DropNewest
+)
+
+// ParseDropPolicy returns the DropPolicy named "oldest" or "newest".
+func ParseDropPolicy(s string) (DropPolicy, error) {
+	switch s {
+	case "oldest":
+		return DropOldest, nil
+	case "newest":
+		return DropNewest, nil
+	}
+	return 0, fmt.Errorf("unknown drop policy %q, should be oldest or newest", s)
+}
+
+// Stats are the statistics of a Queue.
+type Stats struct {
+	// Requests and Bytes are the number and size of the SetRequests
+	// waiting to be replayed.
+	Requests int
+	Bytes    int64
+	// Dropped and Replayed count the SetRequests dropped and replayed
+	// since the queue was opened.
+	Dropped  int64
+	Replayed int64
+}
+
+// Option configures a Queue.
+type Option func(q *Queue)
+
+// WithMaxBytes limits the size of the SetRequests queued for each
+// device. 0, the default, means no limit.
+func WithMaxBytes(n int64) Option {
+	return func(q *Queue) { q.maxBytes = n }
+}
+
+// WithMaxAge drops queued SetRequests older than d. 0, the default,
+// means no limit.
+func WithMaxAge(d time.Duration) Option {
+	return func(q *Queue) { q.maxAge = d }
+}
+
+// WithDropPolicy sets which SetRequests are dropped when a device's
+// queue is full. It's DropOldest by default.
+func WithDropPolicy(p DropPolicy) Option {
+	return func(q *Queue) { q.policy = p }
+}
+
+// WithRetryInterval sets how often replaying is retried while
+// CloudVision can't be reached. It's 5 seconds by default.
+func WithRetryInterval(d time.Duration) Option {
+	return func(q *Queue) { q.retryInterval = d }
+}
+
+// WithMetricCollector makes the queue report its statistics to m.
+func WithMetricCollector(m provider.MetricCollector) Option {
+	return func(q *Queue) { q.metrics = m }
+}
+
+// Queue queues the SetRequests of devices while upstream is
+// unavailable. Each device's SetRequests are kept in a directory of
+// their own, one file per SetRequest.
+type Queue struct {
+	dir           string
+	upstream      gnmi.GNMIClient
+	maxBytes      int64
+	maxAge        time.Duration
+	policy        DropPolicy
+	retryInterval time.Duration
+	metrics       provider.MetricCollector
+	log           *logrus.Entry
+
+	kick chan struct{}
+
+	lock     sync.Mutex
+	devices  map[string]*deviceQueue
+	dropped  int64
+	replayed int64
+}
+
+// entry is a queued SetRequest.
+type entry struct {
+	seq  uint64
+	size int64
+	time time.Time
+}
+
+// deviceQueue holds the SetRequests of one device.
+type deviceQueue struct {
+	id  string
+	dir string
+	// sendLock serializes replays, and direct sends with them, so
+	// that SetRequests are sent in order.
+	sendLock sync.Mutex
+	// entries, bytes, nextSeq and replaying are protected by the
+	// Queue's lock.
+	entries []entry
+	bytes   int64
+	nextSeq uint64
+	// replaying is set from the first replay of the entries until
+	// they've all been replayed.
+	replaying bool
+}
+
+// Open opens the queue kept in dir, creating it if needed, which
+// replays the SetRequests it queues to upstream. SetRequests queued by
+// a previous run are replayed too.
+func Open(dir string, upstream gnmi.GNMIClient, opts ...Option) (*Queue, error) {
+	q := &Queue{
+		dir:           dir,
+		upstream:      upstream,
+		retryInterval: 5 * time.Second,
+		log:           logrus.WithField("queue", dir),
+		kick:          make(chan struct{}, 1),
+		devices:       map[string]*deviceQueue{},
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	dirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		id, err := url.PathUnescape(d.Name())
+		if err != nil {
+			continue
+		}
+		dq, err := loadDeviceQueue(id, filepath.Join(dir, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		q.devices[id] = dq
+	}
+	if q.metrics != nil {
+		for _, m := range []struct{ name, unit, description string }{
+			{MetricRequests, "Number", "SetRequests waiting to be replayed"},
+			{MetricBytes, "Bytes", "size of the SetRequests waiting to be replayed"},
+			{MetricDropped, "Number", "SetRequests dropped from the queue"},
+			{MetricReplayed, "Number", "SetRequests replayed from the queue"},
+		} {
+			if err := q.metrics.CreateMetric(m.name, m.unit, m.description); err != nil {
+				return nil, err
+			}
+		}
+	}
+	q.lock.Lock()
+	q.updateMetrics()
+	q.lock.Unlock()
+	return q, nil
+}
+
+func loadDeviceQueue(id, dir string) (*deviceQueue, error) {
+	dq := &deviceQueue{id: id, dir: dir}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		seq, err := strconv.ParseUint(f.Name(), 10, 64)
+		if err != nil {
+			// Leftover temporary file.
+			os.Remove(filepath.Join(dir, f.Name()))
+			continue
+		}
+		t, size, err := readEntryHeader(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		dq.entries = append(dq.entries, entry{seq: seq, size: size, time: t})
+		dq.bytes += size
+	}
+	sort.Slice(dq.entries, func(i, j int) bool { return dq.entries[i].seq < dq.entries[j].seq })
+	if n := len(dq.entries); n > 0 {
+		dq.nextSeq = dq.entries[n-1].seq + 1
+	}
+	return dq, nil
+}
+
+// Entries are files holding the time of the SetRequest, as 8 bytes of
+// nanoseconds since the Unix epoch, followed by its gen.Record, which
+// keeps the outgoing gRPC metadata it was made with.
+const entryHeaderSize = 8
+
+func (dq *deviceQueue) path(seq uint64) string {
+	return filepath.Join(dq.dir, fmt.Sprintf("%020d", seq))
+}
+
+func readEntryHeader(path string) (time.Time, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	var header [entryHeaderSize]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		return time.Time{}, 0, fmt.Errorf("bad queue entry %s: %w", path, err)
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(header[:]))), info.Size(), nil
+}
+
+func (dq *deviceQueue) read(e entry) (*gen.Record, error) {
+	b, err := os.ReadFile(dq.path(e.seq))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < entryHeaderSize {
+		return nil, fmt.Errorf("bad queue entry %s", dq.path(e.seq))
+	}
+	rec := &gen.Record{}
+	if err := proto.Unmarshal(b[entryHeaderSize:], rec); err != nil {
+		return nil, fmt.Errorf("bad queue entry %s: %w", dq.path(e.seq), err)
+	}
+	return rec, nil
+}
+
+func encodeEntry(rec *gen.Record) ([]byte, error) {
+	t := time.Unix(0, rec.Timestamp)
+	b, err := proto.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	return append(binary.BigEndian.AppendUint64(nil, uint64(t.UnixNano())), b...), nil
+}
+
+func (dq *deviceQueue) write(seq uint64, b []byte) error {
+	if err := os.MkdirAll(dq.dir, 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that a crash doesn't leave
+	// a truncated entry behind.
+	tmp := dq.path(seq) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dq.path(seq)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// deviceQueue returns the queue of the specified device.
+func (q *Queue) deviceQueue(id string) *deviceQueue {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	dq, ok := q.devices[id]
+	if !ok {
+		dq = &deviceQueue{id: id, dir: filepath.Join(q.dir, url.PathEscape(id))}
+		q.devices[id] = dq
+	}
+	return dq
+}
+
+// updateMetrics reports the queue's statistics. q.lock must be held.
+func (q *Queue) updateMetrics() {
+	if q.metrics == nil {
+		return
+	}
+	stats := q.stats()
+	for name, v := range map[string]int64{
+		MetricRequests: int64(stats.Requests),
+		MetricBytes:    stats.Bytes,
+		MetricDropped:  stats.Dropped,
+		MetricReplayed: stats.Replayed,
+	} {
+		if err := q.metrics.SetMetricInt(name, v); err != nil {
+			q.log.Debugf("Failed to set metric %s: %v", name, err)
+		}
+	}
+}
+
+func (q *Queue) stats() Stats {
+	stats := Stats{Dropped: q.dropped, Replayed: q.replayed}
+	for _, dq := range q.devices {
+		stats.Requests += len(dq.entries)
+		stats.Bytes += dq.bytes
+	}
+	return stats
+}
+
+// Stats returns the statistics of the queue.
+func (q *Queue) Stats() Stats {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.stats()
+}
+
+// pending returns the number of SetRequests queued for dq.
+func (q *Queue) pending(dq *deviceQueue) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(dq.entries)
+}
+
+// remove removes the oldest queued SetRequest of dq, counting it as
+// dropped or replayed. q.lock must be held.
+func (q *Queue) remove(dq *deviceQueue, dropped bool) {
+	e := dq.entries[0]
+	if err := os.Remove(dq.path(e.seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		q.log.Errorf("Failed to remove queue entry: %v", err)
+	}
+	dq.entries = dq.entries[1:]
+	dq.bytes -= e.size
+	if dropped {
+		q.dropped++
+	} else {
+		q.replayed++
+	}
+}
+
+// expire drops the SetRequests of dq older than the queue's max age.
+// q.lock must be held.
+func (q *Queue) expire(dq *deviceQueue, now time.Time) {
+	if q.maxAge <= 0 {
+		return
+	}
+	n := 0
+	for len(dq.entries) > 0 && now.Sub(dq.entries[0].time) > q.maxAge {
+		q.remove(dq, true)
+		n++
+	}
+	if n > 0 {
+		q.log.Infof("Dropped %d queued SetRequests of device %s older than %v",
+			n, dq.id, q.maxAge)
+	}
+}
+
+// push queues req, made at time t with ctx, for dq.
+func (q *Queue) push(ctx context.Context, dq *deviceQueue, t time.Time,
+	req *gnmi.SetRequest) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	defer q.updateMetrics()
+	q.expire(dq, t)
+	b, err := encodeEntry(record.New(ctx, t, dq.id, req))
+	if err != nil {
+		return err
+	}
+	size := int64(len(b))
+	if q.maxBytes > 0 {
+		if (q.policy == DropNewest || size > q.maxBytes) && dq.bytes+size > q.maxBytes {
+			q.dropped++
+			q.log.Debugf("Queue of device %s is full, dropping SetRequest", dq.id)
+			return nil
+		}
+		for len(dq.entries) > 0 && dq.bytes+size > q.maxBytes {
+			q.remove(dq, true)
+			q.log.Debugf("Queue of device %s is full, dropping oldest SetRequest", dq.id)
+		}
+	}
+	seq := dq.nextSeq
+	if err := dq.write(seq, b); err != nil {
+		return fmt.Errorf("failed to queue SetRequest: %w", err)
+	}
+	dq.nextSeq++
+	dq.entries = append(dq.entries, entry{seq: seq, size: size, time: t})
+	dq.bytes += size
+	return nil
+}
+
+// unavailable returns whether err means that upstream can't be
+// reached right now, as opposed to it rejecting the SetRequest.
+func unavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// replay sends the SetRequests queued for dq to upstream, in order,
+// until they've all been sent or upstream is unavailable. It returns
+// false in the latter case.
+func (q *Queue) replay(ctx context.Context, dq *deviceQueue) bool {
+	dq.sendLock.Lock()
+	defer dq.sendLock.Unlock()
+	for {
+		q.lock.Lock()
+		q.expire(dq, time.Now())
+		if len(dq.entries) == 0 {
+			dq.replaying = false
+			q.updateMetrics()
+			q.lock.Unlock()
+			return true
+		}
+		e := dq.entries[0]
+		if !dq.replaying {
+			dq.replaying = true
+			q.log.Warnf("Replaying %d queued SetRequests of device %s made since %v, "+
+				"which CloudVision timestamps with the time they're replayed",
+				len(dq.entries), dq.id, e.time)
+		}
+		q.lock.Unlock()
+
+		rec, err := dq.read(e)
+		if err == nil {
+			_, err = q.upstream.Set(outgoingContext(ctx, rec), rec.Request)
+			if unavailable(err) || ctx.Err() != nil {
+				return false
+			}
+		}
+		q.lock.Lock()
+		if err != nil {
+			// It will never be accepted, so don't hold the others
+			// back for it.
+			q.log.Errorf("Dropping queued SetRequest of device %s: %v", dq.id, err)
+		}
+		q.remove(dq, err != nil)
+		q.updateMetrics()
+		q.lock.Unlock()
+	}
+}
+
+// outgoingContext returns ctx with the outgoing gRPC metadata the
+// SetRequest of rec was made with, and its original timestamp.
+func outgoingContext(ctx context.Context, rec *gen.Record) context.Context {
+	md := metadata.MD{}
+	for _, m := range rec.Metadata {
+		md.Append(m.Key, m.Values...)
+	}
+	md.Set(TimestampMetadata, strconv.FormatInt(rec.Timestamp, 10))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Run replays queued SetRequests whenever upstream is available, until
+// ctx is done.
+func (q *Queue) Run(ctx context.Context) error {
+	ticker := time.NewTicker(q.retryInterval)
+	defer ticker.Stop()
+	for {
+		q.lock.Lock()
+		var devices []*deviceQueue
+		for _, dq := range q.devices {
+			if len(dq.entries) > 0 {
+				devices = append(devices, dq)
+			}
+		}
+		q.lock.Unlock()
+		sort.Slice(devices, func(i, j int) bool { return devices[i].id < devices[j].id })
+		for _, dq := range devices {
+			if !q.replay(ctx, dq) {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-q.kick:
+		}
+	}
+}
+
+// Client returns a gnmi.GNMIClient sending the SetRequests of the
+// specified device to upstream, or queueing them if upstream is
+// unavailable or earlier ones are still queued. Other calls go
+// straight to upstream.
+func (q *Queue) Client(deviceID string) gnmi.GNMIClient {
+	return &client{GNMIClient: q.upstream, q: q, dq: q.deviceQueue(deviceID)}
+}
+
+type client struct {
+	gnmi.GNMIClient
+	q  *Queue
+	dq *deviceQueue
+}
+
+func (c *client) Set(ctx context.Context, req *gnmi.SetRequest,
+	opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+	now := time.Now()
+	if c.q.pending(c.dq) == 0 {
+		c.dq.sendLock.Lock()
+		// A replay may have been queued meanwhile.
+		if c.q.pending(c.dq) == 0 {
+			resp, err := c.GNMIClient.Set(ctx, req, opts...)
+			c.dq.sendLock.Unlock()
+			if !unavailable(err) || ctx.Err() != nil {
+				return resp, err
+			}
+			c.q.log.Infof("Upstream unavailable, queueing SetRequests of device %s: %v",
+				c.dq.id, err)
+		} else {
+			c.dq.sendLock.Unlock()
+		}
+	}
+	if err := c.q.push(ctx, c.dq, now, req); err != nil {
+		return nil, err
+	}
+	select {
+	case c.q.kick <- struct{}{}:
+	default:
+	}
+	return &gnmi.SetResponse{}, nil
+}
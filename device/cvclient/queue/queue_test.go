@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package queue
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/record"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// upstream records the SetRequests it gets, along with their
+// original timestamps and device IDs, and is unavailable while down
+// is set.
+type upstream struct {
+	down atomic.Bool
+
+	lock       sync.Mutex
+	values     []int64
+	timestamps []int64
+	deviceIDs  []string
+}
+
+func (u *upstream) process(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse,
+	error) {
+	if u.down.Load() {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	var ts int64
+	var deviceID string
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if v := md.Get(TimestampMetadata); len(v) > 0 {
+			ts, _ = strconv.ParseInt(v[0], 10, 64)
+		}
+		if v := md.Get("deviceid"); len(v) > 0 {
+			deviceID = v[0]
+		}
+	}
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.values = append(u.values, req.Update[0].Val.GetIntVal())
+	u.timestamps = append(u.timestamps, ts)
+	u.deviceIDs = append(u.deviceIDs, deviceID)
+	return &gnmi.SetResponse{}, nil
+}
+
+func (u *upstream) received() ([]int64, []int64) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return append([]int64(nil), u.values...), append([]int64(nil), u.timestamps...)
+}
+
+func setRequest(i int) *gnmi.SetRequest {
+	return &gnmi.SetRequest{
+		Update: []*gnmi.Update{pgnmi.Update(pgnmi.Path("counter"), agnmi.TypedValue(i))},
+	}
+}
+
+func checkValues(t *testing.T, got []int64, expected ...int64) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("expected values %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("expected values %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	dir := t.TempDir()
+	u := &upstream{}
+	gc := pgnmi.NewSimpleGNMIClient(u.process)
+	q, err := Open(dir, gc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Like the v1 client, describe the device with gRPC metadata.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "deviceid", "dev/1")
+	client := q.Client("dev/1")
+
+	// SetRequests go straight through while upstream is available.
+	if _, err := client.Set(ctx, setRequest(0)); err != nil {
+		t.Fatal(err)
+	}
+	values, timestamps := u.received()
+	checkValues(t, values, 0)
+	if timestamps[0] != 0 {
+		t.Errorf("expected no timestamp on direct SetRequest, got %d", timestamps[0])
+	}
+
+	// They're queued while it isn't.
+	u.down.Store(true)
+	start := time.Now()
+	for i := 1; i <= 3; i++ {
+		if _, err := client.Set(ctx, setRequest(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := q.Stats(); stats.Requests != 3 || stats.Bytes == 0 {
+		t.Fatalf("expected 3 queued SetRequests, got %+v", stats)
+	}
+
+	// They survive restarts, and SetRequests made while some are
+	// queued are queued behind them even if upstream is back.
+	q, err = Open(dir, gc, WithRetryInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.down.Store(false)
+	if _, err := q.Client("dev/1").Set(ctx, setRequest(4)); err != nil {
+		t.Fatal(err)
+	}
+	values, _ = u.received()
+	checkValues(t, values, 0)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		q.Run(runCtx)
+		close(done)
+	}()
+	deadline := time.Now().Add(10 * time.Second)
+	for q.Stats().Requests > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for replay")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	values, timestamps = u.received()
+	checkValues(t, values, 0, 1, 2, 3, 4)
+	for i, ts := range timestamps[1:] {
+		if ts < start.UnixNano() || ts > time.Now().UnixNano() {
+			t.Errorf("unexpected original timestamp %d for SetRequest %d", ts, i+1)
+		}
+	}
+	u.lock.Lock()
+	for i, id := range u.deviceIDs {
+		if id != "dev/1" {
+			t.Errorf("expected device ID metadata dev/1 for SetRequest %d, got %q", i, id)
+		}
+	}
+	u.lock.Unlock()
+	if stats := q.Stats(); stats.Replayed != 4 || stats.Dropped != 0 || stats.Bytes != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestQueueLimits(t *testing.T) {
+	size := func(i int) int64 {
+		b, err := encodeEntry(record.New(context.Background(), time.Now(), "dev",
+			setRequest(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return int64(len(b))
+	}
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		sleep    time.Duration
+		expected []int64
+	}{{
+		name:     "drop oldest",
+		opts:     []Option{WithMaxBytes(2 * size(1))},
+		expected: []int64{2, 3},
+	}, {
+		name:     "drop newest",
+		opts:     []Option{WithMaxBytes(2 * size(1)), WithDropPolicy(DropNewest)},
+		expected: []int64{1, 2},
+	}, {
+		name:     "max age",
+		opts:     []Option{WithMaxAge(50 * time.Millisecond)},
+		sleep:    100 * time.Millisecond,
+		expected: []int64{3},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &upstream{}
+			u.down.Store(true)
+			q, err := Open(t.TempDir(), pgnmi.NewSimpleGNMIClient(u.process), tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := q.Client("dev")
+			for i := 1; i <= 3; i++ {
+				if i == 3 {
+					time.Sleep(tc.sleep)
+				}
+				if _, err := client.Set(ctx, setRequest(i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			u.down.Store(false)
+			runCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go q.Run(runCtx)
+			deadline := time.Now().Add(10 * time.Second)
+			for q.Stats().Requests > 0 {
+				if time.Now().After(deadline) {
+					t.Fatal("timed out waiting for replay")
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+			values, _ := u.received()
+			checkValues(t, values, tc.expected...)
+			if stats := q.Stats(); stats.Dropped != int64(3-len(tc.expected)) {
+				t.Errorf("unexpected stats %+v", stats)
+			}
+		})
+	}
+}
+
+func TestParseDropPolicy(t *testing.T) {
+	for s, expected := range map[string]DropPolicy{"oldest": DropOldest, "newest": DropNewest} {
+		if p, err := ParseDropPolicy(s); err != nil || p != expected {
+			t.Errorf("ParseDropPolicy(%q) = %v, %v", s, p, err)
+		}
+	}
+	if _, err := ParseDropPolicy("random"); err == nil {
+		t.Error("expected error for unknown drop policy")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package cvclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FetchTimeout bounds the time a Refresher waits for its value, so that
+// an unresponsive device doesn't hold up its client.
+const FetchTimeout = 10 * time.Second
+
+// A Refresher fetches a value reported by a device, such as its
+// metadata, and refreshes it in the background, so that heartbeats can
+// publish the value last fetched without waiting for the device.
+type Refresher[T any] struct {
+	fetch func(context.Context) (T, error)
+
+	// lock protects the fields below.
+	lock sync.Mutex
+	// value is the value last fetched, if fetched is set.
+	value    T
+	fetched  bool
+	fetching bool
+	wg       sync.WaitGroup
+}
+
+// NewRefresher returns a Refresher fetching its value with fetch.
+func NewRefresher[T any](fetch func(context.Context) (T, error)) *Refresher[T] {
+	return &Refresher[T]{fetch: fetch}
+}
+
+// Fetch fetches the value, waiting at most FetchTimeout for it.
+func (r *Refresher[T]) Fetch(ctx context.Context) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, FetchTimeout)
+	defer cancel()
+	v, err := r.fetch(ctx)
+	if err == nil {
+		r.lock.Lock()
+		r.value, r.fetched = v, true
+		r.lock.Unlock()
+	}
+	return v, err
+}
+
+// Refresh fetches the value in the background, unless it's being
+// fetched already. The fetch isn't canceled along with ctx.
+func (r *Refresher[T]) Refresh(ctx context.Context) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.fetching {
+		return
+	}
+	r.fetching = true
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		_, _ = r.Fetch(context.WithoutCancel(ctx))
+		r.lock.Lock()
+		r.fetching = false
+		r.lock.Unlock()
+	}()
+}
+
+// Latest returns the value last fetched, and false if it was never
+// fetched.
+func (r *Refresher[T]) Latest() (T, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.value, r.fetched
+}
+
+// Wait waits for the background fetch started by Refresh, if any, to
+// finish.
+func (r *Refresher[T]) Wait() {
+	r.wg.Wait()
+}
@@ -6,7 +6,11 @@ package v1
 
 import (
 	"context"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/metadata"
@@ -22,6 +26,9 @@ type Metadata struct {
 	DeviceType       *string
 	Alive            *bool
 	CollectorVersion string
+	// DeviceMetadata is the metadata of the device's metadata
+	// provider, if it was sent.
+	DeviceMetadata *cvclient.DeviceMetadata
 }
 
 const (
@@ -31,8 +38,66 @@ const (
 	deviceTypeMetadata       = "deviceType"
 	deviceLivenessMetadata   = "deviceLiveness"
 	collectorVersionMetadata = "collectorVersion"
+	hostnameMetadata         = "hostname"
+	vendorMetadata           = "vendor"
+	modelMetadata            = "model"
+	serialNumberMetadata     = "serialNumber"
+	softwareVersionMetadata  = "softwareVersion"
+	// deviceLabelMetadata has a key=value value per label.
+	deviceLabelMetadata = "deviceLabel"
 )
 
+// deviceMetadataPairs returns the metadata key-value pairs of md. Empty
+// fields are sent too, so that they're cleared when no longer known.
+func deviceMetadataPairs(md *cvclient.DeviceMetadata) []string {
+	kv := []string{
+		hostnameMetadata, md.Hostname,
+		vendorMetadata, md.Vendor,
+		modelMetadata, md.Model,
+		serialNumberMetadata, md.SerialNumber,
+		softwareVersionMetadata, md.SoftwareVersion,
+	}
+	keys := make([]string, 0, len(md.Labels))
+	for k := range md.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		kv = append(kv, deviceLabelMetadata, k+"="+md.Labels[k])
+	}
+	return kv
+}
+
+// newDeviceMetadata returns the device metadata of md, or nil if it has
+// none.
+func newDeviceMetadata(md metadata.MD) *cvclient.DeviceMetadata {
+	if len(md.Get(hostnameMetadata)) == 0 {
+		return nil
+	}
+	get := func(key string) string {
+		if v := md.Get(key); len(v) != 0 {
+			return v[0]
+		}
+		return ""
+	}
+	ret := &cvclient.DeviceMetadata{
+		Hostname:        get(hostnameMetadata),
+		Vendor:          get(vendorMetadata),
+		Model:           get(modelMetadata),
+		SerialNumber:    get(serialNumberMetadata),
+		SoftwareVersion: get(softwareVersionMetadata),
+	}
+	for _, label := range md.Get(deviceLabelMetadata) {
+		if k, v, ok := strings.Cut(label, "="); ok {
+			if ret.Labels == nil {
+				ret.Labels = map[string]string{}
+			}
+			ret.Labels[k] = v
+		}
+	}
+	return ret
+}
+
 // NewMetadataFromOutgoing returns a metadata from an outgoing context.
 func NewMetadataFromOutgoing(ctx context.Context) (Metadata, error) {
 	ret := Metadata{}
@@ -98,5 +163,7 @@ func newMetadata(md metadata.MD) (Metadata, error) {
 		ret.CollectorVersion = collectorVersionVal[0]
 	}
 
+	ret.DeviceMetadata = newDeviceMetadata(md)
+
 	return ret, nil
 }
@@ -8,7 +8,12 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -53,6 +58,29 @@ func TestNewMetadata(t *testing.T) {
 				TypeCheck:  true,
 			},
 		},
+		{
+			desc: "device metadata",
+			ctx: metadata.AppendToOutgoingContext(
+				context.Background(),
+				deviceIDMetadata, "id",
+				typeCheckMetadata, "true",
+				openConfigMetadata, "true",
+				hostnameMetadata, "switch1",
+				vendorMetadata, "Arista Networks",
+				modelMetadata, "",
+				deviceLabelMetadata, "rack=r1",
+				deviceLabelMetadata, "role=leaf"),
+			md: Metadata{
+				DeviceID:   "id",
+				OpenConfig: true,
+				TypeCheck:  true,
+				DeviceMetadata: &cvclient.DeviceMetadata{
+					Hostname: "switch1",
+					Vendor:   "Arista Networks",
+					Labels:   map[string]string{"rack": "r1", "role": "leaf"},
+				},
+			},
+		},
 		{
 			desc: "missing device ID",
 			ctx: metadata.AppendToOutgoingContext(
@@ -100,3 +128,70 @@ func TestNewMetadata(t *testing.T) {
 		})
 	}
 }
+
+type testMetadataProvider struct {
+	md *cvclient.DeviceMetadata
+}
+
+func (p *testMetadataProvider) Metadata(ctx context.Context) (*cvclient.DeviceMetadata,
+	error) {
+	return p.md, nil
+}
+
+func TestSendProvidedMetadata(t *testing.T) {
+	var sent []Metadata
+	gc := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		md, err := NewMetadataFromOutgoing(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent = append(sent, md)
+		return &gnmi.SetResponse{}, nil
+	})
+	p := &testMetadataProvider{md: &cvclient.DeviceMetadata{
+		Hostname: "switch1",
+		Labels:   map[string]string{"rack": "r1"},
+	}}
+	c := NewV1Client(gc, "id", false, WithMetadataProvider(p)).(*v1client)
+	ctx := context.Background()
+	if err := c.SendDeviceMetadata(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Heartbeats send it again only when it's due and has changed,
+	// once fetched in the background.
+	if err := c.SendHeartbeat(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	c.metadataChecked = time.Time{}
+	if err := c.SendHeartbeat(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	c.metadataRefresher.Wait()
+	p.md = &cvclient.DeviceMetadata{Hostname: "switch2"}
+	c.metadataChecked = time.Time{}
+	if err := c.SendHeartbeat(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	c.metadataRefresher.Wait()
+	if err := c.SendHeartbeat(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*cvclient.DeviceMetadata{
+		{Hostname: "switch1", Labels: map[string]string{"rack": "r1"}},
+		nil,
+		nil,
+		nil,
+		{Hostname: "switch2"},
+	}
+	if len(sent) != len(expected) {
+		t.Fatalf("expected %d Sets, got %d", len(expected), len(sent))
+	}
+	for i, md := range expected {
+		if !reflect.DeepEqual(sent[i].DeviceMetadata, md) {
+			t.Errorf("Set %d: expected device metadata %+v, got %+v", i, md,
+				sent[i].DeviceMetadata)
+		}
+	}
+}
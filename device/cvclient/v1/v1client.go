@@ -8,11 +8,16 @@ package v1
 
 import (
 	"context"
+	"reflect"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/log"
 	"github.com/aristanetworks/cloudvision-go/provider"
 	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/aristanetworks/cloudvision-go/tracing"
 	"github.com/aristanetworks/cloudvision-go/version"
 
 	"github.com/openconfig/gnmi/proto/gnmi"
@@ -26,8 +31,38 @@ type v1client struct {
 	isMgmtSystem bool
 	openConfig   bool
 	typeCheck    bool
+
+	// The metadata of a metadata provider, the metadata last sent, and
+	// when it was last checked for changes.
+	metadataRefresher *cvclient.Refresher[*cvclient.DeviceMetadata]
+	metadataLock      sync.Mutex
+	metadata          *cvclient.DeviceMetadata
+	metadataChecked   time.Time
+}
+
+// An Option configures a v1 client.
+type Option func(*v1client)
+
+// WithMetadataProvider makes the client send the metadata reported by
+// p along with the rest of the device metadata, and again with
+// heartbeats when it changes.
+func WithMetadataProvider(p cvclient.MetadataProvider) Option {
+	return func(c *v1client) {
+		c.metadataRefresher = cvclient.NewRefresher(
+			func(ctx context.Context) (*cvclient.DeviceMetadata, error) {
+				md, err := p.Metadata(ctx)
+				if err != nil {
+					log.Log(c).Debugf("v1client: error in Metadata [%s]: %s", c.deviceID, err)
+				}
+				return md, err
+			})
+	}
 }
 
+// metadataCheckInterval bounds how often heartbeats check the metadata
+// of the metadata provider for changes.
+const metadataCheckInterval = time.Minute
+
 func (c *v1client) ctxWithDeviceMetadata(ctx context.Context) context.Context {
 	return metadata.AppendToOutgoingContext(ctx,
 		deviceIDMetadata, c.deviceID,
@@ -49,8 +84,11 @@ func (c *v1client) Get(ctx context.Context, in *gnmi.GetRequest,
 
 func (c *v1client) Set(ctx context.Context, in *gnmi.SetRequest,
 	opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+	ctx, span := tracing.StartSet(ctx, c.deviceID, in)
 	ctx = c.ctxWithDeviceMetadata(ctx)
-	return c.gnmiClient.Set(ctx, in, opts...)
+	resp, err := c.gnmiClient.Set(ctx, in, opts...)
+	tracing.End(span, err)
+	return resp, err
 }
 
 func (c *v1client) Subscribe(ctx context.Context,
@@ -59,7 +97,46 @@ func (c *v1client) Subscribe(ctx context.Context,
 	return c.gnmiClient.Subscribe(ctx, opts...)
 }
 
+// ctxWithProvidedMetadata adds the metadata of the metadata provider,
+// if there's one, to ctx. If onlyChanged is true, the metadata last
+// fetched is added, and only if it differs from the last metadata
+// added, and it's fetched again in the background once
+// metadataCheckInterval has passed since it was last checked.
+func (c *v1client) ctxWithProvidedMetadata(ctx context.Context,
+	onlyChanged bool) context.Context {
+	if c.metadataRefresher == nil {
+		return ctx
+	}
+	var md *cvclient.DeviceMetadata
+	if onlyChanged {
+		md, _ = c.metadataRefresher.Latest()
+		c.metadataLock.Lock()
+		if time.Since(c.metadataChecked) >= metadataCheckInterval {
+			c.metadataChecked = time.Now()
+			c.metadataRefresher.Refresh(ctx)
+		}
+		c.metadataLock.Unlock()
+	} else {
+		c.metadataLock.Lock()
+		c.metadataChecked = time.Now()
+		c.metadataLock.Unlock()
+		var err error
+		if md, err = c.metadataRefresher.Fetch(ctx); err != nil {
+			return ctx
+		}
+	}
+
+	c.metadataLock.Lock()
+	defer c.metadataLock.Unlock()
+	if md == nil || (onlyChanged && reflect.DeepEqual(md, c.metadata)) {
+		return ctx
+	}
+	c.metadata = md
+	return metadata.AppendToOutgoingContext(ctx, deviceMetadataPairs(md)...)
+}
+
 func (c *v1client) SendDeviceMetadata(ctx context.Context) error {
+	ctx = c.ctxWithProvidedMetadata(ctx, false)
 	ctx = metadata.AppendToOutgoingContext(ctx, collectorVersionMetadata, version.Version)
 	if c.isMgmtSystem {
 		// ManagementSystem is a system managing other devices which itself
@@ -80,6 +157,7 @@ func (c *v1client) SendHeartbeat(ctx context.Context, alive bool) error {
 		return nil
 	}
 	ctx = metadata.AppendToOutgoingContext(ctx, deviceLivenessMetadata, "true")
+	ctx = c.ctxWithProvidedMetadata(ctx, true)
 	_, err := c.Set(ctx, &gnmi.SetRequest{})
 	return err
 }
@@ -89,12 +167,17 @@ func (c *v1client) SetManagedDevices(d []string) {
 }
 
 // NewV1Client returns a new v1client object.
-func NewV1Client(gc gnmi.GNMIClient, deviceID string, isMgmtSystem bool) cvclient.CVClient {
-	return &v1client{
+func NewV1Client(gc gnmi.GNMIClient, deviceID string, isMgmtSystem bool,
+	opts ...Option) cvclient.CVClient {
+	c := &v1client{
 		gnmiClient:   gc,
 		deviceID:     deviceID,
 		isMgmtSystem: isMgmtSystem,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *v1client) ForProvider(p provider.GNMIProvider) cvclient.CVClient {
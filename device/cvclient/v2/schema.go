@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// A Schema is a compiled YANG schema, against which a Validator
+// checks the updates of an origin.
+type Schema struct {
+	// root holds the top-level nodes of the schema's modules.
+	root *yang.Entry
+}
+
+// CompileSchema compiles the YANG modules of the .yang files found in
+// dirs, and in their subdirectories, into a Schema. The modules they
+// import or include are looked up in dirs too.
+func CompileSchema(dirs ...string) (*Schema, error) {
+	ms := yang.NewModules()
+	var files []string
+	for _, dir := range dirs {
+		// Search subdirectories for imports.
+		ms.AddPath(filepath.Join(dir, "..."))
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(path, ".yang") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find YANG files in %s: %w", dir, err)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YANG files found in %v", dirs)
+	}
+	for _, file := range files {
+		if err := ms.Read(file); err != nil {
+			return nil, fmt.Errorf("failed to parse YANG file: %w", err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		return nil, fmt.Errorf("failed to compile YANG modules: %w", errors.Join(errs...))
+	}
+
+	// Modules are keyed by name and by name@revision.
+	seen := map[*yang.Module]bool{}
+	var modules []*yang.Module
+	for _, m := range ms.Modules {
+		if !seen[m] {
+			seen[m] = true
+			modules = append(modules, m)
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Name < modules[j].Name })
+	root := &yang.Entry{
+		Name: "/",
+		Kind: yang.DirectoryEntry,
+		Dir:  map[string]*yang.Entry{},
+	}
+	for _, m := range modules {
+		for name, e := range yang.ToEntry(m).Dir {
+			root.Dir[name] = e
+		}
+	}
+	return &Schema{root: root}, nil
+}
+
+// child returns the data node named name under e, looking through
+// the choices and cases of e, which aren't part of data paths.
+func child(e *yang.Entry, name string) *yang.Entry {
+	if c, ok := e.Dir[name]; ok && !c.IsChoice() && !c.IsCase() {
+		return c
+	}
+	for _, c := range e.Dir {
+		if c.IsChoice() || c.IsCase() {
+			if found := child(c, name); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
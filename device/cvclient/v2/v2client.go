@@ -7,6 +7,9 @@ package v2
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/aristanetworks/cloudvision-go/log"
 	"github.com/aristanetworks/cloudvision-go/provider"
 	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/aristanetworks/cloudvision-go/tracing"
 	"github.com/aristanetworks/cloudvision-go/version"
 
 	agnmi "github.com/aristanetworks/goarista/gnmi"
@@ -45,6 +49,35 @@ type v2Client struct {
 
 	managedDevsLock sync.Mutex
 	managedDevices  []string
+
+	// The capabilities of a device.CapabilityReporter, and those last
+	// published, so that heartbeats can republish them when they
+	// change.
+	capabilitiesRefresher *cvclient.Refresher[[]device.Capability]
+	capabilitiesLock      sync.Mutex
+	capabilities          []device.Capability
+
+	// The metadata of a device.MetadataProvider, the metadata last
+	// published, and when it was last checked for changes.
+	metadataRefresher *cvclient.Refresher[*device.Metadata]
+	metadataLock      sync.Mutex
+	metadata          *device.Metadata
+	metadataChecked   time.Time
+
+	// validator, if set, checks SetRequests before they're sent.
+	validator *Validator
+}
+
+// An Option configures a v2 client.
+type Option func(*v2Client)
+
+// WithValidator makes the client, and the clients of its providers,
+// check their SetRequests with v. In ValidationStrict mode, Set returns
+// an error for invalid SetRequests without sending them.
+func WithValidator(v *Validator) Option {
+	return func(c *v2Client) {
+		c.validator = v
+	}
 }
 
 // setTargetAndOrigin sets target and origin fields in a GNMI path based on values in c.
@@ -77,7 +110,15 @@ func (c *v2Client) Set(ctx context.Context, in *gnmi.SetRequest,
 	opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
 	in.Prefix = c.setTargetAndOrigin(in.Prefix)
 	log.Log(c).Debugf("v2Client: SetRequest: %v", in)
-	return c.gnmiClient.Set(ctx, in, opts...)
+	if c.validator != nil {
+		if err := c.validator.check(c.deviceID, in); err != nil {
+			return nil, fmt.Errorf("invalid SetRequest: %w", err)
+		}
+	}
+	ctx, span := tracing.StartSet(ctx, c.deviceID, in)
+	resp, err := c.gnmiClient.Set(ctx, in, opts...)
+	tracing.End(span, err)
+	return resp, err
 }
 
 func (c *v2Client) Subscribe(ctx context.Context,
@@ -91,6 +132,164 @@ func metadataPrefix() *gnmi.Path {
 	return prefix
 }
 
+// capabilitiesPath is the path, relative to metadataPrefix, under
+// which the models supported by the device are published.
+var capabilitiesPath = pgnmi.Path("capabilities")
+
+func capabilityUpdates(caps []device.Capability) []*gnmi.Update {
+	var u []*gnmi.Update
+	for _, cp := range caps {
+		model := pgnmi.ListWithKey("model", "name", cp.Name)
+		u = append(u, pgnmi.Update(pgnmi.Path("capabilities", model, "name"),
+			agnmi.TypedValue(cp.Name)))
+		if cp.Organization != "" {
+			u = append(u, pgnmi.Update(pgnmi.Path("capabilities", model, "organization"),
+				agnmi.TypedValue(cp.Organization)))
+		}
+		if cp.Version != "" {
+			u = append(u, pgnmi.Update(pgnmi.Path("capabilities", model, "version"),
+				agnmi.TypedValue(cp.Version)))
+		}
+		if len(cp.Paths) > 0 {
+			elems := make([]*gnmi.TypedValue, len(cp.Paths))
+			for i, p := range cp.Paths {
+				elems[i] = agnmi.TypedValue(p)
+			}
+			u = append(u, pgnmi.Update(pgnmi.Path("capabilities", model, "paths"),
+				&gnmi.TypedValue{
+					Value: &gnmi.TypedValue_LeaflistVal{
+						LeaflistVal: &gnmi.ScalarArray{Element: elems},
+					},
+				}))
+		}
+	}
+	return u
+}
+
+// addCapabilities adds the device's capabilities to a metadata
+// SetRequest, replacing any previously published. If onlyChanged is
+// true, the capabilities last fetched are added, and only if they
+// differ from the last ones added, and they're fetched again in the
+// background.
+func (c *v2Client) addCapabilities(ctx context.Context, req *gnmi.SetRequest,
+	onlyChanged bool) {
+	if c.capabilitiesRefresher == nil {
+		return
+	}
+	var caps []device.Capability
+	if onlyChanged {
+		var ok bool
+		caps, ok = c.capabilitiesRefresher.Latest()
+		c.capabilitiesRefresher.Refresh(ctx)
+		if !ok {
+			return
+		}
+	} else {
+		var err error
+		if caps, err = c.capabilitiesRefresher.Fetch(ctx); err != nil {
+			return
+		}
+	}
+
+	c.capabilitiesLock.Lock()
+	defer c.capabilitiesLock.Unlock()
+	if onlyChanged && reflect.DeepEqual(caps, c.capabilities) {
+		return
+	}
+	c.capabilities = caps
+	req.Delete = append(req.Delete, capabilitiesPath)
+	req.Update = append(req.Update, capabilityUpdates(caps)...)
+}
+
+// metadataCheckInterval bounds how often heartbeats check the metadata
+// of a device.MetadataProvider for changes.
+const metadataCheckInterval = time.Minute
+
+// The paths, relative to metadataPrefix, under which the metadata of a
+// device.MetadataProvider is published.
+var (
+	hostnamePath        = pgnmi.Path("hostname")
+	vendorPath          = pgnmi.Path("vendor")
+	modelPath           = pgnmi.Path("model")
+	serialNumberPath    = pgnmi.Path("serial-number")
+	softwareVersionPath = pgnmi.Path("software-version")
+	labelsPath          = pgnmi.Path("labels")
+)
+
+// deviceMetadataChanges returns the deletes and updates publishing md.
+// Fields left empty are deleted, as are labels no longer present.
+func deviceMetadataChanges(md *device.Metadata) ([]*gnmi.Path, []*gnmi.Update) {
+	deletes := []*gnmi.Path{labelsPath}
+	var updates []*gnmi.Update
+	for _, f := range []struct {
+		path *gnmi.Path
+		val  string
+	}{
+		{hostnamePath, md.Hostname},
+		{vendorPath, md.Vendor},
+		{modelPath, md.Model},
+		{serialNumberPath, md.SerialNumber},
+		{softwareVersionPath, md.SoftwareVersion},
+	} {
+		if f.val == "" {
+			deletes = append(deletes, f.path)
+		} else {
+			updates = append(updates, pgnmi.Update(f.path, agnmi.TypedValue(f.val)))
+		}
+	}
+	keys := make([]string, 0, len(md.Labels))
+	for k := range md.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		updates = append(updates, pgnmi.Update(
+			pgnmi.Path("labels", pgnmi.ListWithKey("label", "name", k), "value"),
+			agnmi.TypedValue(md.Labels[k])))
+	}
+	return deletes, updates
+}
+
+// addDeviceMetadata adds the metadata of a device.MetadataProvider to
+// a metadata SetRequest. If onlyChanged is true, the metadata last
+// fetched is added, and only if it differs from the last metadata
+// added, and it's fetched again in the background once
+// metadataCheckInterval has passed since it was last checked.
+func (c *v2Client) addDeviceMetadata(ctx context.Context, req *gnmi.SetRequest,
+	onlyChanged bool) {
+	if c.metadataRefresher == nil {
+		return
+	}
+	var md *device.Metadata
+	if onlyChanged {
+		md, _ = c.metadataRefresher.Latest()
+		c.metadataLock.Lock()
+		if time.Since(c.metadataChecked) >= metadataCheckInterval {
+			c.metadataChecked = time.Now()
+			c.metadataRefresher.Refresh(ctx)
+		}
+		c.metadataLock.Unlock()
+	} else {
+		c.metadataLock.Lock()
+		c.metadataChecked = time.Now()
+		c.metadataLock.Unlock()
+		var err error
+		if md, err = c.metadataRefresher.Fetch(ctx); err != nil {
+			return
+		}
+	}
+
+	c.metadataLock.Lock()
+	defer c.metadataLock.Unlock()
+	if md == nil || (onlyChanged && reflect.DeepEqual(md, c.metadata)) {
+		return
+	}
+	c.metadata = md
+	deletes, updates := deviceMetadataChanges(md)
+	req.Delete = append(req.Delete, deletes...)
+	req.Update = append(req.Update, updates...)
+}
+
 func (c *v2Client) metadataRequest(ctx context.Context) *gnmi.SetRequest {
 	u := []*gnmi.Update{
 		pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue(c.deviceType)),
@@ -118,10 +317,13 @@ func (c *v2Client) metadataRequest(ctx context.Context) *gnmi.SetRequest {
 			pgnmi.Update(pgnmi.Path("ip-addr"), agnmi.TypedValue(ip)),
 		)
 	}
-	return &gnmi.SetRequest{
+	req := &gnmi.SetRequest{
 		Prefix: metadataPrefix(),
 		Update: u,
 	}
+	c.addCapabilities(ctx, req, false)
+	c.addDeviceMetadata(ctx, req, false)
+	return req
 }
 
 func (c *v2Client) SendDeviceMetadata(ctx context.Context) error {
@@ -130,7 +332,7 @@ func (c *v2Client) SendDeviceMetadata(ctx context.Context) error {
 	return err
 }
 
-func (c *v2Client) heartbeatRequest() *gnmi.SetRequest {
+func (c *v2Client) heartbeatRequest(ctx context.Context) *gnmi.SetRequest {
 	now := time.Now()
 	nanos := now.UnixNano()
 	u := []*gnmi.Update{pgnmi.Update(pgnmi.Path("last-seen"), agnmi.TypedValue(nanos))}
@@ -140,6 +342,11 @@ func (c *v2Client) heartbeatRequest() *gnmi.SetRequest {
 		Update: u,
 	}
 
+	// Capabilities may change as the device's providers start
+	// streaming, so republish them if they have.
+	c.addCapabilities(ctx, out, true)
+	c.addDeviceMetadata(ctx, out, true)
+
 	if c.deviceType == DeviceManager {
 		c.managedDevsLock.Lock()
 		ids := c.managedDevices
@@ -171,7 +378,7 @@ func (c *v2Client) SendHeartbeat(ctx context.Context, alive bool) error {
 	if !alive {
 		return nil
 	}
-	req := c.heartbeatRequest()
+	req := c.heartbeatRequest(ctx)
 	_, err := c.Set(ctx, req)
 	return err
 }
@@ -184,7 +391,7 @@ func (c *v2Client) SetManagedDevices(d []string) {
 
 // NewV2Client returns a new client object for communication
 // with CV using the v2 protocol.
-func NewV2Client(gc gnmi.GNMIClient, info *device.Info) cvclient.CVClient {
+func NewV2Client(gc gnmi.GNMIClient, info *device.Info, opts ...Option) cvclient.CVClient {
 	deviceType := NetworkElement
 	dev := info.Device
 	if _, ok := dev.(device.Manager); ok {
@@ -194,7 +401,7 @@ func NewV2Client(gc gnmi.GNMIClient, info *device.Info) cvclient.CVClient {
 			deviceType = dev.Type()
 		}
 	}
-	return &v2Client{
+	c := &v2Client{
 		gnmiClient: gc,
 		deviceID:   info.ID,
 		deviceType: deviceType,
@@ -202,6 +409,31 @@ func NewV2Client(gc gnmi.GNMIClient, info *device.Info) cvclient.CVClient {
 		device:     dev,
 		info:       info,
 	}
+	if reporter, ok := dev.(device.CapabilityReporter); ok {
+		c.capabilitiesRefresher = cvclient.NewRefresher(
+			func(ctx context.Context) ([]device.Capability, error) {
+				caps, err := reporter.Capabilities(ctx)
+				if err != nil {
+					log.Log(c).Debugf("v2Client: error in Capabilities [%s]: %s",
+						c.deviceID, err)
+				}
+				return caps, err
+			})
+	}
+	if mp, ok := dev.(device.MetadataProvider); ok {
+		c.metadataRefresher = cvclient.NewRefresher(
+			func(ctx context.Context) (*device.Metadata, error) {
+				md, err := mp.Metadata(ctx)
+				if err != nil {
+					log.Log(c).Debugf("v2Client: error in Metadata [%s]: %s", c.deviceID, err)
+				}
+				return md, err
+			})
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *v2Client) ForProvider(p provider.GNMIProvider) cvclient.CVClient {
@@ -211,5 +443,6 @@ func (c *v2Client) ForProvider(p provider.GNMIProvider) cvclient.CVClient {
 		deviceType: c.deviceType,
 		origin:     p.Origin(),
 		info:       c.info,
+		validator:  c.validator,
 	}
 }
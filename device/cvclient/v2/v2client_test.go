@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aristanetworks/cloudvision-go/device"
 	"github.com/aristanetworks/cloudvision-go/provider"
@@ -148,12 +149,12 @@ func TestHeartbeatRequest(t *testing.T) {
 			if tc.managedDevices != nil {
 				c.SetManagedDevices(tc.managedDevices)
 			}
-			r := c.heartbeatRequest()
+			r := c.heartbeatRequest(context.Background())
 			if err := verifyUpdates(r, tc.expectLeaves, true); err != nil {
 				t.Fatalf("Error verifying leaves in set request: %v", err)
 			}
 
-			r = c.heartbeatRequest()
+			r = c.heartbeatRequest(context.Background())
 			// second heartbeat will not send managed devices again
 			delete(tc.expectLeaves, "/device-metadata/state/metadata/managed-devices")
 			if err := verifyUpdates(r, tc.expectLeaves, true); err != nil {
@@ -162,3 +163,247 @@ func TestHeartbeatRequest(t *testing.T) {
 		})
 	}
 }
+
+type capabilityDevice struct {
+	testDevice
+	caps []device.Capability
+}
+
+func (cd *capabilityDevice) Capabilities(ctx context.Context) ([]device.Capability, error) {
+	return cd.caps, nil
+}
+
+func TestCapabilities(t *testing.T) {
+	dev := &capabilityDevice{
+		caps: []device.Capability{{
+			Name:         "openconfig-interfaces",
+			Organization: "OpenConfig working group",
+			Version:      "3.0.0",
+		}},
+	}
+	c := NewV2Client(nil, &device.Info{
+		Device: dev,
+		Config: &device.Config{Device: "test"},
+	}).(*v2Client)
+
+	const prefix = "/device-metadata/state/metadata/"
+	ifModel := prefix + "capabilities/model[name=openconfig-interfaces]/"
+	r := c.metadataRequest(context.Background())
+	if len(r.Delete) != 1 || agnmi.StrPath(r.Delete[0]) != "/capabilities" {
+		t.Fatalf("expected delete of capabilities, got %v", r.Delete)
+	}
+	expected := map[string]interface{}{
+		prefix + "type":              agnmi.TypedValue(NetworkElement),
+		prefix + "source-type":       agnmi.TypedValue("test"),
+		prefix + "collector-version": agnmi.TypedValue(versionString),
+		prefix + "ip-addr":           agnmi.TypedValue("192.168.5.10"),
+		ifModel + "name":             agnmi.TypedValue("openconfig-interfaces"),
+		ifModel + "organization":     agnmi.TypedValue("OpenConfig working group"),
+		ifModel + "version":          agnmi.TypedValue("3.0.0"),
+	}
+	if err := verifyUpdates(r, expected, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unchanged capabilities aren't republished with heartbeats.
+	lastSeen := map[string]interface{}{
+		prefix + "last-seen": agnmi.TypedValue(int(42)),
+	}
+	r = c.heartbeatRequest(context.Background())
+	if err := verifyUpdates(r, lastSeen, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Delete) != 0 {
+		t.Fatalf("unexpected delete in heartbeat: %v", r.Delete)
+	}
+	c.capabilitiesRefresher.Wait()
+
+	// Changed capabilities are, by the heartbeat after the one that
+	// fetched them.
+	dev.caps = []device.Capability{{
+		Name:  "openconfig-system",
+		Paths: []string{"/system/state/hostname"},
+	}}
+	sysModel := prefix + "capabilities/model[name=openconfig-system]/"
+	r = c.heartbeatRequest(context.Background())
+	if err := verifyUpdates(r, lastSeen, true); err != nil {
+		t.Fatal(err)
+	}
+	c.capabilitiesRefresher.Wait()
+	r = c.heartbeatRequest(context.Background())
+	expected = map[string]interface{}{
+		prefix + "last-seen": agnmi.TypedValue(int(42)),
+		sysModel + "name":    agnmi.TypedValue("openconfig-system"),
+		sysModel + "paths": &gnmi.TypedValue{
+			Value: &gnmi.TypedValue_LeaflistVal{
+				LeaflistVal: &gnmi.ScalarArray{
+					Element: []*gnmi.TypedValue{agnmi.TypedValue("/system/state/hostname")},
+				},
+			},
+		},
+	}
+	if err := verifyUpdates(r, expected, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Delete) != 1 {
+		t.Fatalf("expected delete of capabilities, got %v", r.Delete)
+	}
+}
+
+type metadataDevice struct {
+	testDevice
+	md *device.Metadata
+}
+
+func (md *metadataDevice) Metadata(ctx context.Context) (*device.Metadata, error) {
+	return md.md, nil
+}
+
+func TestDeviceMetadata(t *testing.T) {
+	dev := &metadataDevice{
+		md: &device.Metadata{
+			Hostname:        "switch1",
+			Vendor:          "Arista Networks",
+			Model:           "DCS-7050SX3-48YC8",
+			SoftwareVersion: "4.30.1F",
+			Labels:          map[string]string{"rack": "r1"},
+		},
+	}
+	c := NewV2Client(nil, &device.Info{
+		Device: dev,
+		Config: &device.Config{Device: "test"},
+	}).(*v2Client)
+
+	const prefix = "/device-metadata/state/metadata/"
+	deletes := func(r *gnmi.SetRequest) []string {
+		var paths []string
+		for _, p := range r.Delete {
+			paths = append(paths, agnmi.StrPath(p))
+		}
+		return paths
+	}
+	r := c.metadataRequest(context.Background())
+	// Unknown fields, and labels no longer present, are deleted.
+	if d := deletes(r); !reflect.DeepEqual(d, []string{"/labels", "/serial-number"}) {
+		t.Fatalf("unexpected deletes %v", d)
+	}
+	expected := map[string]interface{}{
+		prefix + "type":                          agnmi.TypedValue(NetworkElement),
+		prefix + "source-type":                   agnmi.TypedValue("test"),
+		prefix + "collector-version":             agnmi.TypedValue(versionString),
+		prefix + "ip-addr":                       agnmi.TypedValue("192.168.5.10"),
+		prefix + "hostname":                      agnmi.TypedValue("switch1"),
+		prefix + "vendor":                        agnmi.TypedValue("Arista Networks"),
+		prefix + "model":                         agnmi.TypedValue("DCS-7050SX3-48YC8"),
+		prefix + "software-version":              agnmi.TypedValue("4.30.1F"),
+		prefix + "labels/label[name=rack]/value": agnmi.TypedValue("r1"),
+	}
+	if err := verifyUpdates(r, expected, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Metadata isn't checked again with each heartbeat.
+	dev.md = &device.Metadata{Hostname: "switch2"}
+	lastSeen := map[string]interface{}{
+		prefix + "last-seen": agnmi.TypedValue(int(42)),
+	}
+	r = c.heartbeatRequest(context.Background())
+	if err := verifyUpdates(r, lastSeen, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once it's due, changed metadata is fetched, and republished by
+	// the next heartbeat.
+	c.metadataChecked = time.Time{}
+	r = c.heartbeatRequest(context.Background())
+	if err := verifyUpdates(r, lastSeen, true); err != nil {
+		t.Fatal(err)
+	}
+	c.metadataRefresher.Wait()
+	r = c.heartbeatRequest(context.Background())
+	expected = map[string]interface{}{
+		prefix + "last-seen": agnmi.TypedValue(int(42)),
+		prefix + "hostname":  agnmi.TypedValue("switch2"),
+	}
+	if err := verifyUpdates(r, expected, true); err != nil {
+		t.Fatal(err)
+	}
+	if d := deletes(r); len(d) != 5 {
+		t.Fatalf("expected deletes of labels and unknown fields, got %v", d)
+	}
+
+	// Unchanged metadata isn't.
+	c.metadataChecked = time.Time{}
+	r = c.heartbeatRequest(context.Background())
+	c.metadataRefresher.Wait()
+	if err := verifyUpdates(r, lastSeen, true); err != nil {
+		t.Fatal(err)
+	}
+	r = c.heartbeatRequest(context.Background())
+	if err := verifyUpdates(r, lastSeen, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Delete) != 0 {
+		t.Fatalf("unexpected delete in heartbeat: %v", r.Delete)
+	}
+}
+
+// hungDevice reports its capabilities and metadata only once released.
+type hungDevice struct {
+	testDevice
+	release chan struct{}
+}
+
+func (d *hungDevice) Capabilities(ctx context.Context) ([]device.Capability, error) {
+	select {
+	case <-d.release:
+		return []device.Capability{{Name: "openconfig-system"}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (d *hungDevice) Metadata(ctx context.Context) (*device.Metadata, error) {
+	select {
+	case <-d.release:
+		return &device.Metadata{Hostname: "switch1"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestHeartbeatHungDevice(t *testing.T) {
+	dev := &hungDevice{release: make(chan struct{})}
+	c := NewV2Client(nil, &device.Info{
+		Device: dev,
+		Config: &device.Config{Device: "test"},
+	}).(*v2Client)
+
+	// Heartbeats don't wait for the device.
+	done := make(chan *gnmi.SetRequest)
+	go func() { done <- c.heartbeatRequest(context.Background()) }()
+	select {
+	case r := <-done:
+		if len(r.Update) != 1 || len(r.Delete) != 0 {
+			t.Fatalf("expected only last-seen in heartbeat, got %v", r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("heartbeat waited for the device")
+	}
+
+	// They publish what the device reports once it does.
+	close(dev.release)
+	c.capabilitiesRefresher.Wait()
+	c.metadataRefresher.Wait()
+	const prefix = "/device-metadata/state/metadata/"
+	r := c.heartbeatRequest(context.Background())
+	expected := map[string]interface{}{
+		prefix + "last-seen": agnmi.TypedValue(int(42)),
+		prefix + "capabilities/model[name=openconfig-system]/name": agnmi.TypedValue(
+			"openconfig-system"),
+		prefix + "hostname": agnmi.TypedValue("switch1"),
+	}
+	if err := verifyUpdates(r, expected, true); err != nil {
+		t.Fatal(err)
+	}
+}
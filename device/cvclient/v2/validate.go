@@ -0,0 +1,307 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/sirupsen/logrus"
+)
+
+// ValidationMode says what a Validator does with SetRequests whose
+// updates don't match the schema of their origin.
+type ValidationMode int
+
+const (
+	// ValidationLog logs invalid updates, once per path, and lets
+	// them through.
+	ValidationLog ValidationMode = iota
+	// ValidationStrict rejects SetRequests with invalid updates
+	// before they're sent.
+	ValidationStrict
+)
+
+// ParseValidationMode parses a ValidationMode, either "log" or
+// "strict".
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch s {
+	case "log":
+		return ValidationLog, nil
+	case "strict":
+		return ValidationStrict, nil
+	}
+	return 0, fmt.Errorf("unknown validation mode %q", s)
+}
+
+// maxLogged bounds the number of invalid paths a Validator remembers
+// having logged.
+const maxLogged = 10000
+
+// A Validator checks the paths, list keys and value types of the
+// SetRequests made through v2 clients against the YANG schemas of
+// their origins. Updates of origins without a schema aren't checked.
+type Validator struct {
+	mode    ValidationMode
+	schemas map[string]*Schema
+
+	lock   sync.Mutex
+	logged map[string]bool
+}
+
+// NewValidator returns a Validator checking the updates of each origin
+// of schemas against its schema.
+func NewValidator(mode ValidationMode, schemas map[string]*Schema) *Validator {
+	return &Validator{
+		mode:    mode,
+		schemas: schemas,
+		logged:  map[string]bool{},
+	}
+}
+
+// check checks req, as sent for deviceID. In ValidationStrict mode it
+// returns the problems found; in ValidationLog mode, it logs them.
+func (v *Validator) check(deviceID string, req *gnmi.SetRequest) error {
+	err := v.Validate(req)
+	if err == nil || v.mode == ValidationStrict {
+		return err
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	var problems []string
+	for _, e := range unwrapJoined(err) {
+		if !v.logged[e.Error()] && len(v.logged) < maxLogged {
+			v.logged[e.Error()] = true
+			problems = append(problems, e.Error())
+		}
+	}
+	if len(problems) > 0 {
+		logrus.WithField("device", deviceID).Errorf("Invalid updates sent to CloudVision: %s",
+			strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// Validate returns the problems with req, joined, or nil if it
+// matches the schemas of its origins.
+func (v *Validator) Validate(req *gnmi.SetRequest) error {
+	var errs []error
+	for _, p := range req.Delete {
+		if err := v.validate(req.Prefix, p, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, updates := range [][]*gnmi.Update{req.Replace, req.Update} {
+		for _, upd := range updates {
+			if err := v.validate(req.Prefix, upd.Path, upd.Val); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate checks path, relative to prefix, and val, its value if it's
+// updated rather than deleted.
+func (v *Validator) validate(prefix, path *gnmi.Path, val *gnmi.TypedValue) error {
+	origin := path.GetOrigin()
+	if origin == "" {
+		origin = prefix.GetOrigin()
+	}
+	schema, ok := v.schemas[origin]
+	if !ok {
+		return nil
+	}
+	elems := append(append([]*gnmi.PathElem{}, prefix.GetElem()...), path.GetElem()...)
+	full := &gnmi.Path{Origin: origin, Elem: elems}
+	e := schema.root
+	for i, elem := range elems {
+		name := elem.Name
+		// Names may be qualified with their module.
+		if j := strings.IndexByte(name, ':'); j >= 0 {
+			name = name[j+1:]
+		}
+		next := child(e, name)
+		if next == nil {
+			return fmt.Errorf("%s: unknown element %q", agnmi.StrPath(full), elem.Name)
+		}
+		e = next
+		if err := checkKeys(e, elem, i == len(elems)-1); err != nil {
+			return fmt.Errorf("%s: %w", agnmi.StrPath(full), err)
+		}
+	}
+	if val == nil {
+		return nil
+	}
+	if err := checkValue(e, val); err != nil {
+		return fmt.Errorf("%s: %w", agnmi.StrPath(full), err)
+	}
+	return nil
+}
+
+// checkKeys checks the keys of elem, a path element of schema node e.
+// Only the last element of a path may leave out the keys of a list,
+// to address all of its entries.
+func checkKeys(e *yang.Entry, elem *gnmi.PathElem, last bool) error {
+	if !e.IsList() {
+		if len(elem.Key) > 0 {
+			return fmt.Errorf("keys given for %q, which isn't a list", elem.Name)
+		}
+		return nil
+	}
+	if len(elem.Key) == 0 && last {
+		return nil
+	}
+	expected := strings.Fields(e.Key)
+	var got []string
+	for k := range elem.Key {
+		got = append(got, k)
+	}
+	sort.Strings(expected)
+	sort.Strings(got)
+	if strings.Join(got, " ") != strings.Join(expected, " ") {
+		return fmt.Errorf("list %q has keys %v, got %v", elem.Name, expected, got)
+	}
+	return nil
+}
+
+// checkValue checks val, the value of schema node e.
+func checkValue(e *yang.Entry, val *gnmi.TypedValue) error {
+	switch val.GetValue().(type) {
+	case *gnmi.TypedValue_JsonVal, *gnmi.TypedValue_JsonIetfVal, *gnmi.TypedValue_AnyVal:
+		// Encoded subtrees aren't checked.
+		return nil
+	}
+	if !e.IsLeaf() && !e.IsLeafList() {
+		return fmt.Errorf("scalar value %v given for %q, which isn't a leaf",
+			agnmi.StrVal(val), e.Name)
+	}
+	if ll, ok := val.GetValue().(*gnmi.TypedValue_LeaflistVal); ok {
+		if !e.IsLeafList() {
+			return fmt.Errorf("leaf-list value given for leaf %q", e.Name)
+		}
+		for _, elem := range ll.LeaflistVal.GetElement() {
+			if err := checkScalar(e.Type, elem); err != nil {
+				return fmt.Errorf("leaf-list %q: %w", e.Name, err)
+			}
+		}
+		return nil
+	}
+	if err := checkScalar(e.Type, val); err != nil {
+		return fmt.Errorf("leaf %q: %w", e.Name, err)
+	}
+	return nil
+}
+
+// checkScalar checks that val is of type t.
+func checkScalar(t *yang.YangType, val *gnmi.TypedValue) error {
+	mismatch := func() error {
+		return fmt.Errorf("value %v of type %T is not a valid %s", agnmi.StrVal(val),
+			val.GetValue(), typeName(t))
+	}
+	switch t.Kind {
+	case yang.Ystring, yang.Ybits:
+		if _, ok := val.GetValue().(*gnmi.TypedValue_StringVal); !ok {
+			return mismatch()
+		}
+	case yang.Ybinary:
+		if _, ok := val.GetValue().(*gnmi.TypedValue_BytesVal); !ok {
+			return mismatch()
+		}
+	case yang.Ybool, yang.Yempty:
+		if _, ok := val.GetValue().(*gnmi.TypedValue_BoolVal); !ok {
+			return mismatch()
+		}
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64,
+		yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		// Providers may send either signed or unsigned integers, as
+		// long as they're within range.
+		var n yang.Number
+		switch v := val.GetValue().(type) {
+		case *gnmi.TypedValue_IntVal:
+			n = yang.FromInt(v.IntVal)
+		case *gnmi.TypedValue_UintVal:
+			n = yang.FromUint(v.UintVal)
+		default:
+			return mismatch()
+		}
+		if len(t.Range) > 0 && !t.Range.Contains(yang.YangRange{{Min: n, Max: n}}) {
+			return fmt.Errorf("value %v is out of the range %v of %s", agnmi.StrVal(val),
+				t.Range, typeName(t))
+		}
+	case yang.Ydecimal64:
+		switch val.GetValue().(type) {
+		case *gnmi.TypedValue_DecimalVal, *gnmi.TypedValue_FloatVal,
+			*gnmi.TypedValue_DoubleVal:
+		default:
+			return mismatch()
+		}
+	case yang.Yenum:
+		s, ok := val.GetValue().(*gnmi.TypedValue_StringVal)
+		if !ok {
+			return mismatch()
+		}
+		if t.Enum != nil && !t.Enum.IsDefined(s.StringVal) {
+			return fmt.Errorf("%q is not one of %v", s.StringVal, t.Enum.Names())
+		}
+	case yang.Yidentityref:
+		s, ok := val.GetValue().(*gnmi.TypedValue_StringVal)
+		if !ok {
+			return mismatch()
+		}
+		if !isIdentity(t.IdentityBase, s.StringVal) {
+			return fmt.Errorf("%q is not derived from identity %s", s.StringVal,
+				t.IdentityBase.Name)
+		}
+	case yang.Yunion:
+		for _, member := range t.Type {
+			if checkScalar(member, val) == nil {
+				return nil
+			}
+		}
+		return mismatch()
+	default:
+		// The types of leafrefs and instance identifiers depend on
+		// the data they refer to, which isn't known here.
+	}
+	return nil
+}
+
+// isIdentity returns true if name, possibly qualified with its
+// module, is an identity derived from base.
+func isIdentity(base *yang.Identity, name string) bool {
+	if base == nil || len(base.Values) == 0 {
+		return true
+	}
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, id := range base.Values {
+		if id.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(t *yang.YangType) string {
+	if t.Name != "" && t.Name != t.Kind.String() {
+		return fmt.Sprintf("%s (%s)", t.Name, t.Kind)
+	}
+	return t.Kind.String()
+}
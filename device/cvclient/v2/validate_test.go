@@ -0,0 +1,265 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package v2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func testValidator(t *testing.T, mode ValidationMode) *Validator {
+	schema, err := CompileSchema("testdata/yang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewValidator(mode, map[string]*Schema{"test": schema})
+}
+
+func intf(name string) string {
+	return pgnmi.ListWithKey("interface", "name", name)
+}
+
+func TestValidate(t *testing.T) {
+	v := testValidator(t, ValidationStrict)
+	leaflist := &gnmi.TypedValue{Value: &gnmi.TypedValue_LeaflistVal{
+		LeaflistVal: &gnmi.ScalarArray{Element: []*gnmi.TypedValue{
+			agnmi.TypedValue("10.0.0.1"), agnmi.TypedValue("10.0.0.2"),
+		}},
+	}}
+	for name, tc := range map[string]struct {
+		path *gnmi.Path
+		val  *gnmi.TypedValue
+		err  string
+	}{
+		"string": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "name"),
+			val:  agnmi.TypedValue("eth0"),
+		},
+		"uint in range": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "mtu"),
+			val:  agnmi.TypedValue(uint16(1500)),
+		},
+		"int in range": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "mtu"),
+			val:  agnmi.TypedValue(1500),
+		},
+		"out of range": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "mtu"),
+			val:  agnmi.TypedValue(uint16(10)),
+			err:  "out of the range",
+		},
+		"wrong type": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "mtu"),
+			val:  agnmi.TypedValue("1500"),
+			err:  "is not a valid uint16",
+		},
+		"module-qualified": {
+			path: pgnmi.Path("test-interfaces:interfaces", intf("eth0"), "config",
+				"enabled"),
+			val: agnmi.TypedValue(true),
+		},
+		"unknown element": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "speed"),
+			val:  agnmi.TypedValue(uint32(100)),
+			err:  `unknown element "speed"`,
+		},
+		"wrong key": {
+			path: pgnmi.Path("interfaces", pgnmi.ListWithKey("interface", "id", "eth0"),
+				"config", "name"),
+			val: agnmi.TypedValue("eth0"),
+			err: "has keys [name], got [id]",
+		},
+		"keys of non-list": {
+			path: pgnmi.Path(pgnmi.ListWithKey("interfaces", "name", "eth0")),
+			err:  "isn't a list",
+		},
+		"missing keys": {
+			path: pgnmi.Path("interfaces", "interface", "config", "name"),
+			val:  agnmi.TypedValue("eth0"),
+			err:  "has keys [name], got []",
+		},
+		"enum": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "oper-status"),
+			val:  agnmi.TypedValue("UP"),
+		},
+		"bad enum": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "oper-status"),
+			val:  agnmi.TypedValue("SIDEWAYS"),
+			err:  `"SIDEWAYS" is not one of`,
+		},
+		"identityref": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "type"),
+			val:  agnmi.TypedValue("tt:ETHERNET"),
+		},
+		"bad identityref": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "type"),
+			val:  agnmi.TypedValue("TUNNEL"),
+			err:  "is not derived from identity IF_TYPE",
+		},
+		"typedef": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "counters",
+				"in-octets"),
+			val: agnmi.TypedValue(uint64(42)),
+		},
+		"decimal64": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "counters", "rate"),
+			val:  agnmi.TypedValue(float64(1.5)),
+		},
+		"union": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "counters", "id"),
+			val:  agnmi.TypedValue("one"),
+		},
+		"union mismatch": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "counters", "id"),
+			val:  agnmi.TypedValue(true),
+			err:  "is not a valid union",
+		},
+		"choice": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "speed-mbps"),
+			val:  agnmi.TypedValue(uint32(1000)),
+		},
+		"leaf-list": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "state", "addresses"),
+			val:  leaflist,
+		},
+		"leaf-list of leaf": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config", "name"),
+			val:  leaflist,
+			err:  "leaf-list value given for leaf",
+		},
+		"scalar of container": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config"),
+			val:  agnmi.TypedValue("eth0"),
+			err:  "isn't a leaf",
+		},
+		"json of container": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "config"),
+			val: &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{
+				JsonIetfVal: []byte(`{"mtu": 1500}`),
+			}},
+		},
+		"nested list": {
+			path: pgnmi.Path("interfaces", intf("eth0"), "subinterfaces",
+				pgnmi.ListWithKey("subinterface", "index", "0"), "index"),
+			val: agnmi.TypedValue(uint32(0)),
+		},
+		"delete of list entry": {
+			path: pgnmi.Path("interfaces", intf("eth0")),
+		},
+		"delete of whole list": {
+			path: pgnmi.Path("interfaces", "interface"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := &gnmi.SetRequest{Prefix: &gnmi.Path{Origin: "test"}}
+			if tc.val != nil {
+				req.Update = []*gnmi.Update{pgnmi.Update(tc.path, tc.val)}
+			} else {
+				req.Delete = []*gnmi.Path{tc.path}
+			}
+			err := v.Validate(req)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateOrigins(t *testing.T) {
+	v := testValidator(t, ValidationStrict)
+	bogus := pgnmi.Update(pgnmi.Path("bogus"), agnmi.TypedValue(1))
+	// Origins without a schema aren't checked.
+	if err := v.Validate(&gnmi.SetRequest{
+		Prefix: &gnmi.Path{Origin: "arista"},
+		Update: []*gnmi.Update{bogus},
+	}); err != nil {
+		t.Errorf("unexpected error for origin without schema: %v", err)
+	}
+	// The origin of a path overrides that of the prefix.
+	err := v.Validate(&gnmi.SetRequest{
+		Prefix: &gnmi.Path{Origin: "arista"},
+		Update: []*gnmi.Update{{
+			Path: &gnmi.Path{Origin: "test", Elem: bogus.Path.Elem},
+			Val:  bogus.Val,
+		}},
+	})
+	if err == nil {
+		t.Error("expected error for path of origin with schema")
+	}
+	// Paths are relative to the prefix.
+	prefix := pgnmi.Path("interfaces", intf("eth0"), "config")
+	prefix.Origin = "test"
+	if err := v.Validate(&gnmi.SetRequest{
+		Prefix: prefix,
+		Update: []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path("enabled"), agnmi.TypedValue(true)),
+		},
+	}); err != nil {
+		t.Errorf("unexpected error for path relative to prefix: %v", err)
+	}
+}
+
+type testProvider struct{}
+
+func (testProvider) Run(ctx context.Context) error   { return nil }
+func (testProvider) InitGNMI(client gnmi.GNMIClient) {}
+func (testProvider) OpenConfig() bool                { return false }
+func (testProvider) Origin() string                  { return "test" }
+
+func TestClientValidation(t *testing.T) {
+	var sent []*gnmi.SetRequest
+	gc := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		sent = append(sent, req)
+		return &gnmi.SetResponse{}, nil
+	})
+	info := &device.Info{ID: "dev", Device: testDevice{},
+		Config: &device.Config{Device: "test"}}
+	invalid := func() *gnmi.SetRequest {
+		return &gnmi.SetRequest{Update: []*gnmi.Update{
+			pgnmi.Update(pgnmi.Path("interfaces", intf("eth0"), "config", "mtu"),
+				agnmi.TypedValue("big")),
+		}}
+	}
+
+	strict := NewV2Client(gc, info, WithValidator(testValidator(t, ValidationStrict)))
+	c := strict.ForProvider(testProvider{})
+	if _, err := c.Set(context.Background(), invalid()); err == nil {
+		t.Error("expected invalid SetRequest to be rejected in strict mode")
+	}
+	if len(sent) != 0 {
+		t.Errorf("invalid SetRequest sent in strict mode: %v", sent)
+	}
+	// Metadata has its own origin, without a schema.
+	if err := strict.SendDeviceMetadata(context.Background()); err != nil {
+		t.Errorf("unexpected error sending metadata: %v", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("expected metadata to be sent, got %v", sent)
+	}
+
+	sent = nil
+	logging := NewV2Client(gc, info, WithValidator(testValidator(t, ValidationLog)))
+	c = logging.ForProvider(testProvider{})
+	if _, err := c.Set(context.Background(), invalid()); err != nil {
+		t.Errorf("unexpected error in log mode: %v", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("expected invalid SetRequest to be sent in log mode, got %v", sent)
+	}
+}
@@ -8,8 +8,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/log"
 	"github.com/aristanetworks/cloudvision-go/provider"
 	"google.golang.org/grpc"
 )
@@ -73,6 +77,84 @@ type Manager interface {
 	Manage(ctx context.Context, inventory Inventory) error
 }
 
+// A Capability describes a data model supported by a device.
+type Capability struct {
+	// Name is the name of the model, such as "openconfig-interfaces".
+	Name         string
+	Organization string
+	Version      string
+	// Paths optionally lists the paths within the model that the
+	// device supports.
+	Paths []string
+}
+
+// A CapabilityReporter is a Device that can report which data models
+// it supports. The result may change over the lifetime of the
+// device, for example as it learns what its providers can stream.
+type CapabilityReporter interface {
+	Capabilities(ctx context.Context) ([]Capability, error)
+}
+
+// Metadata describes a device beyond its ID and type.
+type Metadata = cvclient.DeviceMetadata
+
+// A MetadataProvider is a Device that can report its hostname, vendor,
+// model, serial number, software version and labels. They may change
+// over the lifetime of the device, such as after a software upgrade.
+type MetadataProvider = cvclient.MetadataProvider
+
+// A Closer is a Device holding resources, such as connections or
+// files, that must be released once it's no longer used. Close is
+// called once per device instance, after its providers have exited
+// and been closed, with a context whose deadline bounds how long it
+// may take.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
+// closeTimeout bounds how long a device and its providers are given
+// to close.
+const closeTimeout = 10 * time.Second
+
+// CloseDevice closes a device's providers and then the device itself,
+// for those that implement Closer. It must be called at most once per
+// device instance, after its providers have exited. Inventories close
+// the devices they hold, but devices created by NewDeviceInfo that
+// aren't added to one must be closed by their creator. The device's
+// Providers method is expected to return the same providers it did
+// when they were started.
+func CloseDevice(ctx context.Context, d Device) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), closeTimeout)
+	defer cancel()
+
+	var errs []error
+	providers, err := d.Providers()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, p := range providers {
+		if c, ok := p.(provider.Closer); ok {
+			if err := c.Close(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("error closing provider %T: %w", p, err))
+			}
+		}
+	}
+	if c, ok := d.(Closer); ok {
+		if err := c.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error closing device: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sameDevice returns true if a and b are the same device instance.
+func sameDevice(a, b Device) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
 // Creator returns a new instance of a Device.
 type Creator = func(context.Context, map[string]string, provider.Monitor) (Device, error)
 
@@ -125,6 +207,24 @@ func newDevice(ctx context.Context, config *Config, monitor provider.Monitor) (D
 	return registrationInfo.creator(ctx, sanitizedConfig, monitor)
 }
 
+// ValidateOptions checks a device config's options against the
+// options its device type was registered with. Configs for device
+// types that aren't registered are not checked.
+func ValidateOptions(config *Config) error {
+	registrationInfo, ok := deviceMap[config.Device]
+	if !ok {
+		return nil
+	}
+	if _, err := SanitizedOptions(registrationInfo.options, config.Options); err != nil {
+		if config.Name != "" {
+			return NewBadConfigErrorf("Config '%s' for device '%s': %w",
+				config.Name, config.Device, err)
+		}
+		return NewBadConfigErrorf("Config for device '%s': %w", config.Device, err)
+	}
+	return nil
+}
+
 // NewDeviceInfo takes a device config, creates the device, and returns an device Info.
 func NewDeviceInfo(ctx context.Context, config *Config, monitor provider.Monitor) (*Info, error) {
 	d, err := newDevice(ctx, config, monitor)
@@ -132,12 +232,17 @@ func NewDeviceInfo(ctx context.Context, config *Config, monitor provider.Monitor
 		return nil, fmt.Errorf("Failed creating device '%v': %w", config.Device, err)
 	}
 	did, err := d.DeviceID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting device ID from Device %s: %w", config.Device, err)
+	if err == nil && did == "" {
+		err = NewBadConfigErrorf("deviceID cannot be empty. From Device %s", config.Device)
+	} else if err != nil {
+		err = fmt.Errorf("Error getting device ID from Device %s: %w", config.Device, err)
 	}
-	if did == "" {
-		return nil, NewBadConfigErrorf(
-			"deviceID cannot be empty. From Device %s", config.Device)
+	if err != nil {
+		// The device won't be used, so let it release what it holds.
+		if cerr := CloseDevice(ctx, d); cerr != nil {
+			log.Log(d).Errorf("Error closing device %s: %v", config.Device, cerr)
+		}
+		return nil, err
 	}
 	return &Info{Device: d, ID: did, Config: config}, nil
 }
@@ -167,7 +272,7 @@ func (i *Info) String() string {
 		return fmt.Sprintf(template, i.ID, "")
 	}
 	var options []string
-	for k, v := range i.Config.Options {
+	for k, v := range RedactedOptions(i.Config.Device, i.Config.Options) {
 		options = append(options, fmt.Sprintf("deviceoption: %s=%s", k, v))
 	}
 	optStr := strings.Join(options, ", ")
@@ -213,4 +318,8 @@ type SensorConfig struct {
 
 	//Receives the grpc connection and returns a ClusterClock
 	ClusterClockCreator func(conn *grpc.ClientConn) (ClusterClock, error)
+
+	// Receives the grpc connection and returns the ShardCoordinator
+	// of sharded sensor replicas.
+	ShardCoordinatorCreator func(conn *grpc.ClientConn) (ShardCoordinator, error)
 }
@@ -21,6 +21,8 @@ func init() {
 		"pollInterval": {
 			Description: "Polling interval, with unit suffix (s/m/h)",
 			Default:     "20s",
+			Type:        device.OptionTypeDuration,
+			Min:         "1s",
 		},
 	}
 	device.Register("darwin", NewDarwinDevice, options)
@@ -59,6 +61,50 @@ func (d *darwin) deviceSerial(ctx context.Context) (string, error) {
 	return strings.TrimSuffix(string(out), "\n"), nil
 }
 
+// parseSystemProfiler returns the metadata found in the "Key: Value"
+// lines of the output of system_profiler SPHardwareDataType
+// SPSoftwareDataType.
+func parseSystemProfiler(out string) *device.Metadata {
+	md := &device.Metadata{Vendor: "Apple"}
+	labels := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		key, val, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok {
+			continue
+		}
+		val = strings.TrimSpace(val)
+		switch key {
+		case "Model Identifier":
+			md.Model = val
+		case "Model Name":
+			labels["model-name"] = val
+		case "Serial Number (system)":
+			md.SerialNumber = val
+		case "System Version":
+			md.SoftwareVersion = val
+		case "Kernel Version":
+			labels["kernel-version"] = val
+		case "Computer Name":
+			md.Hostname = val
+		}
+	}
+	if len(labels) > 0 {
+		md.Labels = labels
+	}
+	return md
+}
+
+// Metadata returns the model, serial number and macOS version of the
+// device, as reported by system_profiler.
+func (d *darwin) Metadata(ctx context.Context) (*device.Metadata, error) {
+	out, err := exec.CommandContext(ctx, "system_profiler",
+		"SPHardwareDataType", "SPSoftwareDataType").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseSystemProfiler(string(out)), nil
+}
+
 func (d *darwin) DeviceID(ctx context.Context) (string, error) {
 	return d.deviceID, nil
 }
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package devices
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+)
+
+const systemProfilerOutput = `Hardware:
+
+    Hardware Overview:
+
+      Model Name: MacBook Pro
+      Model Identifier: MacBookPro18,3
+      Chip: Apple M1 Pro
+      Serial Number (system): C02ABC123XYZ
+      Hardware UUID: 00000000-0000-0000-0000-000000000000
+
+Software:
+
+    System Software Overview:
+
+      System Version: macOS 14.5 (23F79)
+      Kernel Version: Darwin 23.5.0
+      Boot Volume: Macintosh HD
+      Computer Name: build-mac
+      Time since boot: 3 days, 2 hours, 1 minute
+`
+
+func TestParseSystemProfiler(t *testing.T) {
+	expected := &device.Metadata{
+		Hostname:        "build-mac",
+		Vendor:          "Apple",
+		Model:           "MacBookPro18,3",
+		SerialNumber:    "C02ABC123XYZ",
+		SoftwareVersion: "macOS 14.5 (23F79)",
+		Labels: map[string]string{
+			"model-name":     "MacBook Pro",
+			"kernel-version": "Darwin 23.5.0",
+		},
+	}
+	if md := parseSystemProfiler(systemProfilerOutput); !reflect.DeepEqual(md, expected) {
+		t.Errorf("expected %+v, got %+v", expected, md)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package devices
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	plinux "github.com/aristanetworks/cloudvision-go/provider/linux"
+)
+
+// Register this device with its options.
+func init() {
+	options := map[string]device.Option{
+		"pollInterval": {
+			Description: "Polling interval, with unit suffix (s/m/h)",
+			Default:     "20s",
+			Type:        device.OptionTypeDuration,
+			Min:         "1s",
+		},
+		"root": {
+			Description: "Directory under which the proc and sys filesystems are found",
+			Default:     "/",
+		},
+	}
+	device.Register("linux", NewLinuxDevice, options)
+}
+
+type linux struct {
+	deviceID string
+	root     string
+	provider provider.GNMIProvider
+}
+
+func (l *linux) Alive(ctx context.Context) (bool, error) {
+	// Runs on the device itself, so if the method is called, it's alive.
+	return true, nil
+}
+
+// Use the DMI serial number as the device ID, falling back on the
+// machine ID for hosts (such as many VMs) without one.
+func (l *linux) deviceSerial() (string, error) {
+	serial, err := plinux.Serial(l.root)
+	if err == nil && serial != "" {
+		return serial, nil
+	}
+	machineID, merr := plinux.MachineID(l.root)
+	if merr == nil && machineID != "" {
+		return machineID, nil
+	}
+	return "", fmt.Errorf("no DMI serial (%v) or machine ID (%v)", err, merr)
+}
+
+func (l *linux) DeviceID(ctx context.Context) (string, error) {
+	return l.deviceID, nil
+}
+
+func (l *linux) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{l.provider}, nil
+}
+
+func (l *linux) Type() string {
+	return ""
+}
+
+func (l *linux) IPAddr(ctx context.Context) (string, error) {
+	// We recompute this every time since it can potentially change.
+	intfName, err := plinux.DefaultRouteInterface(l.root)
+	if err != nil || intfName == "" {
+		return "", nil
+	}
+	intf, err := net.InterfaceByName(intfName)
+	if err != nil {
+		return "", nil
+	}
+	addrs, err := intf.Addrs()
+	if err != nil {
+		return "", nil
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", nil
+}
+
+// NewLinuxDevice instantiates a Linux host device.
+func NewLinuxDevice(ctx context.Context, options map[string]string,
+	monitor provider.Monitor) (device.Device, error) {
+	pollInterval, err := device.GetDurationOption("pollInterval", options)
+	if err != nil {
+		return nil, err
+	}
+	root, err := device.GetStringOption("root", options)
+	if err != nil {
+		return nil, err
+	}
+
+	device := linux{root: root}
+	did, err := device.deviceSerial()
+	if err != nil {
+		return nil, fmt.Errorf("Failure getting device ID: %v", err)
+	}
+	device.deviceID = did
+	device.provider = plinux.NewLinuxProvider(root, pollInterval)
+
+	return &device, nil
+}
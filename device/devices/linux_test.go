@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package devices
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	pm "github.com/aristanetworks/cloudvision-go/provider/mock"
+)
+
+func TestLinuxDeviceID(t *testing.T) {
+	fixtureRoot := "../../provider/linux/testdata/root"
+
+	noSerialRoot := t.TempDir()
+	machineID := filepath.Join(noSerialRoot, "etc", "machine-id")
+	if err := os.MkdirAll(filepath.Dir(machineID), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(machineID, []byte("deadbeef\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		root     string
+		expected string
+		err      bool
+	}{
+		{name: "DMI serial", root: fixtureRoot, expected: "ABC1234XYZ"},
+		{name: "machine ID fallback", root: noSerialRoot, expected: "deadbeef"},
+		{name: "no ID", root: t.TempDir(), err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := device.NewDeviceInfo(context.Background(), &device.Config{
+				Device:  "linux",
+				Options: map[string]string{"root": tc.root},
+			}, pm.NewMockMonitor())
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got device ID %q", info.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info.ID != tc.expected {
+				t.Fatalf("expected device ID %q, got %q", tc.expected, info.ID)
+			}
+			providers, err := info.Device.Providers()
+			if err != nil || len(providers) != 1 {
+				t.Fatalf("expected one provider, got %v (err: %v)", providers, err)
+			}
+		})
+	}
+}
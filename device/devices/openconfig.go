@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync"
 
 	"github.com/aristanetworks/cloudvision-go/device"
 	"github.com/aristanetworks/cloudvision-go/log"
@@ -32,6 +33,7 @@ func openConfigOptions() map[string]device.Option {
 			Description: "gNMI subscription path (comma-separated if multiple)",
 			Default:     "/",
 			Required:    false,
+			Type:        device.OptionTypeList,
 		},
 		"username": {
 			Description: "gNMI subscription username",
@@ -40,6 +42,7 @@ func openConfigOptions() map[string]device.Option {
 		},
 		"password": {
 			Description: "gNMI subscription password",
+			Secret:      true,
 			Default:     "",
 			Required:    false,
 		},
@@ -70,6 +73,7 @@ func openConfigOptions() map[string]device.Option {
 		},
 		"key": {
 			Description: "Client TLS private key data",
+			Secret:      true,
 			Default:     "",
 			Required:    false,
 		},
@@ -77,16 +81,20 @@ func openConfigOptions() map[string]device.Option {
 			Description: "Compression method (Supported options: \"\" and \"gzip\")",
 			Default:     "",
 			Required:    false,
+			Type:        device.OptionTypeEnum,
+			Values:      []string{"", "gzip"},
 		},
 		"tls": {
 			Description: "Enable TLS",
 			Default:     "false",
 			Required:    false,
+			Type:        device.OptionTypeBool,
 		},
 		"bdp": {
 			Description: "Enable BDP",
 			Default:     "true",
 			Required:    false,
+			Type:        device.OptionTypeBool,
 		},
 		"device_id": {
 			Description: "device ID",
@@ -97,6 +105,8 @@ func openConfigOptions() map[string]device.Option {
 			Description: "Connection timeout (duration)",
 			Default:     "10s",
 			Required:    false,
+			Type:        device.OptionTypeDuration,
+			Min:         "1ms",
 		},
 	}
 }
@@ -108,9 +118,14 @@ func init() {
 type openconfigDevice struct {
 	gNMIProvider provider.GNMIProvider
 	gNMIClient   pb.GNMIClient
+	conn         *grpc.ClientConn
 	config       *gnmi.Config
 	deviceID     string
 	mgmtIP       string
+
+	// Models reported by the target's gNMI Capabilities, fetched once.
+	capabilitiesLock sync.Mutex
+	capabilities     []device.Capability
 }
 
 func (o *openconfigDevice) Alive(ctx context.Context) (bool, error) {
@@ -134,6 +149,22 @@ type ocStringGetter func(chan *pb.SubscribeResponse) (string, error)
 
 func (o *openconfigDevice) getStringFromSubscription(ctx context.Context,
 	path string, f ocStringGetter) (string, error) {
+	result := ""
+	err := o.subscribeOnce(ctx, path, func(respCh chan *pb.SubscribeResponse) error {
+		r, err := f(respCh)
+		result = r
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// subscribeOnce makes a once subscription to path, whose responses are
+// read by f.
+func (o *openconfigDevice) subscribeOnce(ctx context.Context,
+	path string, f func(chan *pb.SubscribeResponse) error) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	ctx = gnmi.NewContext(ctx, o.config)
@@ -142,7 +173,6 @@ func (o *openconfigDevice) getStringFromSubscription(ctx context.Context,
 		Paths: gnmi.SplitPaths([]string{path}),
 	}
 	respCh := make(chan *pb.SubscribeResponse, 1)
-	result := ""
 	errg, ctx := errgroup.WithContext(ctx)
 	errg.Go(func() error {
 		err := gnmi.SubscribeErr(ctx, o.gNMIClient, opt, respCh)
@@ -155,15 +185,11 @@ func (o *openconfigDevice) getStringFromSubscription(ctx context.Context,
 		return nil
 	})
 	errg.Go(func() error {
-		r, err := f(respCh)
+		err := f(respCh)
 		cancel()
-		result = r
 		return err
 	})
-	if err := errg.Wait(); err != nil {
-		return "", err
-	}
-	return result, nil
+	return errg.Wait()
 }
 
 // getSerial assumes a subscription to /components/component/state, which
@@ -275,6 +301,149 @@ func (o *openconfigDevice) DeviceID(ctx context.Context) (string, error) {
 	return o.config.Addr, nil
 }
 
+// forEachLeaf calls f with the full path and value of each update
+// received on respCh, until it's closed.
+func forEachLeaf(respCh chan *pb.SubscribeResponse, f func(*pb.Path, *pb.TypedValue)) {
+	for resp := range respCh {
+		notif := resp.GetUpdate()
+		if notif == nil {
+			continue
+		}
+		for _, upd := range notif.Update {
+			if len(upd.Path.GetElem()) == 0 {
+				continue
+			}
+			fullPath := upd.Path
+			if notif.Prefix != nil {
+				fullPath = gnmi.JoinPaths(notif.Prefix, upd.Path)
+			}
+			f(fullPath, upd.Val)
+		}
+	}
+}
+
+// getSystemMetadata assumes a subscription to /system/state, and fills
+// in the hostname and software version of md.
+func getSystemMetadata(respCh chan *pb.SubscribeResponse, md *device.Metadata) {
+	forEachLeaf(respCh, func(path *pb.Path, val *pb.TypedValue) {
+		switch path.Elem[len(path.Elem)-1].Name {
+		case "hostname":
+			md.Hostname = val.GetStringVal()
+		case "software-version":
+			md.SoftwareVersion = val.GetStringVal()
+		}
+	})
+}
+
+// getComponentsMetadata assumes a subscription to
+// /components/component/state, and fills in the vendor, model and serial
+// number of md from the CHASSIS component. The software version is
+// filled in from the CHASSIS or OPERATING_SYSTEM component if md has
+// none yet.
+func getComponentsMetadata(respCh chan *pb.SubscribeResponse, md *device.Metadata) {
+	components := map[string]map[string]string{}
+	forEachLeaf(respCh, func(path *pb.Path, val *pb.TypedValue) {
+		for _, elm := range path.Elem {
+			if elm.Name != "component" {
+				continue
+			}
+			name := elm.Key["name"]
+			if components[name] == nil {
+				components[name] = map[string]string{}
+			}
+			components[name][path.Elem[len(path.Elem)-1].Name] = val.GetStringVal()
+		}
+	})
+	// Types are identityrefs, which may or may not come with their
+	// module prefix.
+	typeOf := func(leaves map[string]string) string {
+		typ := leaves["type"]
+		return typ[strings.LastIndex(typ, ":")+1:]
+	}
+	var osVersion string
+	for _, leaves := range components {
+		switch typeOf(leaves) {
+		case "CHASSIS":
+			md.Vendor = leaves["mfg-name"]
+			md.Model = leaves["part-no"]
+			md.SerialNumber = leaves["serial-no"]
+			if md.SoftwareVersion == "" {
+				md.SoftwareVersion = leaves["software-version"]
+			}
+		case "OPERATING_SYSTEM":
+			if v := leaves["software-version"]; v != "" {
+				osVersion = v
+			}
+		}
+	}
+	if md.SoftwareVersion == "" {
+		md.SoftwareVersion = osVersion
+	}
+}
+
+// Metadata returns the hostname and software version of the target from
+// /system/state, and its vendor, model and serial number from
+// /components. It fails only if neither can be subscribed to.
+func (o *openconfigDevice) Metadata(ctx context.Context) (*device.Metadata, error) {
+	md := &device.Metadata{}
+	errSystem := o.subscribeOnce(ctx, "/system/state",
+		func(respCh chan *pb.SubscribeResponse) error {
+			getSystemMetadata(respCh, md)
+			return nil
+		})
+	errComps := o.subscribeOnce(ctx, "/components/component/state",
+		func(respCh chan *pb.SubscribeResponse) error {
+			getComponentsMetadata(respCh, md)
+			return nil
+		})
+	if errSystem != nil && errComps != nil {
+		return nil, fmt.Errorf("failed to get metadata from system state (%v) "+
+			"or from components (%w)", errSystem, errComps)
+	} else if errSystem != nil || errComps != nil {
+		log.Log(o).Debugf("Partial metadata: system state err: %v, components err: %v",
+			errSystem, errComps)
+	}
+	return md, nil
+}
+
+// modelCapabilities converts the models in a gNMI CapabilityResponse.
+func modelCapabilities(resp *pb.CapabilityResponse) []device.Capability {
+	caps := make([]device.Capability, 0, len(resp.SupportedModels))
+	for _, m := range resp.SupportedModels {
+		caps = append(caps, device.Capability{
+			Name:         m.Name,
+			Organization: m.Organization,
+			Version:      m.Version,
+		})
+	}
+	return caps
+}
+
+// Capabilities returns the models the target reports supporting in
+// its gNMI Capabilities response.
+func (o *openconfigDevice) Capabilities(ctx context.Context) ([]device.Capability, error) {
+	o.capabilitiesLock.Lock()
+	defer o.capabilitiesLock.Unlock()
+	if o.capabilities != nil {
+		return o.capabilities, nil
+	}
+	resp, err := o.gNMIClient.Capabilities(gnmi.NewContext(ctx, o.config),
+		&pb.CapabilityRequest{})
+	if err != nil {
+		return nil, err
+	}
+	o.capabilities = modelCapabilities(resp)
+	return o.capabilities, nil
+}
+
+// Close closes the connection to the target.
+func (o *openconfigDevice) Close(ctx context.Context) error {
+	if o.conn == nil {
+		return nil
+	}
+	return o.conn.Close()
+}
+
 func (o *openconfigDevice) Type() string {
 	return ""
 }
@@ -318,10 +487,11 @@ func parseGNMIOptions(opt map[string]string) (*gnmi.Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	config.Password, err = device.GetStringOption("password", opt)
+	password, err := device.GetSecretOption("password", opt)
 	if err != nil {
 		return nil, err
 	}
+	config.Password = password.Value()
 
 	config.CAFile, config.CAData, err = parseCertOpt(opt, "cafile", "ca")
 	if err != nil {
@@ -379,16 +549,24 @@ func newOpenConfig(ctx context.Context, opt map[string]string,
 
 	dialCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	client, err := gnmi.DialContext(dialCtx, config)
+	conn, err := gnmi.DialContextConn(dialCtx, config)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to dial: %s", err)
 	}
+	client := pb.NewGNMIClient(conn)
+	defer func() {
+		// Don't leak the connection if we fail to create the device.
+		if openconfig.conn == nil {
+			conn.Close()
+		}
+	}()
 
 	{ // Try to make a request to ensure we are up and running
 		ctx := gnmi.NewContext(ctx, config) // add credentials
 		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
-		if _, err := client.Capabilities(ctx, &pb.CapabilityRequest{}); err != nil {
+		resp, err := client.Capabilities(ctx, &pb.CapabilityRequest{})
+		if err != nil {
 			if s, ok := status.FromError(err); s != nil && ok {
 				switch s.Code() {
 				case codes.Unauthenticated:
@@ -408,10 +586,13 @@ func newOpenConfig(ctx context.Context, opt map[string]string,
 				}
 			}
 			log.Debugf("Capabilities request err: %v", err)
+		} else {
+			openconfig.capabilities = modelCapabilities(resp)
 		}
 	}
 
 	log.Infof("Connected to gNMI target device: %s", config.Addr)
+	openconfig.conn = conn
 	openconfig.gNMIClient = client
 	openconfig.config = config
 	openconfig.deviceID = deviceID
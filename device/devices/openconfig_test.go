@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -219,6 +220,47 @@ func TestOpenConfigDeviceID(t *testing.T) {
 	}
 }
 
+func TestOpenConfigMetadata(t *testing.T) {
+	respCh := func(ups ...*gnmi.Update) chan *gnmi.SubscribeResponse {
+		ch := make(chan *gnmi.SubscribeResponse, 1)
+		ch <- &gnmi.SubscribeResponse{Response: &gnmi.SubscribeResponse_Update{
+			Update: &gnmi.Notification{Update: ups}}}
+		close(ch)
+		return ch
+	}
+	leaf := func(path, val string) *gnmi.Update {
+		return pg.Update(pg.PathFromString(path), agnmi.TypedValue(val))
+	}
+
+	md := &device.Metadata{}
+	getSystemMetadata(respCh(
+		leaf("system/state/hostname", "switch1"),
+		leaf("system/state/domain-name", "example.com"),
+	), md)
+	getComponentsMetadata(respCh(
+		leaf("components/component[name=Chassis]/state/type",
+			"openconfig-platform-types:CHASSIS"),
+		leaf("components/component[name=Chassis]/state/mfg-name", "Arista Networks"),
+		leaf("components/component[name=Chassis]/state/part-no", "DCS-7280SR-48C6"),
+		leaf("components/component[name=Chassis]/state/serial-no", "JPE123"),
+		leaf("components/component[name=EOS]/state/type", "OPERATING_SYSTEM"),
+		leaf("components/component[name=EOS]/state/software-version", "4.30.1F"),
+		leaf("components/component[name=Fan1]/state/type",
+			"openconfig-platform-types:FAN"),
+		leaf("components/component[name=Fan1]/state/serial-no", "FAN123"),
+	), md)
+	expected := &device.Metadata{
+		Hostname:        "switch1",
+		Vendor:          "Arista Networks",
+		Model:           "DCS-7280SR-48C6",
+		SerialNumber:    "JPE123",
+		SoftwareVersion: "4.30.1F",
+	}
+	if !reflect.DeepEqual(md, expected) {
+		t.Errorf("expected %+v, got %+v", expected, md)
+	}
+}
+
 // implements gnmi.GNMIServer
 type mockGnmiServer struct {
 	serveCapability func(ctx context.Context,
@@ -271,7 +313,8 @@ func TestNewOpenConfig(t *testing.T) {
 		opts            map[string]string
 		serveCapability func(ctx context.Context,
 			r *gnmi.CapabilityRequest, s *mockGnmiServer) (*gnmi.CapabilityResponse, error)
-		expectErr string
+		expectErr  string
+		expectCaps []device.Capability
 	}{
 		{
 			name: "Bad address, can't connect",
@@ -338,8 +381,19 @@ func TestNewOpenConfig(t *testing.T) {
 			},
 			serveCapability: func(ctx context.Context,
 				r *gnmi.CapabilityRequest, s *mockGnmiServer) (*gnmi.CapabilityResponse, error) {
-				return &gnmi.CapabilityResponse{}, nil
+				return &gnmi.CapabilityResponse{
+					SupportedModels: []*gnmi.ModelData{{
+						Name:         "openconfig-interfaces",
+						Organization: "OpenConfig working group",
+						Version:      "3.0.0",
+					}},
+				}, nil
 			},
+			expectCaps: []device.Capability{{
+				Name:         "openconfig-interfaces",
+				Organization: "OpenConfig working group",
+				Version:      "3.0.0",
+			}},
 		},
 	} {
 		t.Run(tcase.name, func(t *testing.T) {
@@ -390,6 +444,15 @@ func TestNewOpenConfig(t *testing.T) {
 			if len(tcase.expectErr) == 0 && dev == nil {
 				t.Fatalf("Expected a valid device object, got nil")
 			}
+			if tcase.expectCaps != nil {
+				caps, err := dev.(device.CapabilityReporter).Capabilities(ctx)
+				if err != nil {
+					t.Fatalf("Capabilities failed: %v", err)
+				}
+				if !reflect.DeepEqual(caps, tcase.expectCaps) {
+					t.Fatalf("Expected capabilities %v, got %v", tcase.expectCaps, caps)
+				}
+			}
 			grpcServer.Stop()
 			cancel()
 
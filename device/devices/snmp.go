@@ -22,49 +22,61 @@ import (
 var options = map[string]device.Option{
 	"a": {
 		Description: "SNMPv3 authentication protocol",
-		Pattern:     `sha|SHA|md5|MD5`,
+		Type:        device.OptionTypeEnum,
+		Values:      []string{"sha", "SHA", "md5", "MD5"},
 	},
 	"A": {
 		Description: "SNMPv3 authentication key",
+		Secret:      true,
 	},
 	"address": {
 		Description: "Hostname or address of device",
 		Required:    true,
+		Type:        device.OptionTypeAddress,
 	},
 	"port": {
 		Description: "Device SNMP port to use",
 		Default:     "161",
+		Type:        device.OptionTypePort,
 	},
 	"c": {
 		Description: "SNMP community string",
+		Secret:      true,
 	},
 	"l": {
 		Description: "SNMPv3 security level (noAuthNoPriv|authNoPriv|authPriv)",
 		Default:     "authPriv",
-		Pattern:     `noAuthNoPriv|authNoPriv|authPriv`,
+		Type:        device.OptionTypeEnum,
+		Values:      []string{"noAuthNoPriv", "authNoPriv", "authPriv"},
 	},
 	"mibs": {
 		Description: "Comma-separated list of mib files/directories",
 		Required:    true,
+		Type:        device.OptionTypeList,
 	},
 	"pollInterval": {
 		Description: "Polling interval, with unit suffix (s/m/h)",
 		Default:     "20s",
+		Type:        device.OptionTypeDuration,
+		Min:         "1s",
 	},
 	"u": {
 		Description: "SNMPv3 security name",
 	},
 	"v": {
 		Description: "SNMP version (2c|3)",
-		Pattern:     `2c|3`,
 		Default:     "2c",
+		Type:        device.OptionTypeEnum,
+		Values:      []string{"2c", "3"},
 	},
 	"x": {
 		Description: "SNMPv3 privacy protocol",
-		Pattern:     `des|DES|aes|AES`,
+		Type:        device.OptionTypeEnum,
+		Values:      []string{"des", "DES", "aes", "AES"},
 	},
 	"X": {
 		Description: "SNMPv3 privacy key",
+		Secret:      true,
 	},
 }
 
@@ -74,14 +86,14 @@ func init() {
 
 type snmp struct {
 	address      string
-	authKey      string
+	authKey      device.Secret
 	authProto    string
-	community    string
+	community    device.Secret
 	level        string
 	mibs         []string
 	pollInterval time.Duration
 	port         uint16
-	privacyKey   string
+	privacyKey   device.Secret
 	privacyProto string
 	securityName string
 	systemID     string
@@ -110,6 +122,38 @@ func (s *snmp) DeviceID(ctx context.Context) (string, error) {
 	return s.systemID, nil
 }
 
+// Capabilities reports the OpenConfig models the SNMP provider has
+// been able to produce data for so far.
+func (s *snmp) Capabilities(ctx context.Context) ([]device.Capability, error) {
+	var caps []device.Capability
+	for _, m := range s.snmpProvider.(*psnmp.Snmp).MappedModels() {
+		caps = append(caps, device.Capability{
+			Name:         m.Name,
+			Organization: "OpenConfig working group",
+			Paths:        m.Paths,
+		})
+	}
+	return caps, nil
+}
+
+func (s *snmp) Metadata(ctx context.Context) (*device.Metadata, error) {
+	info, err := s.snmpProvider.(*psnmp.Snmp).SystemInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	md := &device.Metadata{
+		Hostname:        info.Hostname,
+		Vendor:          info.Vendor,
+		Model:           info.Model,
+		SerialNumber:    info.SerialNumber,
+		SoftwareVersion: info.SoftwareVersion,
+	}
+	if info.Description != "" {
+		md.Labels = map[string]string{"description": info.Description}
+	}
+	return md, nil
+}
+
 func (s *snmp) Providers() ([]provider.Provider, error) {
 	return []provider.Provider{s.snmpProvider}, nil
 }
@@ -135,7 +179,7 @@ func (s *snmp) validateOptions() error {
 	}
 
 	if s.version == "2c" {
-		if s.community == "" {
+		if s.community.IsEmpty() {
 			return errors.New("community string required for version 2c")
 		}
 		return nil
@@ -150,7 +194,7 @@ func (s *snmp) validateOptions() error {
 			return errors.New("auth is configured, so an authentication " +
 				"protocol must be specified")
 		}
-		if s.authKey == "" {
+		if s.authKey.IsEmpty() {
 			return errors.New("auth is configured, so an authentication " +
 				"key must be specified")
 		}
@@ -161,7 +205,7 @@ func (s *snmp) validateOptions() error {
 			return errors.New("privacy is configured, so a privacy " +
 				"protocol must be specified")
 		}
-		if s.privacyKey == "" {
+		if s.privacyKey.IsEmpty() {
 			return errors.New("privacy is configured, so a privacy " +
 				"key must be specified")
 		}
@@ -201,8 +245,8 @@ func (s *snmp) formatOptions() (gosnmp.SnmpVersion, *psnmp.V3Params) {
 	} else if strings.ToLower(s.privacyProto) == "des" {
 		v3Params.UsmParams.PrivacyProtocol = gosnmp.DES
 	}
-	v3Params.UsmParams.AuthenticationPassphrase = s.authKey
-	v3Params.UsmParams.PrivacyPassphrase = s.privacyKey
+	v3Params.UsmParams.AuthenticationPassphrase = s.authKey.Value()
+	v3Params.UsmParams.PrivacyPassphrase = s.privacyKey.Value()
 
 	return gosnmp.Version3, v3Params
 }
@@ -225,7 +269,7 @@ func newSnmp(ctx context.Context, options map[string]string,
 		return nil, err
 	}
 
-	s.authKey, err = device.GetStringOption("A", options)
+	s.authKey, err = device.GetSecretOption("A", options)
 	if err != nil {
 		return nil, s.deviceConfigErr(err)
 	}
@@ -235,7 +279,7 @@ func newSnmp(ctx context.Context, options map[string]string,
 		return nil, s.deviceConfigErr(err)
 	}
 
-	s.community, err = device.GetStringOption("c", options)
+	s.community, err = device.GetSecretOption("c", options)
 	if err != nil {
 		return nil, s.deviceConfigErr(err)
 	}
@@ -265,7 +309,7 @@ func newSnmp(ctx context.Context, options map[string]string,
 	}
 	s.port = uint16(portint)
 
-	s.privacyKey, err = device.GetStringOption("X", options)
+	s.privacyKey, err = device.GetSecretOption("X", options)
 	if err != nil {
 		return nil, s.deviceConfigErr(err)
 	}
@@ -291,7 +335,7 @@ func newSnmp(ctx context.Context, options map[string]string,
 
 	s.v, s.v3Params = s.formatOptions()
 
-	s.snmpProvider = psnmp.NewSNMPProvider(ctx, s.address, s.port, s.community,
+	s.snmpProvider = psnmp.NewSNMPProvider(ctx, s.address, s.port, s.community.Value(),
 		s.pollInterval, s.v, s.v3Params, s.mibs, false, monitor)
 
 	return s, nil
@@ -216,8 +216,38 @@ func TestOptions(t *testing.T) {
 				"mibs":         "/a/b/c",
 				"pollInterval": "0s",
 			},
-			expectedError: errors.New("Configuration error for device " +
-				"1.1.1.1: poll interval must be greater than 0 seconds"),
+			expectedError: errors.New("Value for option 'pollInterval' ('0s') " +
+				"is less than the minimum of 1s"),
+		},
+		{
+			name: "poll interval without unit",
+			options: map[string]string{
+				"v":            "2c",
+				"c":            "public",
+				"address":      "1.1.1.1",
+				"mibs":         "/a/b/c",
+				"pollInterval": "20",
+			},
+			expectedError: errors.New("Value for option 'pollInterval' ('20') " +
+				"is not a valid duration: time: missing unit in duration \"20\""),
+		},
+		{
+			name:    "bad version",
+			options: selectOpt("v=2", "address", "mibs"),
+			expectedError: errors.New("Value for option 'v' ('2') " +
+				"is not one of 2c, 3"),
+		},
+		{
+			name: "bad port",
+			options: map[string]string{
+				"v":       "2c",
+				"c":       "public",
+				"address": "1.1.1.1",
+				"mibs":    "/a/b/c",
+				"port":    "70000",
+			},
+			expectedError: errors.New("Value for option 'port' ('70000') " +
+				"is not a valid port: not a valid port number"),
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -4,7 +4,16 @@
 
 package gen
 
-// To run this command you need protoc:
+// To run these commands you need protoc and its Go plugins:
 // brew install protobuf
+// go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.28.1
+// go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
 
-//go:generate protoc --proto_path=${GOPATH}/src --go_out=plugins=grpc,:${GOPATH}/src github.com/aristanetworks/cloudvision-go/device/inventory.proto
+// DeviceInventoryServer predates protoc-gen-go-grpc, so its
+// implementations aren't required to embed
+// UnimplementedDeviceInventoryServer.
+//go:generate protoc --proto_path=${GOPATH}/src --go_out=${GOPATH}/src --go-grpc_out=require_unimplemented_servers=false:${GOPATH}/src github.com/aristanetworks/cloudvision-go/device/inventory.proto
+
+//go:generate protoc --proto_path=${GOPATH}/src --go_out=${GOPATH}/src --go-grpc_out=${GOPATH}/src github.com/aristanetworks/cloudvision-go/device/plugin.proto
+
+//go:generate protoc --proto_path=${GOPATH}/src --go_out=${GOPATH}/src github.com/aristanetworks/cloudvision-go/device/record.proto
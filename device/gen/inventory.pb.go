@@ -1,696 +1,1223 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
 // source: github.com/aristanetworks/cloudvision-go/device/inventory.proto
 
 package gen
 
 import (
-	context "context"
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+type WatchResponse_Type int32
 
-type DeviceConfig struct {
-	Options              map[string]string `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	DeviceType           string            `protobuf:"bytes,2,opt,name=deviceType,proto3" json:"deviceType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+const (
+	WatchResponse_EXISTING WatchResponse_Type = 0
+	WatchResponse_ADDED    WatchResponse_Type = 1
+	WatchResponse_UPDATED  WatchResponse_Type = 2
+	WatchResponse_DELETED  WatchResponse_Type = 3
+)
+
+// Enum value maps for WatchResponse_Type.
+var (
+	WatchResponse_Type_name = map[int32]string{
+		0: "EXISTING",
+		1: "ADDED",
+		2: "UPDATED",
+		3: "DELETED",
+	}
+	WatchResponse_Type_value = map[string]int32{
+		"EXISTING": 0,
+		"ADDED":    1,
+		"UPDATED":  2,
+		"DELETED":  3,
+	}
+)
+
+func (x WatchResponse_Type) Enum() *WatchResponse_Type {
+	p := new(WatchResponse_Type)
+	*p = x
+	return p
 }
 
-func (m *DeviceConfig) Reset()         { *m = DeviceConfig{} }
-func (m *DeviceConfig) String() string { return proto.CompactTextString(m) }
-func (*DeviceConfig) ProtoMessage()    {}
-func (*DeviceConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{0}
+func (x WatchResponse_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchResponse_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchResponse_Type) Type() protoreflect.EnumType {
+	return &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_enumTypes[0]
+}
+
+func (x WatchResponse_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (m *DeviceConfig) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeviceConfig.Unmarshal(m, b)
+// Deprecated: Use WatchResponse_Type.Descriptor instead.
+func (WatchResponse_Type) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{14, 0}
 }
-func (m *DeviceConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeviceConfig.Marshal(b, m, deterministic)
+
+type DeviceConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Options    map[string]string `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DeviceType string            `protobuf:"bytes,2,opt,name=deviceType,proto3" json:"deviceType,omitempty"`
+	Name       string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 }
-func (m *DeviceConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeviceConfig.Merge(m, src)
+
+func (x *DeviceConfig) Reset() {
+	*x = DeviceConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *DeviceConfig) XXX_Size() int {
-	return xxx_messageInfo_DeviceConfig.Size(m)
+
+func (x *DeviceConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *DeviceConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeviceConfig.DiscardUnknown(m)
+
+func (*DeviceConfig) ProtoMessage() {}
+
+func (x *DeviceConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_DeviceConfig proto.InternalMessageInfo
+// Deprecated: Use DeviceConfig.ProtoReflect.Descriptor instead.
+func (*DeviceConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{0}
+}
 
-func (m *DeviceConfig) GetOptions() map[string]string {
-	if m != nil {
-		return m.Options
+func (x *DeviceConfig) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
 	}
 	return nil
 }
 
-func (m *DeviceConfig) GetDeviceType() string {
-	if m != nil {
-		return m.DeviceType
+func (x *DeviceConfig) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
 	}
 	return ""
 }
 
-// DeviceConfigs is used to marshal a list of DeviceConfig in a config file as
-// protobuf doesn't support top level list type.
-type DeviceConfigs struct {
-	DeviceConfigs        []*DeviceConfig `protobuf:"bytes,1,rep,name=deviceConfigs,proto3" json:"deviceConfigs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (x *DeviceConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (m *DeviceConfigs) Reset()         { *m = DeviceConfigs{} }
-func (m *DeviceConfigs) String() string { return proto.CompactTextString(m) }
-func (*DeviceConfigs) ProtoMessage()    {}
-func (*DeviceConfigs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{1}
-}
+type DeviceConfigs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *DeviceConfigs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeviceConfigs.Unmarshal(m, b)
-}
-func (m *DeviceConfigs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeviceConfigs.Marshal(b, m, deterministic)
+	DeviceConfigs []*DeviceConfig `protobuf:"bytes,1,rep,name=deviceConfigs,proto3" json:"deviceConfigs,omitempty"`
 }
-func (m *DeviceConfigs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeviceConfigs.Merge(m, src)
+
+func (x *DeviceConfigs) Reset() {
+	*x = DeviceConfigs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *DeviceConfigs) XXX_Size() int {
-	return xxx_messageInfo_DeviceConfigs.Size(m)
+
+func (x *DeviceConfigs) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *DeviceConfigs) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeviceConfigs.DiscardUnknown(m)
+
+func (*DeviceConfigs) ProtoMessage() {}
+
+func (x *DeviceConfigs) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_DeviceConfigs proto.InternalMessageInfo
+// Deprecated: Use DeviceConfigs.ProtoReflect.Descriptor instead.
+func (*DeviceConfigs) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *DeviceConfigs) GetDeviceConfigs() []*DeviceConfig {
-	if m != nil {
-		return m.DeviceConfigs
+func (x *DeviceConfigs) GetDeviceConfigs() []*DeviceConfig {
+	if x != nil {
+		return x.DeviceConfigs
 	}
 	return nil
 }
 
 type DeviceInfo struct {
-	// deviceConfig is empty if the device is created without using DeviceConfig.
-	DeviceConfig         *DeviceConfig `protobuf:"bytes,1,opt,name=deviceConfig,proto3" json:"deviceConfig,omitempty"`
-	DeviceID             string        `protobuf:"bytes,2,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceConfig  *DeviceConfig          `protobuf:"bytes,1,opt,name=deviceConfig,proto3" json:"deviceConfig,omitempty"`
+	DeviceID      string                 `protobuf:"bytes,2,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	LastHeartbeat *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=lastHeartbeat,proto3" json:"lastHeartbeat,omitempty"`
+	LastError     string                 `protobuf:"bytes,5,opt,name=lastError,proto3" json:"lastError,omitempty"`
+}
+
+func (x *DeviceInfo) Reset() {
+	*x = DeviceInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *DeviceInfo) Reset()         { *m = DeviceInfo{} }
-func (m *DeviceInfo) String() string { return proto.CompactTextString(m) }
-func (*DeviceInfo) ProtoMessage()    {}
-func (*DeviceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{2}
+func (x *DeviceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *DeviceInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeviceInfo.Unmarshal(m, b)
-}
-func (m *DeviceInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeviceInfo.Marshal(b, m, deterministic)
+func (*DeviceInfo) ProtoMessage() {}
+
+func (x *DeviceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *DeviceInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeviceInfo.Merge(m, src)
+
+// Deprecated: Use DeviceInfo.ProtoReflect.Descriptor instead.
+func (*DeviceInfo) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{2}
 }
-func (m *DeviceInfo) XXX_Size() int {
-	return xxx_messageInfo_DeviceInfo.Size(m)
+
+func (x *DeviceInfo) GetDeviceConfig() *DeviceConfig {
+	if x != nil {
+		return x.DeviceConfig
+	}
+	return nil
 }
-func (m *DeviceInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeviceInfo.DiscardUnknown(m)
+
+func (x *DeviceInfo) GetDeviceID() string {
+	if x != nil {
+		return x.DeviceID
+	}
+	return ""
 }
 
-var xxx_messageInfo_DeviceInfo proto.InternalMessageInfo
+func (x *DeviceInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
 
-func (m *DeviceInfo) GetDeviceConfig() *DeviceConfig {
-	if m != nil {
-		return m.DeviceConfig
+func (x *DeviceInfo) GetLastHeartbeat() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeat
 	}
 	return nil
 }
 
-func (m *DeviceInfo) GetDeviceID() string {
-	if m != nil {
-		return m.DeviceID
+func (x *DeviceInfo) GetLastError() string {
+	if x != nil {
+		return x.LastError
 	}
 	return ""
 }
 
 type AddRequest struct {
-	DeviceConfig         *DeviceConfig `protobuf:"bytes,1,opt,name=deviceConfig,proto3" json:"deviceConfig,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *AddRequest) Reset()         { *m = AddRequest{} }
-func (m *AddRequest) String() string { return proto.CompactTextString(m) }
-func (*AddRequest) ProtoMessage()    {}
-func (*AddRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{3}
+	DeviceConfig *DeviceConfig `protobuf:"bytes,1,opt,name=deviceConfig,proto3" json:"deviceConfig,omitempty"`
 }
 
-func (m *AddRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddRequest.Unmarshal(m, b)
-}
-func (m *AddRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddRequest.Marshal(b, m, deterministic)
-}
-func (m *AddRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddRequest.Merge(m, src)
+func (x *AddRequest) Reset() {
+	*x = AddRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *AddRequest) XXX_Size() int {
-	return xxx_messageInfo_AddRequest.Size(m)
+
+func (x *AddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *AddRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddRequest.DiscardUnknown(m)
+
+func (*AddRequest) ProtoMessage() {}
+
+func (x *AddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_AddRequest proto.InternalMessageInfo
+// Deprecated: Use AddRequest.ProtoReflect.Descriptor instead.
+func (*AddRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{3}
+}
 
-func (m *AddRequest) GetDeviceConfig() *DeviceConfig {
-	if m != nil {
-		return m.DeviceConfig
+func (x *AddRequest) GetDeviceConfig() *DeviceConfig {
+	if x != nil {
+		return x.DeviceConfig
 	}
 	return nil
 }
 
 type AddResponse struct {
-	DeviceInfo           *DeviceInfo `protobuf:"bytes,1,opt,name=deviceInfo,proto3" json:"deviceInfo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *AddResponse) Reset()         { *m = AddResponse{} }
-func (m *AddResponse) String() string { return proto.CompactTextString(m) }
-func (*AddResponse) ProtoMessage()    {}
-func (*AddResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{4}
+	DeviceInfo *DeviceInfo `protobuf:"bytes,1,opt,name=deviceInfo,proto3" json:"deviceInfo,omitempty"`
 }
 
-func (m *AddResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddResponse.Unmarshal(m, b)
-}
-func (m *AddResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddResponse.Marshal(b, m, deterministic)
-}
-func (m *AddResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddResponse.Merge(m, src)
+func (x *AddResponse) Reset() {
+	*x = AddResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *AddResponse) XXX_Size() int {
-	return xxx_messageInfo_AddResponse.Size(m)
+
+func (x *AddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *AddResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddResponse.DiscardUnknown(m)
+
+func (*AddResponse) ProtoMessage() {}
+
+func (x *AddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_AddResponse proto.InternalMessageInfo
+// Deprecated: Use AddResponse.ProtoReflect.Descriptor instead.
+func (*AddResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{4}
+}
 
-func (m *AddResponse) GetDeviceInfo() *DeviceInfo {
-	if m != nil {
-		return m.DeviceInfo
+func (x *AddResponse) GetDeviceInfo() *DeviceInfo {
+	if x != nil {
+		return x.DeviceInfo
 	}
 	return nil
 }
 
 type DeleteRequest struct {
-	DeviceID             string   `protobuf:"bytes,1,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
-func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRequest) ProtoMessage()    {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{5}
+	DeviceID string `protobuf:"bytes,1,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
 }
 
-func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
-}
-func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
-}
-func (m *DeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRequest.Merge(m, src)
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *DeleteRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteRequest.Size(m)
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *DeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{5}
+}
 
-func (m *DeleteRequest) GetDeviceID() string {
-	if m != nil {
-		return m.DeviceID
+func (x *DeleteRequest) GetDeviceID() string {
+	if x != nil {
+		return x.DeviceID
 	}
 	return ""
 }
 
 type DeleteResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
-func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteResponse) ProtoMessage()    {}
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{6}
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
-}
-func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
-}
-func (m *DeleteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteResponse.Merge(m, src)
-}
-func (m *DeleteResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteResponse.Size(m)
-}
-func (m *DeleteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+func (*DeleteResponse) ProtoMessage() {}
 
-type GetRequest struct {
-	DeviceID             string   `protobuf:"bytes,1,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *GetRequest) Reset()         { *m = GetRequest{} }
-func (m *GetRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRequest) ProtoMessage()    {}
-func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{7}
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{6}
 }
 
-func (m *GetRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
-}
-func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+type GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceID string `protobuf:"bytes,1,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
 }
-func (m *GetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRequest.Merge(m, src)
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetRequest) XXX_Size() int {
-	return xxx_messageInfo_GetRequest.Size(m)
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *GetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{7}
+}
 
-func (m *GetRequest) GetDeviceID() string {
-	if m != nil {
-		return m.DeviceID
+func (x *GetRequest) GetDeviceID() string {
+	if x != nil {
+		return x.DeviceID
 	}
 	return ""
 }
 
 type GetResponse struct {
-	DeviceInfo           *DeviceInfo `protobuf:"bytes,1,opt,name=deviceInfo,proto3" json:"deviceInfo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *GetResponse) Reset()         { *m = GetResponse{} }
-func (m *GetResponse) String() string { return proto.CompactTextString(m) }
-func (*GetResponse) ProtoMessage()    {}
-func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{8}
+	DeviceInfo *DeviceInfo `protobuf:"bytes,1,opt,name=deviceInfo,proto3" json:"deviceInfo,omitempty"`
 }
 
-func (m *GetResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
-}
-func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
-}
-func (m *GetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetResponse.Merge(m, src)
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetResponse) XXX_Size() int {
-	return xxx_messageInfo_GetResponse.Size(m)
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *GetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{8}
+}
 
-func (m *GetResponse) GetDeviceInfo() *DeviceInfo {
-	if m != nil {
-		return m.DeviceInfo
+func (x *GetResponse) GetDeviceInfo() *DeviceInfo {
+	if x != nil {
+		return x.DeviceInfo
 	}
 	return nil
 }
 
 type ListRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (m *ListRequest) Reset()         { *m = ListRequest{} }
-func (m *ListRequest) String() string { return proto.CompactTextString(m) }
-func (*ListRequest) ProtoMessage()    {}
-func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{9}
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *ListRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *ListRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListRequest.Merge(m, src)
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{9}
 }
-func (m *ListRequest) XXX_Size() int {
-	return xxx_messageInfo_ListRequest.Size(m)
+
+type ListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceInfos []*DeviceInfo `protobuf:"bytes,1,rep,name=deviceInfos,proto3" json:"deviceInfos,omitempty"`
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ListRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+func (*ListResponse) ProtoMessage() {}
 
-type ListResponse struct {
-	DeviceInfos          []*DeviceInfo `protobuf:"bytes,1,rep,name=deviceInfos,proto3" json:"deviceInfos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *ListResponse) Reset()         { *m = ListResponse{} }
-func (m *ListResponse) String() string { return proto.CompactTextString(m) }
-func (*ListResponse) ProtoMessage()    {}
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88e1eb759e682ab9, []int{10}
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{10}
 }
 
-func (m *ListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
-}
-func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+func (x *ListResponse) GetDeviceInfos() []*DeviceInfo {
+	if x != nil {
+		return x.DeviceInfos
+	}
+	return nil
 }
-func (m *ListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListResponse.Merge(m, src)
+
+type UpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceID     string        `protobuf:"bytes,1,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+	DeviceConfig *DeviceConfig `protobuf:"bytes,2,opt,name=deviceConfig,proto3" json:"deviceConfig,omitempty"`
 }
-func (m *ListResponse) XXX_Size() int {
-	return xxx_messageInfo_ListResponse.Size(m)
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+func (*UpdateRequest) ProtoMessage() {}
 
-func (m *ListResponse) GetDeviceInfos() []*DeviceInfo {
-	if m != nil {
-		return m.DeviceInfos
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{11}
 }
 
-func init() {
-	proto.RegisterType((*DeviceConfig)(nil), "arista.cloudvision.DeviceConfig")
-	proto.RegisterMapType((map[string]string)(nil), "arista.cloudvision.DeviceConfig.OptionsEntry")
-	proto.RegisterType((*DeviceConfigs)(nil), "arista.cloudvision.DeviceConfigs")
-	proto.RegisterType((*DeviceInfo)(nil), "arista.cloudvision.DeviceInfo")
-	proto.RegisterType((*AddRequest)(nil), "arista.cloudvision.AddRequest")
-	proto.RegisterType((*AddResponse)(nil), "arista.cloudvision.AddResponse")
-	proto.RegisterType((*DeleteRequest)(nil), "arista.cloudvision.DeleteRequest")
-	proto.RegisterType((*DeleteResponse)(nil), "arista.cloudvision.DeleteResponse")
-	proto.RegisterType((*GetRequest)(nil), "arista.cloudvision.GetRequest")
-	proto.RegisterType((*GetResponse)(nil), "arista.cloudvision.GetResponse")
-	proto.RegisterType((*ListRequest)(nil), "arista.cloudvision.ListRequest")
-	proto.RegisterType((*ListResponse)(nil), "arista.cloudvision.ListResponse")
-}
-
-func init() {
-	proto.RegisterFile("github.com/aristanetworks/cloudvision-go/device/inventory.proto", fileDescriptor_88e1eb759e682ab9)
-}
-
-var fileDescriptor_88e1eb759e682ab9 = []byte{
-	// 445 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x51, 0xab, 0xd3, 0x30,
-	0x14, 0xa6, 0xab, 0x5e, 0xf5, 0xb4, 0xd3, 0x4b, 0xf0, 0x61, 0xf4, 0xe1, 0x5a, 0xf3, 0x34, 0x90,
-	0xdb, 0xc2, 0xbd, 0x08, 0xb2, 0x07, 0x75, 0x3a, 0x37, 0x06, 0x8e, 0x49, 0x11, 0x04, 0xdf, 0xb6,
-	0x35, 0xab, 0x61, 0x33, 0xa9, 0x4d, 0x5a, 0xe9, 0xef, 0xf2, 0xd9, 0xff, 0x26, 0x6d, 0x1a, 0x97,
-	0x69, 0xe7, 0x26, 0xec, 0xad, 0x27, 0xe7, 0xfb, 0xbe, 0xf3, 0x9d, 0x73, 0x9a, 0xc0, 0xab, 0x84,
-	0xca, 0x2f, 0xf9, 0x32, 0x58, 0xf1, 0xaf, 0xe1, 0x22, 0xa3, 0x42, 0x2e, 0x18, 0x91, 0xdf, 0x79,
-	0xb6, 0x11, 0xe1, 0x6a, 0xcb, 0xf3, 0xb8, 0xa0, 0x82, 0x72, 0x76, 0x9d, 0xf0, 0x30, 0x26, 0x05,
-	0x5d, 0x91, 0x90, 0xb2, 0x82, 0x30, 0xc9, 0xb3, 0x32, 0x48, 0x33, 0x2e, 0x39, 0x42, 0x8a, 0x15,
-	0x18, 0x68, 0xfc, 0xc3, 0x02, 0x77, 0x54, 0xc3, 0xdf, 0x72, 0xb6, 0xa6, 0x09, 0x9a, 0xc0, 0x3d,
-	0x9e, 0x4a, 0xca, 0x99, 0xe8, 0x59, 0xbe, 0xdd, 0x77, 0x6e, 0xae, 0x83, 0xbf, 0x69, 0x81, 0x49,
-	0x09, 0xe6, 0x0a, 0xff, 0x8e, 0xc9, 0xac, 0x8c, 0x34, 0x1b, 0x5d, 0x01, 0x28, 0x1f, 0x1f, 0xcb,
-	0x94, 0xf4, 0x3a, 0xbe, 0xd5, 0x7f, 0x10, 0x19, 0x27, 0xde, 0x00, 0x5c, 0x93, 0x88, 0x2e, 0xc1,
-	0xde, 0x90, 0xb2, 0x67, 0xd5, 0xc0, 0xea, 0x13, 0x3d, 0x86, 0xbb, 0xc5, 0x62, 0x9b, 0x6b, 0xb2,
-	0x0a, 0x06, 0x9d, 0x17, 0x16, 0xfe, 0x04, 0x5d, 0xd3, 0x81, 0x40, 0x63, 0xe8, 0xc6, 0xe6, 0x41,
-	0xe3, 0xdd, 0x3f, 0xe6, 0x3d, 0xda, 0xa7, 0x61, 0x06, 0xa0, 0xd2, 0x53, 0xb6, 0xe6, 0x68, 0x04,
-	0xae, 0x99, 0xae, 0xbd, 0x9d, 0x22, 0xba, 0xc7, 0x42, 0x1e, 0xdc, 0x57, 0xf1, 0x74, 0xd4, 0x74,
-	0xf2, 0x3b, 0xc6, 0x11, 0xc0, 0x30, 0x8e, 0x23, 0xf2, 0x2d, 0x27, 0x42, 0x9e, 0xa7, 0x1e, 0x9e,
-	0x81, 0x53, 0x6b, 0x8a, 0x94, 0x33, 0x41, 0xd0, 0x4b, 0xbd, 0x87, 0xaa, 0xa5, 0x46, 0xf2, 0xea,
-	0xb0, 0x64, 0x85, 0x8a, 0x0c, 0x06, 0x7e, 0x56, 0xcd, 0x7a, 0x4b, 0x24, 0xd1, 0x2e, 0xcd, 0x7e,
-	0xac, 0x3f, 0xfa, 0xb9, 0x84, 0x87, 0x1a, 0xac, 0xca, 0xe3, 0x3e, 0xc0, 0x84, 0xc8, 0x53, 0xb8,
-	0x33, 0x70, 0x6a, 0xe4, 0x99, 0x7c, 0x77, 0xc1, 0x79, 0x4f, 0x85, 0xae, 0x8c, 0x3f, 0x80, 0xab,
-	0xc2, 0x46, 0xfe, 0x35, 0x38, 0x3b, 0xb0, 0xfe, 0x5f, 0x8e, 0xe9, 0x9b, 0x94, 0x9b, 0x9f, 0x1d,
-	0x78, 0xa4, 0x73, 0xcd, 0x45, 0x43, 0x63, 0xb0, 0x87, 0x71, 0x8c, 0x5a, 0x75, 0x76, 0x8b, 0xf6,
-	0x9e, 0x1c, 0xcc, 0x37, 0xee, 0xe6, 0x70, 0xa1, 0xe6, 0x88, 0x9e, 0xb6, 0x5b, 0x32, 0x16, 0xe2,
-	0xe1, 0x7f, 0x41, 0x1a, 0xc1, 0x31, 0xd8, 0x13, 0x22, 0xdb, 0x8d, 0xed, 0xf6, 0xd3, 0x6e, 0xcc,
-	0xdc, 0xca, 0x14, 0xee, 0x54, 0x63, 0x44, 0xad, 0x40, 0x63, 0xde, 0x9e, 0x7f, 0x18, 0xa0, 0xa4,
-	0xde, 0x3c, 0xff, 0x7c, 0xfb, 0xbf, 0x2f, 0x5a, 0x42, 0xd8, 0xf2, 0xa2, 0x7e, 0xcc, 0x6e, 0x7f,
-	0x05, 0x00, 0x00, 0xff, 0xff, 0xaf, 0xb7, 0x05, 0xdc, 0x0f, 0x05, 0x00, 0x00,
+func (x *UpdateRequest) GetDeviceID() string {
+	if x != nil {
+		return x.DeviceID
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (x *UpdateRequest) GetDeviceConfig() *DeviceConfig {
+	if x != nil {
+		return x.DeviceConfig
+	}
+	return nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type UpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-// DeviceInventoryClient is the client API for DeviceInventory service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DeviceInventoryClient interface {
-	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	DeviceInfo *DeviceInfo `protobuf:"bytes,1,opt,name=deviceInfo,proto3" json:"deviceInfo,omitempty"`
 }
 
-type deviceInventoryClient struct {
-	cc *grpc.ClientConn
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func NewDeviceInventoryClient(cc *grpc.ClientConn) DeviceInventoryClient {
-	return &deviceInventoryClient{cc}
+func (x *UpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *deviceInventoryClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
-	out := new(AddResponse)
-	err := c.cc.Invoke(ctx, "/arista.cloudvision.DeviceInventory/Add", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*UpdateResponse) ProtoMessage() {}
+
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return out, nil
+	return mi.MessageOf(x)
 }
 
-func (c *deviceInventoryClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/arista.cloudvision.DeviceInventory/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{12}
 }
 
-func (c *deviceInventoryClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/arista.cloudvision.DeviceInventory/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *UpdateResponse) GetDeviceInfo() *DeviceInfo {
+	if x != nil {
+		return x.DeviceInfo
 	}
-	return out, nil
+	return nil
 }
 
-func (c *deviceInventoryClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
-	out := new(ListResponse)
-	err := c.cc.Invoke(ctx, "/arista.cloudvision.DeviceInventory/List", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-// DeviceInventoryServer is the server API for DeviceInventory service.
-type DeviceInventoryServer interface {
-	Add(context.Context, *AddRequest) (*AddResponse, error)
-	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
-	Get(context.Context, *GetRequest) (*GetResponse, error)
-	List(context.Context, *ListRequest) (*ListResponse, error)
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-// UnimplementedDeviceInventoryServer can be embedded to have forward compatible implementations.
-type UnimplementedDeviceInventoryServer struct {
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnimplementedDeviceInventoryServer) Add(ctx context.Context, req *AddRequest) (*AddResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (*UnimplementedDeviceInventoryServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{13}
 }
-func (*UnimplementedDeviceInventoryServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+
+type WatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       WatchResponse_Type `protobuf:"varint,1,opt,name=type,proto3,enum=arista.cloudvision.WatchResponse_Type" json:"type,omitempty"`
+	DeviceInfo *DeviceInfo        `protobuf:"bytes,2,opt,name=deviceInfo,proto3" json:"deviceInfo,omitempty"`
 }
-func (*UnimplementedDeviceInventoryServer) List(ctx context.Context, req *ListRequest) (*ListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func RegisterDeviceInventoryServer(s *grpc.Server, srv DeviceInventoryServer) {
-	s.RegisterService(&_DeviceInventory_serviceDesc, srv)
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func _DeviceInventory_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DeviceInventoryServer).Add(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/arista.cloudvision.DeviceInventory/Add",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceInventoryServer).Add(ctx, req.(*AddRequest))
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return interceptor(ctx, in, info, handler)
+	return mi.MessageOf(x)
 }
 
-func _DeviceInventory_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DeviceInventoryServer).Delete(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/arista.cloudvision.DeviceInventory/Delete",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceInventoryServer).Delete(ctx, req.(*DeleteRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP(), []int{14}
 }
 
-func _DeviceInventory_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DeviceInventoryServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/arista.cloudvision.DeviceInventory/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceInventoryServer).Get(ctx, req.(*GetRequest))
+func (x *WatchResponse) GetType() WatchResponse_Type {
+	if x != nil {
+		return x.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return WatchResponse_EXISTING
 }
 
-func _DeviceInventory_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *WatchResponse) GetDeviceInfo() *DeviceInfo {
+	if x != nil {
+		return x.DeviceInfo
 	}
-	if interceptor == nil {
-		return srv.(DeviceInventoryServer).List(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/arista.cloudvision.DeviceInventory/List",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceInventoryServer).List(ctx, req.(*ListRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _DeviceInventory_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "arista.cloudvision.DeviceInventory",
-	HandlerType: (*DeviceInventoryServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Add",
-			Handler:    _DeviceInventory_Add_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _DeviceInventory_Delete_Handler,
-		},
-		{
-			MethodName: "Get",
-			Handler:    _DeviceInventory_Get_Handler,
-		},
-		{
-			MethodName: "List",
-			Handler:    _DeviceInventory_List_Handler,
+var File_github_com_aristanetworks_cloudvision_go_device_inventory_proto protoreflect.FileDescriptor
+
+var file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDesc = []byte{
+	0x0a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x72, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2d, 0x67, 0x6f, 0x2f, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x2f, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x12, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc7, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x47, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74,
+	0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x57, 0x0a, 0x0d, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x12, 0x46, 0x0a, 0x0d, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74,
+	0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x22, 0xe6, 0x01, 0x0a, 0x0a, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x44, 0x0a, 0x0c, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x0c, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x40, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x52, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x44, 0x0a, 0x0c, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x4d, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73,
+	0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x2b, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x44, 0x22, 0x10, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x22, 0x4d,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
+	0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x0d, 0x0a,
+	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x50, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0b,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0x71,
+	0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x12, 0x44, 0x0a, 0x0c, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x0c, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x22, 0x50, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x22, 0x0e, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xc6, 0x01, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x22, 0x39, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x58, 0x49,
+	0x53, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x44, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x0b, 0x0a, 0x07, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x32, 0xde, 0x03, 0x0a,
+	0x0f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x46, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x03, 0x47, 0x65, 0x74,
+	0x12, 0x1e, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x61, 0x72, 0x69, 0x73,
+	0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x72, 0x69,
+	0x73, 0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x06,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x72, 0x69, 0x73,
+	0x74, 0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a,
+	0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x20, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74, 0x61, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x72, 0x69, 0x73, 0x74,
+	0x61, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x35, 0x5a,
+	0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x72, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2d, 0x67, 0x6f, 0x2f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x2f, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescOnce sync.Once
+	file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescData = file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDesc
+)
+
+func file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescGZIP() []byte {
+	file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescOnce.Do(func() {
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescData)
+	})
+	return file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDescData
+}
+
+var file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_goTypes = []interface{}{
+	(WatchResponse_Type)(0),       // 0: arista.cloudvision.WatchResponse.Type
+	(*DeviceConfig)(nil),          // 1: arista.cloudvision.DeviceConfig
+	(*DeviceConfigs)(nil),         // 2: arista.cloudvision.DeviceConfigs
+	(*DeviceInfo)(nil),            // 3: arista.cloudvision.DeviceInfo
+	(*AddRequest)(nil),            // 4: arista.cloudvision.AddRequest
+	(*AddResponse)(nil),           // 5: arista.cloudvision.AddResponse
+	(*DeleteRequest)(nil),         // 6: arista.cloudvision.DeleteRequest
+	(*DeleteResponse)(nil),        // 7: arista.cloudvision.DeleteResponse
+	(*GetRequest)(nil),            // 8: arista.cloudvision.GetRequest
+	(*GetResponse)(nil),           // 9: arista.cloudvision.GetResponse
+	(*ListRequest)(nil),           // 10: arista.cloudvision.ListRequest
+	(*ListResponse)(nil),          // 11: arista.cloudvision.ListResponse
+	(*UpdateRequest)(nil),         // 12: arista.cloudvision.UpdateRequest
+	(*UpdateResponse)(nil),        // 13: arista.cloudvision.UpdateResponse
+	(*WatchRequest)(nil),          // 14: arista.cloudvision.WatchRequest
+	(*WatchResponse)(nil),         // 15: arista.cloudvision.WatchResponse
+	nil,                           // 16: arista.cloudvision.DeviceConfig.OptionsEntry
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+}
+var file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_depIdxs = []int32{
+	16, // 0: arista.cloudvision.DeviceConfig.options:type_name -> arista.cloudvision.DeviceConfig.OptionsEntry
+	1,  // 1: arista.cloudvision.DeviceConfigs.deviceConfigs:type_name -> arista.cloudvision.DeviceConfig
+	1,  // 2: arista.cloudvision.DeviceInfo.deviceConfig:type_name -> arista.cloudvision.DeviceConfig
+	17, // 3: arista.cloudvision.DeviceInfo.lastHeartbeat:type_name -> google.protobuf.Timestamp
+	1,  // 4: arista.cloudvision.AddRequest.deviceConfig:type_name -> arista.cloudvision.DeviceConfig
+	3,  // 5: arista.cloudvision.AddResponse.deviceInfo:type_name -> arista.cloudvision.DeviceInfo
+	3,  // 6: arista.cloudvision.GetResponse.deviceInfo:type_name -> arista.cloudvision.DeviceInfo
+	3,  // 7: arista.cloudvision.ListResponse.deviceInfos:type_name -> arista.cloudvision.DeviceInfo
+	1,  // 8: arista.cloudvision.UpdateRequest.deviceConfig:type_name -> arista.cloudvision.DeviceConfig
+	3,  // 9: arista.cloudvision.UpdateResponse.deviceInfo:type_name -> arista.cloudvision.DeviceInfo
+	0,  // 10: arista.cloudvision.WatchResponse.type:type_name -> arista.cloudvision.WatchResponse.Type
+	3,  // 11: arista.cloudvision.WatchResponse.deviceInfo:type_name -> arista.cloudvision.DeviceInfo
+	4,  // 12: arista.cloudvision.DeviceInventory.Add:input_type -> arista.cloudvision.AddRequest
+	6,  // 13: arista.cloudvision.DeviceInventory.Delete:input_type -> arista.cloudvision.DeleteRequest
+	8,  // 14: arista.cloudvision.DeviceInventory.Get:input_type -> arista.cloudvision.GetRequest
+	10, // 15: arista.cloudvision.DeviceInventory.List:input_type -> arista.cloudvision.ListRequest
+	12, // 16: arista.cloudvision.DeviceInventory.Update:input_type -> arista.cloudvision.UpdateRequest
+	14, // 17: arista.cloudvision.DeviceInventory.Watch:input_type -> arista.cloudvision.WatchRequest
+	5,  // 18: arista.cloudvision.DeviceInventory.Add:output_type -> arista.cloudvision.AddResponse
+	7,  // 19: arista.cloudvision.DeviceInventory.Delete:output_type -> arista.cloudvision.DeleteResponse
+	9,  // 20: arista.cloudvision.DeviceInventory.Get:output_type -> arista.cloudvision.GetResponse
+	11, // 21: arista.cloudvision.DeviceInventory.List:output_type -> arista.cloudvision.ListResponse
+	13, // 22: arista.cloudvision.DeviceInventory.Update:output_type -> arista.cloudvision.UpdateResponse
+	15, // 23: arista.cloudvision.DeviceInventory.Watch:output_type -> arista.cloudvision.WatchResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_init() }
+func file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_init() {
+	if File_github_com_aristanetworks_cloudvision_go_device_inventory_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceConfigs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
 		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "github.com/aristanetworks/cloudvision-go/device/inventory.proto",
+		GoTypes:           file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_goTypes,
+		DependencyIndexes: file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_depIdxs,
+		EnumInfos:         file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_enumTypes,
+		MessageInfos:      file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_msgTypes,
+	}.Build()
+	File_github_com_aristanetworks_cloudvision_go_device_inventory_proto = out.File
+	file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_rawDesc = nil
+	file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_goTypes = nil
+	file_github_com_aristanetworks_cloudvision_go_device_inventory_proto_depIdxs = nil
 }
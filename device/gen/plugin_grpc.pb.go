@@ -0,0 +1,360 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: github.com/aristanetworks/cloudvision-go/device/plugin.proto
+
+package gen
+
+import (
+	context "context"
+	gnmi "github.com/openconfig/gnmi/proto/gnmi"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DevicePlugin_Describe_FullMethodName    = "/arista.cloudvision.plugin.DevicePlugin/Describe"
+	DevicePlugin_Create_FullMethodName      = "/arista.cloudvision.plugin.DevicePlugin/Create"
+	DevicePlugin_Alive_FullMethodName       = "/arista.cloudvision.plugin.DevicePlugin/Alive"
+	DevicePlugin_DeviceID_FullMethodName    = "/arista.cloudvision.plugin.DevicePlugin/DeviceID"
+	DevicePlugin_IPAddr_FullMethodName      = "/arista.cloudvision.plugin.DevicePlugin/IPAddr"
+	DevicePlugin_RunProvider_FullMethodName = "/arista.cloudvision.plugin.DevicePlugin/RunProvider"
+	DevicePlugin_Close_FullMethodName       = "/arista.cloudvision.plugin.DevicePlugin/Close"
+)
+
+// DevicePluginClient is the client API for DevicePlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DevicePluginClient interface {
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Alive(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*AliveResponse, error)
+	DeviceID(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceIDResponse, error)
+	IPAddr(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*IPAddrResponse, error)
+	RunProvider(ctx context.Context, in *RunProviderRequest, opts ...grpc.CallOption) (DevicePlugin_RunProviderClient, error)
+	Close(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*CloseResponse, error)
+}
+
+type devicePluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDevicePluginClient(cc grpc.ClientConnInterface) DevicePluginClient {
+	return &devicePluginClient{cc}
+}
+
+func (c *devicePluginClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, DevicePlugin_Describe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *devicePluginClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, DevicePlugin_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *devicePluginClient) Alive(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*AliveResponse, error) {
+	out := new(AliveResponse)
+	err := c.cc.Invoke(ctx, DevicePlugin_Alive_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *devicePluginClient) DeviceID(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceIDResponse, error) {
+	out := new(DeviceIDResponse)
+	err := c.cc.Invoke(ctx, DevicePlugin_DeviceID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *devicePluginClient) IPAddr(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*IPAddrResponse, error) {
+	out := new(IPAddrResponse)
+	err := c.cc.Invoke(ctx, DevicePlugin_IPAddr_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *devicePluginClient) RunProvider(ctx context.Context, in *RunProviderRequest, opts ...grpc.CallOption) (DevicePlugin_RunProviderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DevicePlugin_ServiceDesc.Streams[0], DevicePlugin_RunProvider_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &devicePluginRunProviderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DevicePlugin_RunProviderClient interface {
+	Recv() (*gnmi.SetRequest, error)
+	grpc.ClientStream
+}
+
+type devicePluginRunProviderClient struct {
+	grpc.ClientStream
+}
+
+func (x *devicePluginRunProviderClient) Recv() (*gnmi.SetRequest, error) {
+	m := new(gnmi.SetRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *devicePluginClient) Close(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*CloseResponse, error) {
+	out := new(CloseResponse)
+	err := c.cc.Invoke(ctx, DevicePlugin_Close_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DevicePluginServer is the server API for DevicePlugin service.
+// All implementations must embed UnimplementedDevicePluginServer
+// for forward compatibility
+type DevicePluginServer interface {
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Alive(context.Context, *DeviceRequest) (*AliveResponse, error)
+	DeviceID(context.Context, *DeviceRequest) (*DeviceIDResponse, error)
+	IPAddr(context.Context, *DeviceRequest) (*IPAddrResponse, error)
+	RunProvider(*RunProviderRequest, DevicePlugin_RunProviderServer) error
+	Close(context.Context, *DeviceRequest) (*CloseResponse, error)
+	mustEmbedUnimplementedDevicePluginServer()
+}
+
+// UnimplementedDevicePluginServer must be embedded to have forward compatible implementations.
+type UnimplementedDevicePluginServer struct {
+}
+
+func (UnimplementedDevicePluginServer) Describe(context.Context, *DescribeRequest) (*DescribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedDevicePluginServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedDevicePluginServer) Alive(context.Context, *DeviceRequest) (*AliveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Alive not implemented")
+}
+func (UnimplementedDevicePluginServer) DeviceID(context.Context, *DeviceRequest) (*DeviceIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceID not implemented")
+}
+func (UnimplementedDevicePluginServer) IPAddr(context.Context, *DeviceRequest) (*IPAddrResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IPAddr not implemented")
+}
+func (UnimplementedDevicePluginServer) RunProvider(*RunProviderRequest, DevicePlugin_RunProviderServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunProvider not implemented")
+}
+func (UnimplementedDevicePluginServer) Close(context.Context, *DeviceRequest) (*CloseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Close not implemented")
+}
+func (UnimplementedDevicePluginServer) mustEmbedUnimplementedDevicePluginServer() {}
+
+// UnsafeDevicePluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DevicePluginServer will
+// result in compilation errors.
+type UnsafeDevicePluginServer interface {
+	mustEmbedUnimplementedDevicePluginServer()
+}
+
+func RegisterDevicePluginServer(s grpc.ServiceRegistrar, srv DevicePluginServer) {
+	s.RegisterService(&DevicePlugin_ServiceDesc, srv)
+}
+
+func _DevicePlugin_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DevicePlugin_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DevicePlugin_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DevicePlugin_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DevicePlugin_Alive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).Alive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DevicePlugin_Alive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).Alive(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DevicePlugin_DeviceID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).DeviceID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DevicePlugin_DeviceID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).DeviceID(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DevicePlugin_IPAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).IPAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DevicePlugin_IPAddr_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).IPAddr(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DevicePlugin_RunProvider_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunProviderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DevicePluginServer).RunProvider(m, &devicePluginRunProviderServer{stream})
+}
+
+type DevicePlugin_RunProviderServer interface {
+	Send(*gnmi.SetRequest) error
+	grpc.ServerStream
+}
+
+type devicePluginRunProviderServer struct {
+	grpc.ServerStream
+}
+
+func (x *devicePluginRunProviderServer) Send(m *gnmi.SetRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DevicePlugin_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).Close(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DevicePlugin_Close_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).Close(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DevicePlugin_ServiceDesc is the grpc.ServiceDesc for DevicePlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DevicePlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "arista.cloudvision.plugin.DevicePlugin",
+	HandlerType: (*DevicePluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _DevicePlugin_Describe_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _DevicePlugin_Create_Handler,
+		},
+		{
+			MethodName: "Alive",
+			Handler:    _DevicePlugin_Alive_Handler,
+		},
+		{
+			MethodName: "DeviceID",
+			Handler:    _DevicePlugin_DeviceID_Handler,
+		},
+		{
+			MethodName: "IPAddr",
+			Handler:    _DevicePlugin_IPAddr_Handler,
+		},
+		{
+			MethodName: "Close",
+			Handler:    _DevicePlugin_Close_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunProvider",
+			Handler:       _DevicePlugin_RunProvider_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "github.com/aristanetworks/cloudvision-go/device/plugin.proto",
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	groupKey = "Group"
+	// maxGroupMemberAddresses bounds the number of addresses a group
+	// member expands into, to catch prefixes that are too short.
+	maxGroupMemberAddresses = 1 << 16
+)
+
+// configGroup is a device group in a config file.
+type configGroup struct {
+	Group         string            `yaml:"Group"`
+	Device        string            `yaml:"Device"`
+	NoStream      bool              `yaml:"NoStream"`
+	Options       map[string]string `yaml:"Options"`
+	LogLevel      string            `yaml:"LogLevel"`
+	Credentials   map[string]string `yaml:"Credentials"`
+	AddressOption string            `yaml:"AddressOption"`
+	Members       yaml.Node         `yaml:"Members"`
+}
+
+// groupMember is a member of a device group, with the parts of the
+// group's config it overrides.
+type groupMember struct {
+	Address     string            `yaml:"Address"`
+	NoStream    *bool             `yaml:"NoStream"`
+	Options     map[string]string `yaml:"Options"`
+	LogLevel    string            `yaml:"LogLevel"`
+	Credentials map[string]string `yaml:"Credentials"`
+}
+
+// UnmarshalYAML lets members without overrides be written as just
+// their address.
+func (m *groupMember) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		m.Address = value.Value
+		return nil
+	}
+	type member groupMember
+	return value.Decode((*member)(m))
+}
+
+// addressOption returns the option of a device type that's set to
+// the address of group members.
+func (g *configGroup) addressOption() string {
+	if g.AddressOption != "" {
+		return g.AddressOption
+	}
+	var found []string
+	for name, option := range deviceMap[g.Device].options {
+		if option.Type == OptionTypeAddress {
+			found = append(found, name)
+		}
+	}
+	if len(found) == 1 {
+		return found[0]
+	}
+	return "address"
+}
+
+// config returns the config of the group member with the specified
+// address.
+func (g *configGroup) config(address string, overrides []*groupMember) *Config {
+	config := &Config{
+		Name:        g.Group + "-" + address,
+		Device:      g.Device,
+		NoStream:    g.NoStream,
+		Options:     map[string]string{},
+		LogLevel:    g.LogLevel,
+		Credentials: mergeMaps(nil, g.Credentials),
+	}
+	mergeMaps(config.Options, g.Options)
+	for _, m := range overrides {
+		if m.NoStream != nil {
+			config.NoStream = *m.NoStream
+		}
+		if m.LogLevel != "" {
+			config.LogLevel = m.LogLevel
+		}
+		mergeMaps(config.Options, m.Options)
+		config.Credentials = mergeMaps(config.Credentials, m.Credentials)
+	}
+	config.Options[g.addressOption()] = address
+	return config
+}
+
+// mergeMaps copies the entries of src to dst, allocating dst if it's
+// nil and src isn't empty, and returns dst.
+func mergeMaps(dst, src map[string]string) map[string]string {
+	if dst == nil && len(src) > 0 {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// readGroup reads the configs of the device group in entry, which
+// comes from the config file with the specified name.
+func (r *configReader) readGroup(name string, entry *yaml.Node) error {
+	var group configGroup
+	if err := entry.Decode(&group); err != nil {
+		return configFileError(name, entry.Line, err)
+	}
+	if group.Group == "" {
+		return configFileError(name, entry.Line, errors.New("Group name cannot be empty"))
+	}
+	if group.Members.Kind != 0 && group.Members.Kind != yaml.SequenceNode {
+		return configFileError(name, group.Members.Line,
+			errors.New("Members must be a list"))
+	}
+
+	// The configs keep the order in which their address first
+	// appears, and collect the overrides of every member with it.
+	var addresses []string
+	overrides := map[string][]*groupMember{}
+	lines := map[string]int{}
+	for _, node := range group.Members.Content {
+		member := &groupMember{}
+		if err := node.Decode(member); err != nil {
+			return configFileError(name, node.Line, err)
+		}
+		expanded, err := expandGroupMember(member.Address)
+		if err != nil {
+			return configFileError(name, node.Line, err)
+		}
+		for _, address := range expanded {
+			if _, ok := overrides[address]; !ok {
+				addresses = append(addresses, address)
+				lines[address] = node.Line
+			}
+			overrides[address] = append(overrides[address], member)
+		}
+	}
+	for _, address := range addresses {
+		source := configSource{file: name, line: lines[address], group: group.Group}
+		if err := r.add(source, group.config(address, overrides[address])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandGroupMember returns the addresses of a group member, which
+// is an address or hostname, a range of addresses, written
+// first-last, or a CIDR prefix.
+func expandGroupMember(member string) ([]string, error) {
+	member = strings.TrimSpace(member)
+	if member == "" {
+		return nil, errors.New("group member address cannot be empty")
+	}
+	if prefix, err := netip.ParsePrefix(member); err == nil {
+		return expandPrefix(prefix.Masked())
+	}
+	if first, last, ok := strings.Cut(member, "-"); ok {
+		firstAddr, err1 := netip.ParseAddr(first)
+		lastAddr, err2 := netip.ParseAddr(last)
+		if err1 == nil && err2 == nil {
+			return expandRange(firstAddr, lastAddr)
+		}
+		// Hostnames can have dashes too.
+	}
+	if strings.ContainsAny(member, "/ ") {
+		return nil, fmt.Errorf("invalid group member '%s'", member)
+	}
+	if addr, err := netip.ParseAddr(member); err == nil {
+		return []string{addr.String()}, nil
+	}
+	return []string{member}, nil
+}
+
+func expandRange(first, last netip.Addr) ([]string, error) {
+	if first.Is4() != last.Is4() || last.Less(first) {
+		return nil, fmt.Errorf("invalid address range %s-%s", first, last)
+	}
+	var addresses []string
+	for addr := first; ; addr = addr.Next() {
+		if len(addresses) == maxGroupMemberAddresses {
+			return nil, fmt.Errorf("address range %s-%s has more than %d addresses",
+				first, last, maxGroupMemberAddresses)
+		}
+		addresses = append(addresses, addr.String())
+		if addr == last {
+			return addresses, nil
+		}
+	}
+}
+
+func expandPrefix(prefix netip.Prefix) ([]string, error) {
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits > 16 {
+		return nil, fmt.Errorf("prefix %s has more than %d addresses", prefix,
+			maxGroupMemberAddresses)
+	}
+	first := prefix.Addr()
+	last := first
+	for i := 1; i < 1<<hostBits; i++ {
+		last = last.Next()
+	}
+	// The network and broadcast addresses of IPv4 prefixes aren't
+	// devices, except in point-to-point prefixes.
+	if first.Is4() && hostBits > 1 {
+		first, last = first.Next(), last.Prev()
+	}
+	return expandRange(first, last)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandGroupMember(t *testing.T) {
+	for _, tc := range []struct {
+		member string
+		expect []string
+		err    string
+	}{
+		{member: "10.0.0.1", expect: []string{"10.0.0.1"}},
+		{member: "switch-1.example.com", expect: []string{"switch-1.example.com"}},
+		{member: "10.0.0.254-10.0.1.1",
+			expect: []string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"}},
+		{member: "10.0.0.5/30", expect: []string{"10.0.0.5", "10.0.0.6"}},
+		{member: "10.0.0.4/31", expect: []string{"10.0.0.4", "10.0.0.5"}},
+		{member: "10.0.0.4/32", expect: []string{"10.0.0.4"}},
+		{member: "2001:db8::/127", expect: []string{"2001:db8::", "2001:db8::1"}},
+		{member: "2001:db8::0001", expect: []string{"2001:db8::1"}},
+		{member: "10.0.0.0/8", err: "more than 65536 addresses"},
+		{member: "10.0.0.0-10.255.0.0", err: "more than 65536 addresses"},
+		{member: "10.0.0.2-10.0.0.1", err: "invalid address range"},
+		{member: "10.0.0.1-2001:db8::1", err: "invalid address range"},
+		{member: "10.0.0.0/33", err: "invalid group member"},
+		{member: "", err: "cannot be empty"},
+	} {
+		t.Run(tc.member, func(t *testing.T) {
+			addresses, err := expandGroupMember(tc.member)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(addresses, tc.expect) {
+				t.Fatalf("expected %v, got %v", tc.expect, addresses)
+			}
+		})
+	}
+}
+
+func TestReadConfigsGroups(t *testing.T) {
+	Register(typedTestDeviceInfo.name, typedTestDeviceInfo.creator,
+		typedTestDeviceInfo.options)
+	defer Unregister(typedTestDeviceInfo.name)
+
+	configs, err := readConfigsFromBytes([]byte(`
+- Name: single
+  Device: test
+- Group: core
+  Device: typed
+  Options:
+    count: 2
+    mode: fast
+  Credentials:
+    user: admin
+  Members:
+  - 10.0.0.1-10.0.0.3
+  - switch-1.example.com
+  - Address: 10.0.0.2
+    LogLevel: debug
+    Options:
+      mode: slow
+    Credentials:
+      password: secret
+- Group: edge
+  Device: test
+  AddressOption: host
+  NoStream: true
+  Members:
+  - Address: 10.1.0.1
+    NoStream: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := func(name, address, mode string) *Config {
+		return &Config{
+			Name:        name,
+			Device:      "typed",
+			Options:     map[string]string{"count": "2", "mode": mode, "address": address},
+			Credentials: map[string]string{"user": "admin"},
+		}
+	}
+	overridden := member("core-10.0.0.2", "10.0.0.2", "slow")
+	overridden.LogLevel = "debug"
+	overridden.Credentials["password"] = "secret"
+	expect := []*Config{
+		{Name: "single", Device: "test"},
+		member("core-10.0.0.1", "10.0.0.1", "fast"),
+		overridden,
+		member("core-10.0.0.3", "10.0.0.3", "fast"),
+		member("core-switch-1.example.com", "switch-1.example.com", "fast"),
+		{Name: "edge-10.1.0.1", Device: "test", Options: map[string]string{"host": "10.1.0.1"}},
+	}
+	if !reflect.DeepEqual(configs, expect) {
+		t.Fatalf("expected configs:\n%v\ngot:\n%v", expect, configs)
+	}
+
+	for _, tc := range []struct {
+		description string
+		input       string
+		err         string
+	}{{
+		description: "invalid member option",
+		input: `
+- Group: core
+  Device: typed
+  Members:
+  - 10.0.0.1
+  - Address: 10.0.0.2
+    Options:
+      count: 20
+`,
+		err: "config:6: Config 'core-10.0.0.2' for device 'typed'",
+	}, {
+		description: "clashing names",
+		input: `
+- Name: core-10.0.0.2
+  Device: test
+- Group: core
+  Device: test
+  Members:
+  - 10.0.0.0/30
+`,
+		err: "config:7: duplicate config name 'core-10.0.0.2', already defined at config:2",
+	}, {
+		description: "invalid member",
+		input: `
+- Group: core
+  Device: test
+  Members:
+  - 10.0.0.1
+  - 10.0.0.0/8
+`,
+		err: "config:6: prefix 10.0.0.0/8 has more than 65536 addresses",
+	}, {
+		description: "no group name",
+		input: `
+- Group: ""
+  Device: test
+`,
+		err: "config:2: Group name cannot be empty",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := readConfigsFromBytes([]byte(tc.input))
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("expected error starting with %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
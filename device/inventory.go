@@ -31,6 +31,56 @@ type Inventory interface {
 	SetStatus(key string, status ManagedDeviceStatus) error
 }
 
+// DeviceState is the runtime state of a device in an inventory.
+type DeviceState struct {
+	// LastHeartbeat is when a heartbeat was last sent for the device.
+	LastHeartbeat time.Time
+	// LastError is the last error returned by one of the device's
+	// providers.
+	LastError error
+}
+
+// InventoryEventType is the type of an InventoryEvent.
+type InventoryEventType int
+
+const (
+	// InventoryExisting events are sent for each device in an
+	// inventory when a watch starts.
+	InventoryExisting InventoryEventType = iota
+	// InventoryAdded events are sent when a device is added.
+	InventoryAdded
+	// InventoryUpdated events are sent when a device is replaced or
+	// its status changes, or when one of its providers fails.
+	InventoryUpdated
+	// InventoryDeleted events are sent when a device is deleted.
+	InventoryDeleted
+)
+
+// An InventoryEvent describes a change to a device in an inventory.
+type InventoryEvent struct {
+	Type InventoryEventType
+	// Info is a copy of the device's Info at the time of the event.
+	Info  *Info
+	State DeviceState
+}
+
+// An InventoryWatcher is an Inventory that tracks the state of its
+// devices and reports changes to them.
+type InventoryWatcher interface {
+	Inventory
+	// State returns the state of the device with the specified key.
+	State(key string) (DeviceState, error)
+	// Watch returns a channel of changes to the inventory, starting
+	// with an InventoryExisting event for each device in it. The
+	// channel is closed once ctx is done, or if the receiver falls
+	// too far behind.
+	Watch(ctx context.Context) <-chan InventoryEvent
+}
+
+// watchBufferSize is the number of events a watcher may fall behind
+// by before it's dropped.
+const watchBufferSize = 100
+
 // InventoryOption configures how we create the Inventory.
 type InventoryOption func(*inventory)
 
@@ -42,6 +92,14 @@ type deviceConn struct {
 	cvClient cvclient.CVClient
 	grpcConn *grpc.ClientConn
 	group    sync.WaitGroup
+	// pollGate lets the polls of the providers be drained on shutdown.
+	pollGate *provider.PollGate
+
+	inventory *inventory
+	// stateLock protects state and info.Status, which are read by
+	// watchers without holding the inventory lock.
+	stateLock sync.Mutex
+	state     DeviceState
 }
 
 // inventory implements the Inventory interface.
@@ -55,6 +113,10 @@ type inventory struct {
 	devices        map[string]*deviceConn
 	lock           sync.Mutex
 	clientFactory  func(gnmi.GNMIClient, *Info) cvclient.CVClient
+	shutDown       bool // set once the inventory is shut down
+
+	watchLock sync.Mutex
+	watchers  map[chan InventoryEvent]struct{}
 }
 
 func (dc *deviceConn) sendPeriodicUpdates() error {
@@ -80,6 +142,10 @@ func (dc *deviceConn) sendPeriodicUpdates() error {
 					// Don't give up if an update fails for some reason.
 					logger.Infof("Error sending periodic update for device %v: %v",
 						did, err)
+				} else {
+					dc.stateLock.Lock()
+					dc.state.LastHeartbeat = time.Now()
+					dc.stateLock.Unlock()
 				}
 			} else {
 				if !wasFailing {
@@ -91,10 +157,44 @@ func (dc *deviceConn) sendPeriodicUpdates() error {
 	}
 }
 
+// event returns an event of the specified type for the device.
+func (dc *deviceConn) event(typ InventoryEventType) InventoryEvent {
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+	info := *dc.info
+	return InventoryEvent{Type: typ, Info: &info, State: dc.state}
+}
+
+func (dc *deviceConn) setStatus(status ManagedDeviceStatus) {
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+	dc.info.Status = status
+}
+
+// providerFailed records an error returned by one of the device's
+// providers, unless the device was being stopped.
+func (dc *deviceConn) providerFailed(err error) {
+	if dc.ctx.Err() != nil {
+		return
+	}
+	dc.stateLock.Lock()
+	dc.state.LastError = err
+	dc.stateLock.Unlock()
+	dc.inventory.notify(InventoryUpdated, dc)
+}
+
+// close closes the device once its providers have exited.
+func (dc *deviceConn) close(ctx context.Context) {
+	if err := CloseDevice(ctx, dc.info.Device); err != nil {
+		log.Log(dc.info.Device).Errorf("Error closing device %s: %v", dc.info.ID, err)
+	}
+}
+
 func (i *inventory) newDeviceConn(info *Info) (*deviceConn, error) {
 	dc := &deviceConn{
-		cvClient: i.clientFactory(i.rawGNMIClient, info),
-		info:     info,
+		cvClient:  i.clientFactory(i.rawGNMIClient, info),
+		info:      info,
+		inventory: i,
 	}
 
 	// Take any metadata associated with the device context.
@@ -103,6 +203,7 @@ func (i *inventory) newDeviceConn(info *Info) (*deviceConn, error) {
 	} else {
 		dc.ctx, dc.cancel = context.WithCancel(i.ctx)
 	}
+	dc.ctx, dc.pollGate = provider.WithPollGate(dc.ctx)
 
 	// i.grpcConnector is set,
 	// only if grpcServerAddr is provided
@@ -152,6 +253,7 @@ func (dc *deviceConn) runProviders() error {
 			err := p.Run(dc.ctx)
 			if err != nil {
 				log.Log(p).Errorf("Provider exiting with error %v", err)
+				dc.providerFailed(err)
 			}
 			dc.group.Done()
 		}(p)
@@ -168,11 +270,19 @@ func (i *inventory) Add(info *Info) error {
 		return errors.New("ID in device.Info cannot be empty")
 	} else if info.Config == nil {
 		return errors.New("Config in device.Info cannot be empty")
+	} else if i.shutDown {
+		return errors.New("inventory is shut down")
 	}
+	event := InventoryAdded
 	if dev, ok := i.devices[info.ID]; ok {
+		event = InventoryUpdated
 		log.Log(info.Device).Debugf("Replacing device %s (type %s)",
 			info.ID, info.Config.Device)
 		dev.cancel()
+		if !sameDevice(dev.info.Device, info.Device) {
+			dev.group.Wait()
+			dev.close(i.ctx)
+		}
 		delete(i.devices, info.ID)
 	}
 
@@ -224,6 +334,7 @@ func (i *inventory) Add(info *Info) error {
 		}()
 	}
 
+	i.notify(event, dc)
 	log.Log(info.Device).Infof("Added device %q (%s)", info.ID,
 		info.Config.Device)
 	return nil
@@ -246,6 +357,7 @@ func (i *inventory) Delete(key string) error {
 	// has manage go routine closed too.
 	dc.cancel()
 	dc.group.Wait()
+	dc.close(i.ctx)
 
 	ctx := i.ctx
 	if dc.info.Context != nil {
@@ -253,13 +365,14 @@ func (i *inventory) Delete(key string) error {
 	}
 	// send metadata update for device as removed
 	// so that it can be removed from CloudVision.
-	dc.info.Status = StatusRemoved
+	dc.setStatus(StatusRemoved)
 	if err := dc.cvClient.SendDeviceMetadata(ctx); err != nil {
 		return fmt.Errorf("Error sending device metadata for device "+
 			"%q (%s): %w", key, dc.info.Status, err)
 	}
 
 	delete(i.devices, key)
+	i.notify(InventoryDeleted, dc)
 	log.Log(dc.info.Device).Infof("Deleted device %s", key)
 	return nil
 }
@@ -278,6 +391,8 @@ func (i *inventory) Get(key string) (*Info, error) {
 }
 
 func (i *inventory) List() []*Info {
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	var ret []*Info
 	for _, conn := range i.devices {
 		ret = append(ret, conn.info)
@@ -296,7 +411,8 @@ func (i *inventory) SetStatus(key string, status ManagedDeviceStatus) error {
 		return fmt.Errorf("device %s is not present in inventory", key)
 	}
 
-	dc.info.Status = status
+	dc.setStatus(status)
+	i.notify(InventoryUpdated, dc)
 	if err := dc.cvClient.SendDeviceMetadata(dc.ctx); err != nil {
 		return fmt.Errorf("error sending device metadata for device "+
 			"%s: %w", key, err)
@@ -304,6 +420,56 @@ func (i *inventory) SetStatus(key string, status ManagedDeviceStatus) error {
 	return nil
 }
 
+func (i *inventory) State(key string) (DeviceState, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	dc, ok := i.devices[key]
+	if !ok {
+		return DeviceState{}, fmt.Errorf("Device %s not found", key)
+	}
+	return dc.event(InventoryExisting).State, nil
+}
+
+func (i *inventory) Watch(ctx context.Context) <-chan InventoryEvent {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	ch := make(chan InventoryEvent, len(i.devices)+watchBufferSize)
+	for _, dc := range i.devices {
+		ch <- dc.event(InventoryExisting)
+	}
+	i.watchLock.Lock()
+	i.watchers[ch] = struct{}{}
+	i.watchLock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		i.watchLock.Lock()
+		defer i.watchLock.Unlock()
+		if _, ok := i.watchers[ch]; ok {
+			delete(i.watchers, ch)
+			close(ch)
+		}
+	}()
+	return ch
+}
+
+// notify sends an event for the device to all watchers.
+func (i *inventory) notify(typ InventoryEventType, dc *deviceConn) {
+	event := dc.event(typ)
+	i.watchLock.Lock()
+	defer i.watchLock.Unlock()
+	for ch := range i.watchers {
+		select {
+		case ch <- event:
+		default:
+			// Drop watchers that have fallen behind rather than
+			// block the inventory.
+			delete(i.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
 // WithGNMIClient sets a gNMI client on the Inventory.
 func WithGNMIClient(c gnmi.GNMIClient) InventoryOption {
 	return func(i *inventory) {
@@ -347,12 +513,14 @@ func WithClientFactory(
 	}
 }
 
-// NewInventoryWithOptions creates an Inventory with the supplied options.
+// NewInventoryWithOptions creates an Inventory with the supplied
+// options. The Inventory is also an InventoryWatcher.
 func NewInventoryWithOptions(ctx context.Context,
 	options ...InventoryOption) Inventory {
 	inv := &inventory{
-		ctx:     ctx,
-		devices: make(map[string]*deviceConn),
+		ctx:      ctx,
+		devices:  make(map[string]*deviceConn),
+		watchers: make(map[chan InventoryEvent]struct{}),
 	}
 	for _, opt := range options {
 		opt(inv)
@@ -6,11 +6,15 @@ package device
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/aristanetworks/cloudvision-go/device/cvclient"
 	v1client "github.com/aristanetworks/cloudvision-go/device/cvclient/v1"
+	"github.com/aristanetworks/cloudvision-go/provider"
 	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
 
 	"github.com/openconfig/gnmi/proto/gnmi"
@@ -143,3 +147,123 @@ func TestInventory(t *testing.T) {
 		})
 	}
 }
+
+// closeCounter counts calls to Close.
+type closeCounter struct {
+	mu     sync.Mutex
+	closes int
+}
+
+func (c *closeCounter) Close(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("Close called without a deadline")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closes++
+	return nil
+}
+
+func (c *closeCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closes
+}
+
+// closingProvider is a GNMIProvider that runs until canceled and
+// counts calls to Close, which it expects only once Run has returned.
+type closingProvider struct {
+	closeCounter
+	running atomic.Bool
+}
+
+func (p *closingProvider) InitGNMI(client gnmi.GNMIClient) {}
+func (p *closingProvider) OpenConfig() bool                { return true }
+func (p *closingProvider) Origin() string                  { return "" }
+
+func (p *closingProvider) Run(ctx context.Context) error {
+	p.running.Store(true)
+	defer p.running.Store(false)
+	<-ctx.Done()
+	return nil
+}
+
+func (p *closingProvider) Close(ctx context.Context) error {
+	if p.running.Load() {
+		return errors.New("Close called while provider is running")
+	}
+	return p.closeCounter.Close(ctx)
+}
+
+// closingDevice is a device with one provider, both of which count
+// calls to Close.
+type closingDevice struct {
+	testDevice
+	closeCounter
+	provider *closingProvider
+}
+
+func newClosingDevice(id string) *closingDevice {
+	return &closingDevice{
+		testDevice: testDevice{deviceID: id},
+		provider:   &closingProvider{},
+	}
+}
+
+func (d *closingDevice) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{d.provider}, nil
+}
+
+func (d *closingDevice) checkClosed(t *testing.T, expected int) {
+	t.Helper()
+	if n := d.count(); n != expected {
+		t.Errorf("device %s closed %d times, expected %d", d.deviceID, n, expected)
+	}
+	if n := d.provider.count(); n != expected {
+		t.Errorf("provider of device %s closed %d times, expected %d",
+			d.deviceID, n, expected)
+	}
+}
+
+func TestInventoryClose(t *testing.T) {
+	processor := func(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return nil, nil
+	}
+	inv := NewInventory(context.Background(), pgnmi.NewSimpleGNMIClient(processor),
+		func(gc gnmi.GNMIClient, i *Info) cvclient.CVClient {
+			return v1client.NewV1Client(gc, i.ID, false)
+		})
+	add := func(d *closingDevice) *Info {
+		info := &Info{Config: &Config{NoStream: true}, Device: d, ID: "dev"}
+		if err := inv.Add(info); err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+
+	first := newClosingDevice("dev")
+	info := add(first)
+	first.checkClosed(t, 0)
+
+	// Re-adding the same device doesn't close it.
+	if err := inv.Add(info); err != nil {
+		t.Fatal(err)
+	}
+	first.checkClosed(t, 0)
+
+	// Replacing it with a new instance does, once.
+	second := newClosingDevice("dev")
+	add(second)
+	first.checkClosed(t, 1)
+	second.checkClosed(t, 0)
+
+	if err := inv.Delete("dev"); err != nil {
+		t.Fatal(err)
+	}
+	// Deleting a device that's already gone doesn't close anything.
+	if err := inv.Delete("dev"); err != nil {
+		t.Fatal(err)
+	}
+	first.checkClosed(t, 1)
+	second.checkClosed(t, 1)
+}
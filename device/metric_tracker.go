@@ -22,6 +22,14 @@ type MetricTracker interface {
 	TrackDatasourceRestarts(ctx context.Context, typ string)
 }
 
+// A QuarantineTracker is a MetricTracker that also tracks quarantines
+// of crash looping datasources.
+type QuarantineTracker interface {
+	// TrackDatasourceQuarantines tracks how often datasources of a particular type are
+	// quarantined for crash looping. This metric is monotonically increasing
+	TrackDatasourceQuarantines(ctx context.Context, typ string)
+}
+
 // noopMetricTracker represents a no operation MetricTracker
 type noopMetricTracker struct {
 }
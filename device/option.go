@@ -13,12 +13,162 @@ import (
 	"time"
 )
 
+// OptionType is the type of value accepted by a device option.
+type OptionType int
+
+// Option types. An Option with no Type set accepts any string.
+const (
+	OptionTypeString OptionType = iota
+	OptionTypeInt
+	OptionTypeBool
+	OptionTypeDuration
+	// OptionTypeAddress accepts an IP address or a hostname.
+	OptionTypeAddress
+	OptionTypePort
+	// OptionTypeEnum accepts one of the Option's Values.
+	OptionTypeEnum
+	// OptionTypeList accepts a comma-separated list of strings. If
+	// the Option has Values, each element must be one of them.
+	OptionTypeList
+)
+
+var optionTypeNames = map[OptionType]string{
+	OptionTypeString:   "string",
+	OptionTypeInt:      "int",
+	OptionTypeBool:     "bool",
+	OptionTypeDuration: "duration",
+	OptionTypeAddress:  "address",
+	OptionTypePort:     "port",
+	OptionTypeEnum:     "enum",
+	OptionTypeList:     "list",
+}
+
+func (t OptionType) String() string {
+	if name, ok := optionTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("OptionType(%d)", int(t))
+}
+
 // Option defines a command-line option accepted by a device.
 type Option struct {
 	Description string
 	Default     string
 	Pattern     string
 	Required    bool
+
+	Type OptionType
+	// Min and Max bound the values of int, port and duration
+	// options. They are written the same way as the option value
+	// (for example "1s" for a duration) and are ignored if empty.
+	Min string
+	Max string
+	// Values lists the values accepted by an enum option, or by
+	// each element of a list option.
+	Values []string
+	// Secret marks an option, such as a password or key, whose
+	// value must not appear in logs or other output. Devices should
+	// read secret options with GetSecretOption.
+	Secret bool
+}
+
+// hostnameRegexp matches an RFC 1123 hostname.
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*` +
+	`[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// parseBound parses a value of an int, port or duration option into
+// an int64 so that it can be compared against the option's bounds.
+func (o Option) parseBound(v string) (int64, error) {
+	switch o.Type {
+	case OptionTypeInt:
+		return strconv.ParseInt(v, 10, 64)
+	case OptionTypePort:
+		p, err := strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("not a valid port number")
+		}
+		return int64(p), nil
+	case OptionTypeDuration:
+		d, err := time.ParseDuration(v)
+		return int64(d), err
+	}
+	return 0, nil
+}
+
+func (o Option) hasValue(v string) bool {
+	for _, val := range o.Values {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// checkValue checks that a non-empty option value is consistent
+// with the option's type, bounds and allowed values.
+func (o Option) checkValue(v string) error {
+	switch o.Type {
+	case OptionTypeString:
+	case OptionTypeBool:
+		if _, err := strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("is not a valid bool")
+		}
+	case OptionTypeInt, OptionTypePort, OptionTypeDuration:
+		n, err := o.parseBound(v)
+		if err != nil {
+			return fmt.Errorf("is not a valid %s: %v", o.Type, err)
+		}
+		if o.Min != "" {
+			min, err := o.parseBound(o.Min)
+			if err != nil {
+				return fmt.Errorf("cannot be checked against bad minimum '%s': %v",
+					o.Min, err)
+			}
+			if n < min {
+				return fmt.Errorf("is less than the minimum of %s", o.Min)
+			}
+		}
+		if o.Max != "" {
+			max, err := o.parseBound(o.Max)
+			if err != nil {
+				return fmt.Errorf("cannot be checked against bad maximum '%s': %v",
+					o.Max, err)
+			}
+			if n > max {
+				return fmt.Errorf("is greater than the maximum of %s", o.Max)
+			}
+		}
+	case OptionTypeAddress:
+		if net.ParseIP(v) == nil && !hostnameRegexp.MatchString(v) {
+			return fmt.Errorf("is not a valid IP address or hostname")
+		}
+	case OptionTypeEnum:
+		if !o.hasValue(v) {
+			return fmt.Errorf("is not one of %s", strings.Join(o.Values, ", "))
+		}
+	case OptionTypeList:
+		if len(o.Values) == 0 {
+			break
+		}
+		for _, e := range strings.Split(v, ",") {
+			if !o.hasValue(e) {
+				return fmt.Errorf("contains '%s', which is not one of %s",
+					e, strings.Join(o.Values, ", "))
+			}
+		}
+	default:
+		return fmt.Errorf("has unknown type %s", o.Type)
+	}
+	return nil
+}
+
+// printable returns an option value as it may be printed, which is
+// Redacted for secret options.
+func (o Option) printable(v string) string {
+	if o.Secret {
+		return Redacted
+	}
+	return v
 }
 
 // SanitizedOptions takes the map of device option keys and values
@@ -37,7 +187,7 @@ func SanitizedOptions(options map[string]Option,
 		}
 
 		// Check whether the user's string, if non-empty, matches the
-		// option's defined pattern.
+		// option's defined pattern and type.
 		if o.Pattern != "" && v != "" {
 			re, err := regexp.Compile(o.Pattern)
 			if err != nil {
@@ -47,7 +197,13 @@ func SanitizedOptions(options map[string]Option,
 			fs := re.FindString(v)
 			if fs != v {
 				return nil, fmt.Errorf("Value for option '%s' ('%s') does "+
-					"not match regular expression '%s'", k, v, o.Pattern)
+					"not match regular expression '%s'", k, o.printable(v), o.Pattern)
+			}
+		}
+		if v != "" {
+			if err := o.checkValue(v); err != nil {
+				return nil, fmt.Errorf("Value for option '%s' ('%s') %v",
+					k, o.printable(v), err)
 			}
 		}
 		sopt[k] = v
@@ -55,7 +211,7 @@ func SanitizedOptions(options map[string]Option,
 
 	// Check that all required options were specified, and fill in
 	// any others with defaults. Also check that the defaults are
-	// consistent with the provided patterns.
+	// consistent with the provided patterns and types.
 	for k, v := range options {
 		if v.Pattern != "" && v.Default != "" {
 			re, err := regexp.Compile(v.Pattern)
@@ -70,6 +226,12 @@ func SanitizedOptions(options map[string]Option,
 					v.Default, k, v.Pattern)
 			}
 		}
+		if v.Default != "" {
+			if err := v.checkValue(v.Default); err != nil {
+				return nil, fmt.Errorf("Default value ('%s') for option '%s' %v",
+					v.Default, k, err)
+			}
+		}
 
 		_, found := sopt[k]
 		if v.Required && !found {
@@ -83,15 +245,47 @@ func SanitizedOptions(options map[string]Option,
 	return sopt, nil
 }
 
+// typeDesc describes an option's type and constraints for help
+// output, or returns "" for an unconstrained string option.
+func (o Option) typeDesc() string {
+	var parts []string
+	switch o.Type {
+	case OptionTypeString:
+	case OptionTypeEnum:
+		parts = append(parts, "one of: "+strings.Join(o.Values, ", "))
+	case OptionTypeList:
+		parts = append(parts, "comma-separated list")
+		if len(o.Values) > 0 {
+			parts = append(parts, "each one of: "+strings.Join(o.Values, ", "))
+		}
+	default:
+		parts = append(parts, o.Type.String())
+	}
+	if o.Min != "" {
+		parts = append(parts, "min "+o.Min)
+	}
+	if o.Max != "" {
+		parts = append(parts, "max "+o.Max)
+	}
+	return strings.Join(parts, "; ")
+}
+
 // Create map of option key to description.
 func helpDesc(options map[string]Option) map[string]string {
 	hd := make(map[string]string)
 
 	for k, v := range options {
 		desc := v.Description
+		// Add the type and its constraints for typed options.
+		if td := v.typeDesc(); td != "" {
+			desc = desc + " [" + td + "]"
+		}
 		// Add default if there's a non-empty one.
 		if v.Default != "" {
-			desc = desc + " (default " + v.Default + ")"
+			desc = desc + " (default " + v.printable(v.Default) + ")"
+		}
+		if v.Secret {
+			desc = desc + " (secret)"
 		}
 		if v.Required {
 			k = k + " (required)"
@@ -191,6 +191,189 @@ func TestOptions(t *testing.T) {
 	runOptionsTests(t, testCases)
 }
 
+var typedTestDeviceInfo = registrationInfo{
+	name:    "typed",
+	creator: NewTestDevice,
+	options: map[string]Option{
+		"count":    {Type: OptionTypeInt, Min: "1", Max: "10", Default: "5"},
+		"enabled":  {Type: OptionTypeBool, Default: "true"},
+		"interval": {Type: OptionTypeDuration, Min: "1s", Max: "1h", Default: "20s"},
+		"address":  {Type: OptionTypeAddress},
+		"port":     {Type: OptionTypePort, Default: "161"},
+		"mode":     {Type: OptionTypeEnum, Values: []string{"fast", "slow"}},
+		"paths":    {Type: OptionTypeList},
+		"colors":   {Type: OptionTypeList, Values: []string{"red", "green", "blue"}},
+	},
+}
+
+func TestTypedOptions(t *testing.T) {
+	typedDefaults := map[string]string{
+		"count":    "5",
+		"enabled":  "true",
+		"interval": "20s",
+		"address":  "",
+		"port":     "161",
+		"mode":     "",
+		"paths":    "",
+		"colors":   "",
+	}
+	withDefaults := func(kv map[string]string) map[string]string {
+		m := map[string]string{}
+		for k, v := range typedDefaults {
+			m[k] = v
+		}
+		for k, v := range kv {
+			m[k] = v
+		}
+		return m
+	}
+	testCases := []optionsTestCase{
+		{
+			description:    "defaults",
+			devInfo:        typedTestDeviceInfo,
+			config:         map[string]string{},
+			expectedConfig: typedDefaults,
+			shouldPass:     true,
+		},
+		{
+			description: "sane typed options",
+			devInfo:     typedTestDeviceInfo,
+			config: map[string]string{
+				"count":    "10",
+				"enabled":  "false",
+				"interval": "1h",
+				"address":  "switch-1.example.com",
+				"port":     "1161",
+				"mode":     "slow",
+				"paths":    "/a,/b",
+				"colors":   "red,blue",
+			},
+			expectedConfig: map[string]string{
+				"count":    "10",
+				"enabled":  "false",
+				"interval": "1h",
+				"address":  "switch-1.example.com",
+				"port":     "1161",
+				"mode":     "slow",
+				"paths":    "/a,/b",
+				"colors":   "red,blue",
+			},
+			shouldPass: true,
+		},
+		{
+			description:    "IPv6 address",
+			devInfo:        typedTestDeviceInfo,
+			config:         map[string]string{"address": "2001:db8::1"},
+			expectedConfig: withDefaults(map[string]string{"address": "2001:db8::1"}),
+			shouldPass:     true,
+		},
+		{
+			description: "bad int",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"count": "five"},
+			expectedError: errors.New("Value for option 'count' ('five') is not a " +
+				"valid int: strconv.ParseInt: parsing \"five\": invalid syntax"),
+		},
+		{
+			description: "int below minimum",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"count": "0"},
+			expectedError: errors.New("Value for option 'count' ('0') is less " +
+				"than the minimum of 1"),
+		},
+		{
+			description: "int above maximum",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"count": "11"},
+			expectedError: errors.New("Value for option 'count' ('11') is greater " +
+				"than the maximum of 10"),
+		},
+		{
+			description: "bad bool",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"enabled": "yes please"},
+			expectedError: errors.New("Value for option 'enabled' ('yes please') " +
+				"is not a valid bool"),
+		},
+		{
+			description: "duration without unit",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"interval": "20"},
+			expectedError: errors.New("Value for option 'interval' ('20') is not a " +
+				"valid duration: time: missing unit in duration \"20\""),
+		},
+		{
+			description: "duration above maximum",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"interval": "2h"},
+			expectedError: errors.New("Value for option 'interval' ('2h') is greater " +
+				"than the maximum of 1h"),
+		},
+		{
+			description: "bad address",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"address": "1.1.1.1:161"},
+			expectedError: errors.New("Value for option 'address' ('1.1.1.1:161') " +
+				"is not a valid IP address or hostname"),
+		},
+		{
+			description: "bad port",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"port": "65536"},
+			expectedError: errors.New("Value for option 'port' ('65536') is not a " +
+				"valid port: not a valid port number"),
+		},
+		{
+			description: "bad enum",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"mode": "medium"},
+			expectedError: errors.New("Value for option 'mode' ('medium') is not " +
+				"one of fast, slow"),
+		},
+		{
+			description: "bad list element",
+			devInfo:     typedTestDeviceInfo,
+			config:      map[string]string{"colors": "red,purple"},
+			expectedError: errors.New("Value for option 'colors' ('red,purple') " +
+				"contains 'purple', which is not one of red, green, blue"),
+		},
+		{
+			description: "default inconsistent with type",
+			devInfo: registrationInfo{
+				name:    "typed2",
+				creator: NewTestDevice,
+				options: map[string]Option{
+					"interval": {Type: OptionTypeDuration, Default: "20"},
+				},
+			},
+			config: map[string]string{},
+			expectedError: errors.New("Default value ('20') for option 'interval' " +
+				"is not a valid duration: time: missing unit in duration \"20\""),
+		},
+	}
+	runOptionsTests(t, testCases)
+}
+
+func TestHelpDesc(t *testing.T) {
+	hd := helpDesc(map[string]Option{
+		"plain": {Description: "a string", Default: "x"},
+		"interval": {Description: "poll interval", Type: OptionTypeDuration,
+			Min: "1s", Default: "20s"},
+		"mode": {Description: "mode", Type: OptionTypeEnum,
+			Values: []string{"fast", "slow"}, Required: true},
+		"paths": {Description: "paths", Type: OptionTypeList},
+	})
+	expected := map[string]string{
+		"plain":           "a string (default x)",
+		"interval":        "poll interval [duration; min 1s] (default 20s)",
+		"mode (required)": "mode [one of: fast, slow]",
+		"paths":           "paths [comma-separated list]",
+	}
+	if !reflect.DeepEqual(hd, expected) {
+		t.Fatalf("expected help %v, got %v", expected, hd)
+	}
+}
+
 func stringSliceEqual(s1, s2 []string) bool {
 	if len(s1) != len(s2) {
 		return false
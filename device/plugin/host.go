@@ -0,0 +1,394 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/gen"
+	"github.com/aristanetworks/cloudvision-go/provider"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// startTimeout bounds how long a plugin is given to start
+	// serving.
+	startTimeout = 10 * time.Second
+	// stopTimeout bounds how long a plugin executable is given to
+	// exit before it's killed.
+	stopTimeout = 5 * time.Second
+)
+
+// A stopFunc stops a plugin instance.
+type stopFunc func(ctx context.Context) error
+
+// A launcher starts plugin instances.
+type launcher interface {
+	// launch starts a plugin instance, returning a connection to it
+	// and a function stopping it. Output from the instance is logged
+	// to logger.
+	launch(ctx context.Context, logger provider.Logger) (*grpc.ClientConn, stopFunc, error)
+}
+
+func dial(ctx context.Context, socket string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, socketScheme+socket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		// A plugin that was just launched may take a moment to
+		// listen, so retry quickly at first.
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  10 * time.Millisecond,
+				Multiplier: 1.6,
+				MaxDelay:   time.Second,
+			},
+		}))
+}
+
+// socket connects to a plugin that's already running.
+type socket struct {
+	path string
+}
+
+func (s socket) launch(ctx context.Context,
+	logger provider.Logger) (*grpc.ClientConn, stopFunc, error) {
+	conn, err := dial(ctx, s.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to plugin socket %s: %v", s.path, err)
+	}
+	return conn, func(ctx context.Context) error { return conn.Close() }, nil
+}
+
+// executable starts a new plugin process for each instance.
+type executable struct {
+	path string
+}
+
+func (e executable) launch(ctx context.Context,
+	logger provider.Logger) (*grpc.ClientConn, stopFunc, error) {
+	// The plugin removes its socket when it exits, even if the
+	// Collector doesn't get to stop it.
+	f, err := os.CreateTemp("", "cvplugin-*.sock")
+	if err != nil {
+		return nil, nil, err
+	}
+	socket := f.Name()
+	f.Close()
+	os.Remove(socket)
+
+	name := filepath.Base(e.path)
+	output := &logWriter{log: func(line string) { logger.Infof("%s: %s", name, line) }}
+	cmd := exec.Command(e.path)
+	cmd.Env = append(os.Environ(), SocketEnv+"="+socket, watchStdinEnv+"=1")
+	cmd.Stdout = output
+	cmd.Stderr = output
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start plugin %s: %v", e.path, err)
+	}
+
+	var stopping atomic.Bool
+	exited := make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		if !stopping.Load() {
+			logger.Errorf("Plugin %s (pid %d) exited unexpectedly: %v",
+				e.path, cmd.Process.Pid, err)
+		}
+		close(exited)
+	}()
+
+	var conn *grpc.ClientConn
+	// stop asks the plugin to exit by closing its standard input,
+	// and kills it if it hasn't by the time ctx is done.
+	stop := func(ctx context.Context) error {
+		stopping.Store(true)
+		if conn != nil {
+			conn.Close()
+		}
+		stdin.Close()
+		var err error
+		select {
+		case <-exited:
+		case <-ctx.Done():
+			err = fmt.Errorf("plugin %s (pid %d) did not exit, killing it",
+				e.path, cmd.Process.Pid)
+			cmd.Process.Kill()
+			<-exited
+		}
+		os.Remove(socket)
+		return err
+	}
+
+	// Don't wait for the plugin to start serving if it has exited.
+	dialCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-exited:
+			cancel()
+		case <-dialCtx.Done():
+		}
+	}()
+	conn, err = dial(dialCtx, socket)
+	if err != nil {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), stopTimeout)
+		defer stopCancel()
+		stop(stopCtx)
+		return nil, nil, fmt.Errorf("plugin %s failed to start serving: %v", e.path, err)
+	}
+	return conn, stop, nil
+}
+
+// logWriter passes each line written to it to log.
+type logWriter struct {
+	log  func(line string)
+	lock sync.Mutex
+	buf  []byte
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.log(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Load loads the plugin at location, which is either the path of a
+// plugin executable or unix://<path> of the socket a running plugin
+// listens on, and registers the device type it serves. It returns
+// the name of that device type.
+func Load(ctx context.Context, location string) (string, error) {
+	var l launcher
+	if path, ok := strings.CutPrefix(location, socketScheme); ok {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		l = socket{path: path}
+	} else {
+		path, err := exec.LookPath(location)
+		if err != nil {
+			return "", fmt.Errorf("failed to find plugin %s: %v", location, err)
+		}
+		l = executable{path: path}
+	}
+
+	logger := logrus.WithField("plugin", location)
+	conn, stop, err := l.launch(ctx, logger)
+	if err != nil {
+		return "", err
+	}
+	defer stopPlugin(stop, logger)
+	resp, err := gen.NewDevicePluginClient(conn).Describe(ctx, &gen.DescribeRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe plugin %s: %v", location, err)
+	}
+	if resp.Name == "" {
+		return "", fmt.Errorf("plugin %s has no device type name", location)
+	}
+	for _, name := range device.Registered() {
+		if name == resp.Name {
+			return "", fmt.Errorf("plugin %s: device type '%s' is already registered",
+				location, resp.Name)
+		}
+	}
+
+	p := &plugin{location: location, launcher: l}
+	device.Register(resp.Name, p.create, optionsFromProto(resp.Options))
+	logger.Infof("Registered plugin device type '%s'", resp.Name)
+	return resp.Name, nil
+}
+
+func stopPlugin(stop stopFunc, logger provider.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	if err := stop(ctx); err != nil {
+		logger.Errorf("Error stopping plugin: %v", err)
+	}
+}
+
+// deviceError converts an error returned by a plugin back into the
+// kind of error its device returned.
+func deviceError(err error) error {
+	if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+		return device.NewBadConfigError(errors.New(s.Message()))
+	}
+	return err
+}
+
+// plugin is a loaded plugin.
+type plugin struct {
+	location string
+	launcher launcher
+}
+
+func (p *plugin) create(ctx context.Context, options map[string]string,
+	monitor provider.Monitor) (device.Device, error) {
+	var logger provider.Logger = logrus.WithField("plugin", p.location)
+	if monitor != nil {
+		logger = monitor
+	}
+	conn, stop, err := p.launcher.launch(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	client := gen.NewDevicePluginClient(conn)
+	resp, err := client.Create(ctx, &gen.CreateRequest{Options: options})
+	if err != nil {
+		stopPlugin(stop, logger)
+		return nil, deviceError(err)
+	}
+
+	d := &pluginDevice{
+		client: client,
+		handle: resp.Handle,
+		typ:    resp.Type,
+		stop:   stop,
+	}
+	for i, pp := range resp.Providers {
+		d.providers = append(d.providers, &pluginProvider{
+			device:     d,
+			index:      uint32(i),
+			openConfig: pp.OpenConfig,
+			origin:     pp.Origin,
+		})
+	}
+	return d, nil
+}
+
+// pluginDevice is a device implemented by a plugin instance.
+type pluginDevice struct {
+	client    gen.DevicePluginClient
+	handle    string
+	typ       string
+	providers []provider.Provider
+	stop      stopFunc
+}
+
+func (d *pluginDevice) request() *gen.DeviceRequest {
+	return &gen.DeviceRequest{Handle: d.handle}
+}
+
+func (d *pluginDevice) Alive(ctx context.Context) (bool, error) {
+	resp, err := d.client.Alive(ctx, d.request())
+	if err != nil {
+		return false, deviceError(err)
+	}
+	return resp.Alive, nil
+}
+
+func (d *pluginDevice) DeviceID(ctx context.Context) (string, error) {
+	resp, err := d.client.DeviceID(ctx, d.request())
+	if err != nil {
+		return "", deviceError(err)
+	}
+	return resp.DeviceID, nil
+}
+
+func (d *pluginDevice) Providers() ([]provider.Provider, error) {
+	return d.providers, nil
+}
+
+func (d *pluginDevice) Type() string {
+	return d.typ
+}
+
+func (d *pluginDevice) IPAddr(ctx context.Context) (string, error) {
+	resp, err := d.client.IPAddr(ctx, d.request())
+	if err != nil {
+		return "", deviceError(err)
+	}
+	return resp.IpAddr, nil
+}
+
+// Close releases the device in the plugin and stops the plugin
+// instance.
+func (d *pluginDevice) Close(ctx context.Context) error {
+	_, err := d.client.Close(ctx, d.request())
+	return errors.Join(err, d.stop(ctx))
+}
+
+// pluginProvider streams the updates of a provider running in a
+// plugin instance.
+type pluginProvider struct {
+	device     *pluginDevice
+	index      uint32
+	openConfig bool
+	origin     string
+	client     gnmi.GNMIClient
+}
+
+func (p *pluginProvider) InitGNMI(client gnmi.GNMIClient) {
+	p.client = client
+}
+
+func (p *pluginProvider) OpenConfig() bool {
+	return p.openConfig
+}
+
+func (p *pluginProvider) Origin() string {
+	return p.origin
+}
+
+func (p *pluginProvider) Run(ctx context.Context) error {
+	if p.client == nil {
+		return fmt.Errorf("provider is uninitialized")
+	}
+	stream, err := p.device.client.RunProvider(ctx, &gen.RunProviderRequest{
+		Handle:   p.device.handle,
+		Provider: p.index,
+	})
+	if err != nil {
+		return deviceError(err)
+	}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("plugin provider failed: %w", deviceError(err))
+		}
+		if _, err := p.client.Set(ctx, req); err != nil {
+			return err
+		}
+	}
+}
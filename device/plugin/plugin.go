@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package plugin implements device types in separate processes.
+//
+// A plugin is an executable that serves a single device type over the
+// DevicePlugin gRPC service defined in device/plugin.proto, usually by
+// calling Serve from its main function. The Collector loads plugins
+// with Load, which registers their device types alongside the
+// compiled-in ones. Each device instance then runs in its own plugin
+// process, so that a plugin crash only fails the datasource using it.
+// A plugin may instead be started independently, listening on a Unix
+// socket, in which case all its devices share that process.
+package plugin
+
+import (
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/gen"
+)
+
+// SocketEnv is the environment variable holding the path of the Unix
+// socket a plugin listens on.
+const SocketEnv = "CLOUDVISION_PLUGIN_SOCKET"
+
+// watchStdinEnv is set by the Collector when it launches a plugin
+// executable, which should then exit once its standard input is
+// closed, as it is when the Collector exits.
+const watchStdinEnv = "CLOUDVISION_PLUGIN_WATCH_STDIN"
+
+// socketScheme prefixes plugin locations that are sockets rather
+// than executables.
+const socketScheme = "unix://"
+
+func optionsToProto(options map[string]device.Option) map[string]*gen.PluginOption {
+	pb := make(map[string]*gen.PluginOption, len(options))
+	for k, o := range options {
+		pb[k] = &gen.PluginOption{
+			Description: o.Description,
+			Default:     o.Default,
+			Pattern:     o.Pattern,
+			Required:    o.Required,
+			Type:        gen.PluginOption_Type(o.Type),
+			Min:         o.Min,
+			Max:         o.Max,
+			Values:      o.Values,
+			Secret:      o.Secret,
+		}
+	}
+	return pb
+}
+
+func optionsFromProto(pb map[string]*gen.PluginOption) map[string]device.Option {
+	options := make(map[string]device.Option, len(pb))
+	for k, o := range pb {
+		options[k] = device.Option{
+			Description: o.Description,
+			Default:     o.Default,
+			Pattern:     o.Pattern,
+			Required:    o.Required,
+			Type:        device.OptionType(o.Type),
+			Min:         o.Min,
+			Max:         o.Max,
+			Values:      o.Values,
+			Secret:      o.Secret,
+		}
+	}
+	return options
+}
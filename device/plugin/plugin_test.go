@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package plugin_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/plugin"
+	"github.com/aristanetworks/cloudvision-go/device/plugin/plugintest"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const testDeviceType = "plugintest"
+
+var testOptions = map[string]device.Option{
+	"id": {
+		Description: "device ID",
+		Required:    true,
+	},
+	"fail": {
+		Description: "how the provider fails after its first update",
+		Type:        device.OptionTypeEnum,
+		Values:      []string{"panic", "exit"},
+	},
+}
+
+type testDevice struct {
+	id       string
+	provider *testProvider
+}
+
+func (d *testDevice) Alive(ctx context.Context) (bool, error) { return true, nil }
+
+func (d *testDevice) DeviceID(ctx context.Context) (string, error) { return d.id, nil }
+
+func (d *testDevice) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{d.provider}, nil
+}
+
+func (d *testDevice) Type() string { return "" }
+
+func (d *testDevice) IPAddr(ctx context.Context) (string, error) { return "", nil }
+
+// openDevices counts the test devices that haven't been closed.
+var openDevices atomic.Int32
+
+func (d *testDevice) Close(ctx context.Context) error {
+	openDevices.Add(-1)
+	return nil
+}
+
+type testProvider struct {
+	client gnmi.GNMIClient
+	id     string
+	fail   string
+}
+
+func (p *testProvider) InitGNMI(client gnmi.GNMIClient) { p.client = client }
+func (p *testProvider) OpenConfig() bool                { return true }
+func (p *testProvider) Origin() string                  { return "openconfig" }
+
+func (p *testProvider) Run(ctx context.Context) error {
+	if _, err := p.client.Set(ctx, &gnmi.SetRequest{
+		Update: []*gnmi.Update{pgnmi.Update(pgnmi.Path("system", "state", "hostname"),
+			pgnmi.Strval(p.id))},
+	}); err != nil {
+		return err
+	}
+	switch p.fail {
+	case "panic":
+		panic("test provider panic")
+	case "exit":
+		os.Exit(1)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newTestDevice(ctx context.Context, options map[string]string,
+	monitor provider.Monitor) (device.Device, error) {
+	if options["id"] == "bad" {
+		return nil, device.NewBadConfigErrorf("bad device ID")
+	}
+	openDevices.Add(1)
+	return &testDevice{
+		id:       options["id"],
+		provider: &testProvider{id: options["id"], fail: options["fail"]},
+	}, nil
+}
+
+// TestMain runs the test plugin when the test binary is launched as
+// one.
+func TestMain(m *testing.M) {
+	if os.Getenv(plugin.SocketEnv) != "" {
+		if err := plugin.Serve(testDeviceType, newTestDevice, testOptions); err != nil {
+			os.Exit(2)
+		}
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func waitForExit(t *testing.T, d *plugintest.Device) error {
+	t.Helper()
+	select {
+	case <-d.Done():
+		return d.Err()
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for providers to exit")
+		return nil
+	}
+}
+
+func TestSocketPlugin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := plugin.NewServer(testDeviceType, newTestDevice, testOptions)
+	go s.Serve(listener)
+	defer s.Stop()
+
+	h := plugintest.New(t, "unix://"+path)
+	if h.Type != testDeviceType {
+		t.Fatalf("expected device type %s, got %s", testDeviceType, h.Type)
+	}
+	help, err := device.OptionHelp(testDeviceType)
+	if err != nil || !strings.Contains(help["fail"], "one of: panic, exit") {
+		t.Errorf("unexpected option help %v (%v)", help, err)
+	}
+
+	// Options are sanitized before they reach the plugin, and bad
+	// config errors from the plugin are reported as such.
+	if _, err := h.Create(map[string]string{"fail": "explode"}); err == nil {
+		t.Error("expected error for bad option value")
+	}
+	if _, err := h.Create(map[string]string{"id": "bad"}); !device.IsBadConfigError(err) {
+		t.Errorf("expected bad config error, got %v", err)
+	}
+
+	// A panicking provider only fails its own device.
+	panicking := h.Start(map[string]string{"id": "dev1", "fail": "panic"})
+	if err := waitForExit(t, panicking); err == nil ||
+		!strings.Contains(err.Error(), "panicked") {
+		t.Errorf("expected provider panic, got %v", err)
+	}
+	panicking.Stop()
+
+	d := h.Start(map[string]string{"id": "dev2"})
+	if d.Info.ID != "dev2" {
+		t.Errorf("expected device ID dev2, got %s", d.Info.ID)
+	}
+	d.WaitForUpdate("/system/state/hostname", 10*time.Second)
+	if err := d.Stop(); err != nil {
+		t.Error(err)
+	}
+
+	if n := openDevices.Load(); n != 0 {
+		t.Errorf("%d devices weren't closed", n)
+	}
+}
+
+func TestExecutablePlugin(t *testing.T) {
+	h := plugintest.New(t, os.Args[0])
+
+	// Plugin types can't be registered twice.
+	if _, err := plugin.Load(context.Background(), os.Args[0]); err == nil ||
+		!strings.Contains(err.Error(), "already registered") {
+		t.Errorf("expected error loading plugin twice, got %v", err)
+	}
+
+	// Each device runs in its own process, so one crashing doesn't
+	// affect the others.
+	d1 := h.Start(map[string]string{"id": "dev1"})
+	crashing := h.Start(map[string]string{"id": "dev2", "fail": "exit"})
+	if err := waitForExit(t, crashing); err == nil {
+		t.Error("expected error from crashed plugin")
+	}
+	crashing.Stop()
+
+	d1.WaitForUpdate("/system/state/hostname", 10*time.Second)
+	select {
+	case <-d1.Done():
+		t.Fatalf("device exited: %v", d1.Err())
+	default:
+	}
+	if alive, err := d1.Info.Device.Alive(context.Background()); !alive || err != nil {
+		t.Errorf("expected device to be alive, got %v (%v)", alive, err)
+	}
+	if err := d1.Stop(); err != nil {
+		t.Error(err)
+	}
+}
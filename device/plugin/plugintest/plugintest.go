@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package plugintest provides a harness for testing device plugins.
+//
+// A plugin's tests can run the plugin from its own test binary:
+//
+//	func TestMain(m *testing.M) {
+//		if os.Getenv(plugin.SocketEnv) != "" {
+//			main()
+//			return
+//		}
+//		os.Exit(m.Run())
+//	}
+//
+//	func TestPlugin(t *testing.T) {
+//		h := plugintest.New(t, os.Args[0])
+//		d := h.Start(map[string]string{"address": "10.0.0.1"})
+//		defer d.Stop()
+//		d.WaitForUpdate("/system/state/hostname", 5*time.Second)
+//	}
+package plugintest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/plugin"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	pm "github.com/aristanetworks/cloudvision-go/provider/mock"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"golang.org/x/sync/errgroup"
+)
+
+// A Harness loads a plugin and runs its devices the way the
+// Collector does, recording the updates their providers stream.
+type Harness struct {
+	t testing.TB
+	// Type is the device type served by the plugin.
+	Type string
+}
+
+// New loads the plugin at location, as plugin.Load does, and
+// registers its device type until the test finishes.
+func New(t testing.TB, location string) *Harness {
+	t.Helper()
+	name, err := plugin.Load(context.Background(), location)
+	if err != nil {
+		t.Fatalf("failed to load plugin %s: %v", location, err)
+	}
+	t.Cleanup(func() { device.Unregister(name) })
+	return &Harness{t: t, Type: name}
+}
+
+// Create creates a device of the plugin's type with the specified
+// options, without running it. The device must be closed by the
+// caller if it implements device.Closer.
+func (h *Harness) Create(options map[string]string) (*device.Info, error) {
+	return device.NewDeviceInfo(context.Background(), &device.Config{
+		Device:  h.Type,
+		Options: options,
+	}, pm.NewMockMonitor())
+}
+
+// Start creates a device with the specified options and runs its
+// providers.
+func (h *Harness) Start(options map[string]string) *Device {
+	h.t.Helper()
+	info, err := h.Create(options)
+	if err != nil {
+		h.t.Fatalf("failed to create device: %v", err)
+	}
+	providers, err := info.Device.Providers()
+	if err != nil {
+		h.t.Fatalf("failed to get providers: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Device{
+		Info:    info,
+		t:       h.t,
+		cancel:  cancel,
+		updated: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	client := pgnmi.NewSimpleGNMIClient(d.set)
+	group, ctx := errgroup.WithContext(ctx)
+	for _, p := range providers {
+		gp, ok := p.(provider.GNMIProvider)
+		if !ok {
+			h.t.Fatalf("unexpected provider type %T", p)
+		}
+		gp.InitGNMI(client)
+		group.Go(func() error { return gp.Run(ctx) })
+	}
+	go func() {
+		d.err = group.Wait()
+		close(d.done)
+	}()
+	return d
+}
+
+// A Device is a plugin device whose providers are running.
+type Device struct {
+	Info *device.Info
+
+	t      testing.TB
+	cancel context.CancelFunc
+
+	lock     sync.Mutex
+	requests []*gnmi.SetRequest
+	// updated is closed and replaced with each SetRequest.
+	updated chan struct{}
+
+	done chan struct{}
+	err  error
+}
+
+func (d *Device) set(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.requests = append(d.requests, req)
+	close(d.updated)
+	d.updated = make(chan struct{})
+	return &gnmi.SetResponse{}, nil
+}
+
+// Requests returns the SetRequests streamed by the device's providers
+// so far.
+func (d *Device) Requests() []*gnmi.SetRequest {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return append([]*gnmi.SetRequest(nil), d.requests...)
+}
+
+// latest returns the latest value streamed for path, or nil if
+// there's none, along with a channel closed by the next update.
+func (d *Device) latest(path string) (*gnmi.TypedValue, <-chan struct{}) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var val *gnmi.TypedValue
+	for _, req := range d.requests {
+		for _, u := range append(req.Replace, req.Update...) {
+			if agnmi.StrPath(agnmi.JoinPaths(req.Prefix, u.Path)) == path {
+				val = u.Val
+			}
+		}
+	}
+	return val, d.updated
+}
+
+// WaitForUpdate waits for the device's providers to update path, such
+// as "/system/state/hostname", and returns its latest value. The
+// test fails if that takes longer than timeout or if the providers
+// exit first.
+func (d *Device) WaitForUpdate(path string, timeout time.Duration) *gnmi.TypedValue {
+	d.t.Helper()
+	deadline := time.After(timeout)
+	for {
+		val, updated := d.latest(path)
+		if val != nil {
+			return val
+		}
+		select {
+		case <-updated:
+		case <-d.done:
+			d.t.Fatalf("providers exited before updating %s: %v", path, d.err)
+		case <-deadline:
+			d.t.Fatalf("timed out waiting for an update to %s", path)
+		}
+	}
+}
+
+// Done returns a channel that's closed once the device's providers
+// have all exited.
+func (d *Device) Done() <-chan struct{} {
+	return d.done
+}
+
+// Err returns the error the device's providers exited with, once
+// Done is closed.
+func (d *Device) Err() error {
+	<-d.done
+	return d.err
+}
+
+// Stop stops the device's providers and closes the device, returning
+// the error the providers exited with, if any, other than that of
+// being stopped.
+func (d *Device) Stop() error {
+	d.cancel()
+	<-d.done
+	err := d.err
+	if err == context.Canceled {
+		err = nil
+	}
+	if c, ok := d.Info.Device.(device.Closer); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if cerr := c.Close(ctx); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// The sample command is an example device plugin. Its device reports
+// a configured hostname and the current time as OpenConfig system
+// state. Build it and load it into the Collector with
+//
+//	Collector -plugin ./sample -device sample -deviceoption hostname=foo ...
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/plugin"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+)
+
+var options = map[string]device.Option{
+	"hostname": {
+		Description: "Hostname reported by the device, also used as its ID",
+		Default:     "sample",
+	},
+	"address": {
+		Description: "Management address reported by the device",
+		Type:        device.OptionTypeAddress,
+	},
+	"interval": {
+		Description: "Interval between updates of the current time",
+		Default:     "10s",
+		Type:        device.OptionTypeDuration,
+		Min:         "10ms",
+	},
+}
+
+type sample struct {
+	hostname string
+	address  string
+	provider *sampleProvider
+}
+
+func (s *sample) Alive(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (s *sample) DeviceID(ctx context.Context) (string, error) {
+	return s.hostname, nil
+}
+
+func (s *sample) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{s.provider}, nil
+}
+
+func (s *sample) Type() string {
+	return ""
+}
+
+func (s *sample) IPAddr(ctx context.Context) (string, error) {
+	return s.address, nil
+}
+
+type sampleProvider struct {
+	client   gnmi.GNMIClient
+	hostname string
+	interval time.Duration
+	monitor  provider.Monitor
+}
+
+func (p *sampleProvider) InitGNMI(client gnmi.GNMIClient) {
+	p.client = client
+}
+
+func (p *sampleProvider) OpenConfig() bool {
+	return true
+}
+
+func (p *sampleProvider) Origin() string {
+	return "openconfig"
+}
+
+func systemStatePath(leafName string) *gnmi.Path {
+	return pgnmi.Path("system", "state", leafName)
+}
+
+func (p *sampleProvider) Run(ctx context.Context) error {
+	bootTime := time.Now()
+	if _, err := p.client.Set(ctx, &gnmi.SetRequest{
+		Update: []*gnmi.Update{
+			pgnmi.Update(systemStatePath("hostname"), pgnmi.Strval(p.hostname)),
+			pgnmi.Update(systemStatePath("boot-time"), pgnmi.Intval(bootTime.UnixNano())),
+		},
+	}); err != nil {
+		return err
+	}
+	p.monitor.Infof("Started sample device %s", p.hostname)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.client.Set(ctx, &gnmi.SetRequest{
+			Update: []*gnmi.Update{
+				pgnmi.Update(systemStatePath("current-datetime"),
+					pgnmi.Strval(time.Now().Format(time.RFC3339))),
+			},
+		}); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func newSample(ctx context.Context, options map[string]string,
+	monitor provider.Monitor) (device.Device, error) {
+	hostname, err := device.GetStringOption("hostname", options)
+	if err != nil {
+		return nil, err
+	}
+	address, err := device.GetStringOption("address", options)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := device.GetDurationOption("interval", options)
+	if err != nil {
+		return nil, err
+	}
+	return &sample{
+		hostname: hostname,
+		address:  address,
+		provider: &sampleProvider{
+			hostname: hostname,
+			interval: interval,
+			monitor:  monitor,
+		},
+	}, nil
+}
+
+func main() {
+	if err := plugin.Serve("sample", newSample, options); err != nil {
+		logrus.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/plugin"
+	"github.com/aristanetworks/cloudvision-go/device/plugin/plugintest"
+)
+
+// TestMain runs the plugin when the test binary is launched as one.
+func TestMain(m *testing.M) {
+	if os.Getenv(plugin.SocketEnv) != "" {
+		main()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func TestSample(t *testing.T) {
+	h := plugintest.New(t, os.Args[0])
+
+	// Options are checked against those the plugin registered.
+	if _, err := h.Create(map[string]string{"interval": "1ms"}); err == nil {
+		t.Fatal("expected error for interval below minimum")
+	}
+
+	d := h.Start(map[string]string{
+		"hostname": "sample1",
+		"address":  "10.0.0.1",
+		"interval": "10ms",
+	})
+	defer func() {
+		if err := d.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if d.Info.ID != "sample1" {
+		t.Errorf("expected device ID sample1, got %s", d.Info.ID)
+	}
+	if ip, err := d.Info.Device.IPAddr(context.Background()); err != nil || ip != "10.0.0.1" {
+		t.Errorf("expected IP address 10.0.0.1, got %q (%v)", ip, err)
+	}
+	if _, ok := d.Info.Device.(device.Closer); !ok {
+		t.Error("plugin device isn't a Closer")
+	}
+	hostname := d.WaitForUpdate("/system/state/hostname", 10*time.Second)
+	if v := string(hostname.GetJsonVal()); v != `"sample1"` {
+		t.Errorf("expected hostname sample1, got %s", v)
+	}
+	d.WaitForUpdate("/system/state/current-datetime", 10*time.Second)
+}
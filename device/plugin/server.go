@@ -0,0 +1,316 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/aristanetworks/cloudvision-go/device"
+	"github.com/aristanetworks/cloudvision-go/device/gen"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Serve serves a device type as a plugin on the Unix socket named by
+// SocketEnv, until the Collector that launched the plugin exits. The
+// device type is defined by the same name, creator and options that
+// device.Register takes. Its devices may only have GNMIProviders.
+func Serve(name string, creator device.Creator, options map[string]device.Option) error {
+	path := os.Getenv(SocketEnv)
+	if path == "" {
+		return fmt.Errorf("%s is not set", SocketEnv)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	s := NewServer(name, creator, options)
+	if os.Getenv(watchStdinEnv) != "" {
+		go func() {
+			_, _ = io.Copy(io.Discard, os.Stdin)
+			s.Stop()
+		}()
+	}
+	return s.Serve(listener)
+}
+
+// A Server serves a device type over the DevicePlugin service.
+type Server struct {
+	gen.UnimplementedDevicePluginServer
+
+	name    string
+	creator device.Creator
+	options map[string]device.Option
+
+	grpcServer *grpc.Server
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	lock       sync.Mutex
+	devices    map[string]*serverDevice
+	nextHandle uint64
+}
+
+// serverDevice is a device created by a Server.
+type serverDevice struct {
+	device    device.Device
+	providers []provider.GNMIProvider
+}
+
+// NewServer returns a Server for the specified device type.
+func NewServer(name string, creator device.Creator,
+	options map[string]device.Option) *Server {
+	s := &Server{
+		name:       name,
+		creator:    creator,
+		options:    options,
+		grpcServer: grpc.NewServer(),
+		devices:    map[string]*serverDevice{},
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	gen.RegisterDevicePluginServer(s.grpcServer, s)
+	return s
+}
+
+// Serve accepts connections on listener until Stop is called.
+func (s *Server) Serve(listener net.Listener) error {
+	err := s.grpcServer.Serve(listener)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
+
+// Stop stops the server and closes any devices it still holds.
+func (s *Server) Stop() {
+	s.grpcServer.Stop()
+	s.cancel()
+
+	s.lock.Lock()
+	devices := s.devices
+	s.devices = map[string]*serverDevice{}
+	s.lock.Unlock()
+	for handle, d := range devices {
+		ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+		if err := d.close(ctx); err != nil {
+			logrus.Errorf("Error closing device %s: %v", handle, err)
+		}
+		cancel()
+	}
+}
+
+func statusError(err error) error {
+	if device.IsBadConfigError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (s *Server) device(handle string) (*serverDevice, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	d, ok := s.devices[handle]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no device with handle '%s'", handle)
+	}
+	return d, nil
+}
+
+// Describe implements gen.DevicePluginServer.
+func (s *Server) Describe(ctx context.Context,
+	req *gen.DescribeRequest) (*gen.DescribeResponse, error) {
+	return &gen.DescribeResponse{
+		Name:    s.name,
+		Options: optionsToProto(s.options),
+	}, nil
+}
+
+// Create implements gen.DevicePluginServer.
+func (s *Server) Create(ctx context.Context,
+	req *gen.CreateRequest) (*gen.CreateResponse, error) {
+	options, err := device.SanitizedOptions(s.options, req.Options)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.lock.Lock()
+	s.nextHandle++
+	handle := strconv.FormatUint(s.nextHandle, 10)
+	s.lock.Unlock()
+
+	// The device outlives this request, so it's created with the
+	// server's context.
+	monitor := &logMonitor{Entry: logrus.WithField("device", handle)}
+	dev, err := s.creator(s.ctx, options, monitor)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	d := &serverDevice{device: dev}
+	resp := &gen.CreateResponse{Handle: handle, Type: dev.Type()}
+	providers, err := dev.Providers()
+	if err == nil {
+		for _, p := range providers {
+			gp, ok := p.(provider.GNMIProvider)
+			if !ok {
+				err = status.Errorf(codes.Unimplemented,
+					"unsupported provider %T: plugins only support gNMI providers", p)
+				break
+			}
+			d.providers = append(d.providers, gp)
+			resp.Providers = append(resp.Providers, &gen.PluginProvider{
+				OpenConfig: gp.OpenConfig(),
+				Origin:     gp.Origin(),
+			})
+		}
+	}
+	if err != nil {
+		if cerr := d.close(ctx); cerr != nil {
+			logrus.Errorf("Error closing device %s: %v", handle, cerr)
+		}
+		return nil, statusError(err)
+	}
+
+	s.lock.Lock()
+	s.devices[handle] = d
+	s.lock.Unlock()
+	return resp, nil
+}
+
+// Alive implements gen.DevicePluginServer.
+func (s *Server) Alive(ctx context.Context,
+	req *gen.DeviceRequest) (*gen.AliveResponse, error) {
+	d, err := s.device(req.Handle)
+	if err != nil {
+		return nil, err
+	}
+	alive, err := d.device.Alive(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &gen.AliveResponse{Alive: alive}, nil
+}
+
+// DeviceID implements gen.DevicePluginServer.
+func (s *Server) DeviceID(ctx context.Context,
+	req *gen.DeviceRequest) (*gen.DeviceIDResponse, error) {
+	d, err := s.device(req.Handle)
+	if err != nil {
+		return nil, err
+	}
+	id, err := d.device.DeviceID(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &gen.DeviceIDResponse{DeviceID: id}, nil
+}
+
+// IPAddr implements gen.DevicePluginServer.
+func (s *Server) IPAddr(ctx context.Context,
+	req *gen.DeviceRequest) (*gen.IPAddrResponse, error) {
+	d, err := s.device(req.Handle)
+	if err != nil {
+		return nil, err
+	}
+	ip, err := d.device.IPAddr(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &gen.IPAddrResponse{IpAddr: ip}, nil
+}
+
+// RunProvider implements gen.DevicePluginServer.
+func (s *Server) RunProvider(req *gen.RunProviderRequest,
+	stream gen.DevicePlugin_RunProviderServer) error {
+	d, err := s.device(req.Handle)
+	if err != nil {
+		return err
+	}
+	if int(req.Provider) >= len(d.providers) {
+		return status.Errorf(codes.NotFound, "device %s has no provider %d",
+			req.Handle, req.Provider)
+	}
+	p := d.providers[req.Provider]
+
+	// Providers may issue SetRequests concurrently, but a stream
+	// can only send one message at a time.
+	var sendLock sync.Mutex
+	p.InitGNMI(pgnmi.NewSimpleGNMIClient(
+		func(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+			sendLock.Lock()
+			defer sendLock.Unlock()
+			if err := stream.Send(req); err != nil {
+				return nil, err
+			}
+			return &gnmi.SetResponse{}, nil
+		}))
+	return runProvider(stream.Context(), p)
+}
+
+// runProvider runs p, turning a panic into an error so that it only
+// fails the device's datasource.
+func runProvider(ctx context.Context, p provider.Provider) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("provider panicked: %v", r)
+		}
+	}()
+	return p.Run(ctx)
+}
+
+// Close implements gen.DevicePluginServer.
+func (s *Server) Close(ctx context.Context,
+	req *gen.DeviceRequest) (*gen.CloseResponse, error) {
+	s.lock.Lock()
+	d, ok := s.devices[req.Handle]
+	delete(s.devices, req.Handle)
+	s.lock.Unlock()
+	if !ok {
+		return &gen.CloseResponse{}, nil
+	}
+	return &gen.CloseResponse{}, d.close(ctx)
+}
+
+// close closes the device's providers and then the device itself,
+// for those that implement the Closer interfaces.
+func (d *serverDevice) close(ctx context.Context) error {
+	var errs []error
+	for _, p := range d.providers {
+		if c, ok := p.(provider.Closer); ok {
+			errs = append(errs, c.Close(ctx))
+		}
+	}
+	if c, ok := d.device.(device.Closer); ok {
+		errs = append(errs, c.Close(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// logMonitor is the provider.Monitor given to plugin devices. It logs
+// to the plugin's output, which the Collector forwards to the log of
+// the device's datasource, and drops metrics.
+type logMonitor struct {
+	*logrus.Entry
+}
+
+func (m *logMonitor) SetMetricString(name string, value string) error { return nil }
+func (m *logMonitor) SetMetricFloat(name string, value float64) error { return nil }
+func (m *logMonitor) SetMetricInt(name string, value int64) error     { return nil }
+func (m *logMonitor) IncMetricInt(name string, value int64) error     { return nil }
+func (m *logMonitor) CreateMetric(name string, valueUnit string, description string) error {
+	return nil
+}
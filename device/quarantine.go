@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	quarantinedKey      = pgnmi.Path("quarantined")
+	quarantinedUntilKey = pgnmi.Path("quarantined-until")
+	failureHistoryKey   = pgnmi.Path("failure-history")
+)
+
+// datasourceFailure is an unexpected stop of a datasource.
+type datasourceFailure struct {
+	time time.Time
+	err  string
+}
+
+func (f datasourceFailure) String() string {
+	return fmt.Sprintf("%s: %s", f.time.UTC().Format(time.RFC3339), f.err)
+}
+
+// recordFailure records an unexpected stop of the datasource after a
+// run of the specified duration, and returns whether the datasource is
+// quarantined, and whether it just was. Datasources are quarantined
+// once crashLoopThreshold failures happen within crashLoopWindow. A
+// run lasting longer than crashLoopWindow releases the datasource from
+// quarantine and forgets its earlier failures.
+func (d *datasource) recordFailure(ctx context.Context, err error,
+	ran time.Duration) (quarantined, entered bool) {
+	if d.crashLoopThreshold <= 0 {
+		return false, false
+	}
+	if ran >= d.crashLoopWindow {
+		d.releaseQuarantine(ctx, fmt.Sprintf("ran for %v", ran.Round(time.Second)))
+	}
+	now := time.Now()
+	d.stateLock.Lock()
+	defer d.stateLock.Unlock()
+	d.failures = append(d.failures, datasourceFailure{time: now, err: err.Error()})
+	if d.quarantined {
+		// Keep the failures that led to the quarantine, along with
+		// the latest ones, as its history.
+		if n := len(d.failures) - d.crashLoopThreshold; n > 0 {
+			d.failures = d.failures[n:]
+		}
+		return true, false
+	}
+	start := now.Add(-d.crashLoopWindow)
+	n := 0
+	for n < len(d.failures) && d.failures[n].time.Before(start) {
+		n++
+	}
+	d.failures = d.failures[n:]
+	if len(d.failures) < d.crashLoopThreshold {
+		return false, false
+	}
+	d.quarantined = true
+	return true, true
+}
+
+// publishQuarantine publishes the quarantined state of the datasource
+// along with its failure history.
+func (d *datasource) publishQuarantine(ctx context.Context, until time.Time, entered bool) {
+	if entered {
+		d.log.Errorf("Data source is crash looping, quarantining it until %v",
+			until.Format(time.RFC3339))
+		if qt, ok := d.metricTracker.(QuarantineTracker); ok {
+			qt.TrackDatasourceQuarantines(ctx, d.config.typ)
+		}
+	}
+	d.stateLock.Lock()
+	history := make([]interface{}, len(d.failures))
+	for i, f := range d.failures {
+		history[i] = f.String()
+	}
+	d.stateLock.Unlock()
+	if err := d.submitDatasourceUpdates(ctx,
+		pgnmi.Update(quarantinedKey, agnmi.TypedValue(true)),
+		pgnmi.Update(quarantinedUntilKey, agnmi.TypedValue(until.UnixNano())),
+		pgnmi.Update(failureHistoryKey, agnmi.TypedValue(history)),
+	); err != nil {
+		d.log.Errorf("Failed to publish quarantine state: %v", err)
+	}
+}
+
+// releaseQuarantine takes the datasource out of quarantine, if it's
+// in it, and forgets its failures.
+func (d *datasource) releaseQuarantine(ctx context.Context, reason string) {
+	d.stateLock.Lock()
+	quarantined := d.quarantined
+	d.quarantined = false
+	d.failures = nil
+	d.stateLock.Unlock()
+	if !quarantined {
+		return
+	}
+	// Start over with the base failure backoff.
+	d.failureRetryTimer.Reset()
+	message := "Data source released from quarantine: " + reason
+	d.log.Info(message)
+	if _, err := d.gnmic.Set(ctx, &gnmi.SetRequest{
+		Prefix: d.statePrefix,
+		Delete: []*gnmi.Path{quarantinedUntilKey, failureHistoryKey},
+		Update: []*gnmi.Update{
+			pgnmi.Update(quarantinedKey, agnmi.TypedValue(false)),
+			pgnmi.Update(lastErrorKey, agnmi.TypedValue(message)),
+		},
+	}); err != nil {
+		d.log.Errorf("Failed to publish quarantine release: %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/device/internal"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+)
+
+// quarantineTracker counts quarantines.
+type quarantineTracker struct {
+	noopMetricTracker
+	quarantines atomic.Int32
+}
+
+func (q *quarantineTracker) TrackDatasourceQuarantines(ctx context.Context, typ string) {
+	q.quarantines.Add(1)
+}
+
+const quarantineTestDevice = "dev1"
+
+// runQuarantineTestSensor runs a sensor with a failing datasource,
+// standing in for the main loop of Sensor.Run until ctx is done.
+func runQuarantineTestSensor(ctx context.Context, t *testing.T,
+	opts ...SensorOption) (*Sensor, *internal.MockClient, *quarantineTracker) {
+	const deviceName = quarantineTestDevice
+	const deviceType = "quarantinetest"
+	Register(deviceType, newServiceTestDevice, map[string]Option{
+		"id":   {Description: "device ID", Required: true},
+		"fail": {Description: "provider error"},
+	})
+	t.Cleanup(func() { Unregister(deviceType) })
+
+	gnmic := &internal.MockClient{
+		SubscribeStream: make(chan *internal.MockClientStream),
+		SetReq:          make(chan *gnmi.SetRequest, 1000),
+		SetResp:         make(chan *gnmi.SetResponse),
+	}
+	close(gnmic.SetResp)
+	tracker := &quarantineTracker{}
+	sensor := NewSensor("default", 100.0, append([]SensorOption{WithSensorClientFactory(
+		func(gc gnmi.GNMIClient, info *Info) cvclient.CVClient {
+			return newMockCVClient(gnmic, info, make(chan string, 100))
+		},
+	), WithSensorGNMIClient(gnmic),
+		WithSensorFailureRetryBackoffBase(10 * time.Millisecond),
+		WithMetricTracker(tracker)}, opts...)...)
+	sensor.clockSynced = true
+	sensor.datasourceConfig[deviceName] = &datasourceConfig{
+		name:       deviceName,
+		typ:        deviceType,
+		enabled:    true,
+		option:     map[string]string{"id": "1", "fail": "boom"},
+		credential: map[string]string{},
+		loglevel:   logrus.InfoLevel,
+	}
+
+	go func() {
+		for {
+			select {
+			case request := <-sensor.adminRequests:
+				request(ctx)
+			case name := <-sensor.redeployDatasource:
+				if err := sensor.runDatasourceConfig(ctx, name); err != nil {
+					sensor.log.Errorf("redeploy failed: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sensor, gnmic, tracker
+}
+
+// removeQuarantineTestDatasource removes the datasource of a sensor
+// run by runQuarantineTestSensor.
+func removeQuarantineTestDatasource(ctx context.Context, t *testing.T, sensor *Sensor) {
+	if err := sensor.do(ctx, func(ctx context.Context) error {
+		sensor.removeDatasource(ctx, quarantineTestDevice)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatasourceQuarantine(t *testing.T) {
+	const deviceName = quarantineTestDevice
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sensor, gnmic, tracker := runQuarantineTestSensor(ctx, t,
+		WithSensorCrashLoopDetection(3, time.Minute),
+		WithSensorQuarantineBackoff(time.Hour))
+
+	// waitForQuarantine waits for the datasource to be quarantined
+	// for the nth time and returns the quarantine state it published.
+	waitForQuarantine := func(n int32) *gnmi.SetRequest {
+		t.Helper()
+		deadline := time.After(10 * time.Second)
+		for {
+			select {
+			case req := <-gnmic.SetReq:
+				for _, upd := range req.Update {
+					if pgnmi.PathMatch(upd.Path, quarantinedUntilKey) &&
+						tracker.quarantines.Load() == n {
+						return req
+					}
+				}
+			case <-deadline:
+				t.Fatalf("timed out waiting for quarantine %d", n)
+			}
+		}
+	}
+	// waitForRelease waits for the datasource to be released from
+	// quarantine for the specified reason.
+	waitForRelease := func(reason string) {
+		t.Helper()
+		deadline := time.After(10 * time.Second)
+		for {
+			select {
+			case req := <-gnmic.SetReq:
+				for _, upd := range req.Update {
+					if pgnmi.PathMatch(upd.Path, lastErrorKey) && upd.Val.GetStringVal() ==
+						"Data source released from quarantine: "+reason {
+						if len(req.Delete) != 2 {
+							t.Errorf("expected quarantine state to be deleted in %v", req)
+						}
+						return
+					}
+				}
+			case <-deadline:
+				t.Fatalf("timed out waiting for release (%s)", reason)
+			}
+		}
+	}
+
+	if err := sensor.RestartDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	req := waitForQuarantine(1)
+	for _, upd := range req.Update {
+		if pgnmi.PathMatch(upd.Path, failureHistoryKey) {
+			if n := len(upd.Val.GetLeaflistVal().GetElement()); n != 3 {
+				t.Errorf("expected 3 failures in history, got %d", n)
+			}
+		}
+	}
+	status, err := sensor.Datasource(ctx, deviceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Quarantined || status.NextRestart == nil ||
+		time.Until(*status.NextRestart) < 30*time.Minute {
+		t.Errorf("expected quarantined datasource to restart in an hour, got %+v", status)
+	}
+
+	// Admin restarts release datasources from quarantine.
+	if err := sensor.RestartDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	waitForRelease("restarted locally")
+	waitForQuarantine(2)
+
+	// So do config changes.
+	if err := sensor.do(ctx, func(ctx context.Context) error {
+		sensor.datasourceConfig[deviceName].option["fail"] = "bang"
+		return sensor.runDatasourceConfig(ctx, deviceName)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waitForRelease("config changed")
+	waitForQuarantine(3)
+
+	removeQuarantineTestDatasource(ctx, t, sensor)
+}
+
+// An ordinary outage, with datasources failing over and over, isn't
+// quarantined by default.
+func TestDatasourceOutageNotQuarantined(t *testing.T) {
+	const deviceName = quarantineTestDevice
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sensor, gnmic, tracker := runQuarantineTestSensor(ctx, t,
+		WithSensorFailureRetryBackoffMax(10*time.Millisecond))
+
+	if err := sensor.RestartDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.After(10 * time.Second)
+	for failures := 0; failures < 10; {
+		select {
+		case req := <-gnmic.SetReq:
+			for _, upd := range req.Update {
+				if pgnmi.PathMatch(upd.Path, quarantinedKey) {
+					t.Fatalf("unexpected quarantine in %v", req)
+				}
+				if pgnmi.PathMatch(upd.Path, lastErrorKey) && strings.HasPrefix(
+					upd.Val.GetStringVal(), "Datasource stopped unexpectedly") {
+					failures++
+				}
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for failures")
+		}
+	}
+	status, err := sensor.Datasource(ctx, deviceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Quarantined || tracker.quarantines.Load() != 0 {
+		t.Errorf("expected datasource not to be quarantined, got %+v", status)
+	}
+	removeQuarantineTestDatasource(ctx, t, sensor)
+}
+
+// A run outlasting the crash-loop window releases the datasource from
+// quarantine, and isn't counted as a crash-loop failure.
+func TestDatasourceQuarantineReleasedByLongRun(t *testing.T) {
+	gnmic := &internal.MockClient{
+		SetReq:  make(chan *gnmi.SetRequest, 10),
+		SetResp: make(chan *gnmi.SetResponse),
+	}
+	close(gnmic.SetResp)
+	d := &datasource{
+		log:                logrus.WithField("test", t.Name()),
+		gnmic:              gnmic,
+		failureRetryTimer:  provider.NewBackoffTimer(provider.WithBackoffBase(time.Second)),
+		crashLoopThreshold: 2,
+		crashLoopWindow:    time.Minute,
+		config:             &datasourceConfig{},
+	}
+	ctx := context.Background()
+	boom := errors.New("boom")
+	if q, _ := d.recordFailure(ctx, boom, time.Second); q {
+		t.Fatal("unexpected quarantine after one failure")
+	}
+	if q, entered := d.recordFailure(ctx, boom, time.Second); !q || !entered {
+		t.Fatal("expected quarantine after two short runs")
+	}
+	if q, _ := d.recordFailure(ctx, boom, time.Hour); q {
+		t.Fatal("expected long run to release quarantine")
+	}
+	if d.quarantined || len(d.failures) != 1 {
+		t.Errorf("expected quarantine and earlier failures to be cleared, got %v, %v",
+			d.quarantined, d.failures)
+	}
+	select {
+	case req := <-gnmic.SetReq:
+		if len(req.Delete) != 2 {
+			t.Errorf("expected quarantine state to be deleted in %v", req)
+		}
+	default:
+		t.Error("expected quarantine release to be published")
+	}
+	d.failures[0].time = d.failures[0].time.Add(-time.Hour)
+	if q, _ := d.recordFailure(ctx, boom, time.Second); q {
+		t.Error("unexpected quarantine after failures were cleared")
+	}
+}
+
+// Only failures within the crash-loop window count towards quarantine,
+// however short the runs they end.
+func TestDatasourceQuarantineWindow(t *testing.T) {
+	d := &datasource{
+		log:                logrus.WithField("test", t.Name()),
+		crashLoopThreshold: 3,
+		crashLoopWindow:    time.Minute,
+		config:             &datasourceConfig{},
+	}
+	ctx := context.Background()
+	boom := errors.New("boom")
+	// An outage failing fast every few minutes is never quarantined.
+	for i := 0; i < 10; i++ {
+		if q, _ := d.recordFailure(ctx, boom, time.Second); q {
+			t.Fatalf("unexpected quarantine after failure %d", i)
+		}
+		for j := range d.failures {
+			d.failures[j].time = d.failures[j].time.Add(-2 * time.Minute)
+		}
+	}
+	if len(d.failures) != 1 {
+		t.Errorf("expected failures outside of the window to be forgotten, got %v",
+			d.failures)
+	}
+	// A crash loop is.
+	d.recordFailure(ctx, boom, time.Second)
+	if q, entered := d.recordFailure(ctx, boom, time.Second); q || entered {
+		t.Fatal("unexpected quarantine after two failures within the window")
+	}
+	if q, entered := d.recordFailure(ctx, boom, time.Second); !q || !entered {
+		t.Fatal("expected quarantine after three failures within the window")
+	}
+	// Its history is kept while it's quarantined.
+	for j := range d.failures {
+		d.failures[j].time = d.failures[j].time.Add(-time.Hour)
+	}
+	if q, entered := d.recordFailure(ctx, boom, time.Second); !q || entered {
+		t.Fatal("expected datasource to stay quarantined")
+	}
+	if len(d.failures) != 3 {
+		t.Errorf("expected 3 failures in history, got %v", d.failures)
+	}
+}
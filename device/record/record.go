@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package record implements recordings of the SetRequests made by
+// devices, and their replay to a gNMI server. A recording is a
+// sequence of gen.Records, each preceded by its size as a varint.
+package record
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/gen"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// New returns the Record of req, made by device deviceID at time t
+// with ctx, whose outgoing gRPC metadata is recorded along with it.
+func New(ctx context.Context, t time.Time, deviceID string,
+	req *gnmi.SetRequest) *gen.Record {
+	rec := &gen.Record{
+		Timestamp: t.UnixNano(),
+		DeviceID:  deviceID,
+		Origin:    req.GetPrefix().GetOrigin(),
+		Target:    req.GetPrefix().GetTarget(),
+		Request:   req,
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rec.Metadata = append(rec.Metadata, &gen.Metadata{Key: k, Values: md[k]})
+	}
+	return rec
+}
+
+// outgoingContext returns ctx with the outgoing gRPC metadata of rec
+// added.
+func outgoingContext(ctx context.Context, rec *gen.Record) context.Context {
+	var kv []string
+	for _, md := range rec.Metadata {
+		for _, v := range md.Values {
+			kv = append(kv, md.Key, v)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// A Writer writes a recording. It's safe for concurrent use.
+type Writer struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+// NewWriter returns a Writer writing a recording to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write appends rec to the recording.
+func (w *Writer) Write(rec *gen.Record) error {
+	b, err := proto.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	b = append(binary.AppendUvarint(nil, uint64(len(b))), b...)
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, err := w.w.Write(b); err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+// A Reader reads a recording.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a Reader reading a recording from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next Record of the recording, or io.EOF at its end.
+func (r *Reader) Read() (*gen.Record, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	// SetRequests aren't limited in size.
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	rec := &gen.Record{}
+	if err := proto.Unmarshal(b, rec); err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	return rec, nil
+}
+
+// Replay sends the SetRequests of the recording read by r to client,
+// with the outgoing gRPC metadata they were made with, spaced as they
+// were recorded divided by speed: at 1, they're sent at their original
+// pace, at 2, twice as fast. If speed is 0, they're sent as fast as
+// possible. It returns the number of SetRequests sent.
+func Replay(ctx context.Context, r *Reader, client gnmi.GNMIClient,
+	speed float64) (int, error) {
+	if speed < 0 {
+		return 0, fmt.Errorf("invalid replay speed %v", speed)
+	}
+	var first int64
+	var start time.Time
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+	for n := 0; ; n++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if n == 0 {
+			first, start = rec.Timestamp, time.Now()
+		} else if speed > 0 {
+			offset := time.Duration(float64(rec.Timestamp-first) / speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				timer.Reset(wait)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					return n, ctx.Err()
+				}
+			}
+		}
+		if _, err := client.Set(outgoingContext(ctx, rec), rec.Request); err != nil {
+			return n, fmt.Errorf("failed to replay SetRequest of device %s made at %v: %w",
+				rec.DeviceID, time.Unix(0, rec.Timestamp), err)
+		}
+	}
+}
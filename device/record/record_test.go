@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package record
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	v1client "github.com/aristanetworks/cloudvision-go/device/cvclient/v1"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func testRecording(t *testing.T, start time.Time, interval time.Duration,
+	n int) (*bytes.Buffer, []*gnmi.SetRequest) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	var reqs []*gnmi.SetRequest
+	for i := 0; i < n; i++ {
+		req := &gnmi.SetRequest{
+			Prefix: &gnmi.Path{Origin: "openconfig", Target: "dev1"},
+			Update: []*gnmi.Update{pgnmi.Update(pgnmi.Path("counter"),
+				agnmi.TypedValue(i))},
+		}
+		reqs = append(reqs, req)
+		if err := w.Write(New(context.Background(),
+			start.Add(time.Duration(i)*interval), "dev1", req)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &buf, reqs
+}
+
+func TestRoundTrip(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	buf, reqs := testRecording(t, start, time.Second, 3)
+	r := NewReader(buf)
+	for i, req := range reqs {
+		rec, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rec.Timestamp != start.Add(time.Duration(i)*time.Second).UnixNano() {
+			t.Errorf("unexpected timestamp %d for record %d", rec.Timestamp, i)
+		}
+		if rec.DeviceID != "dev1" || rec.Origin != "openconfig" || rec.Target != "dev1" {
+			t.Errorf("unexpected record %v", rec)
+		}
+		if !proto.Equal(rec.Request, req) {
+			t.Errorf("expected request %v, got %v", req, rec.Request)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	buf, _ := testRecording(t, time.Now(), time.Second, 1)
+	r := NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if _, err := r.Read(); err == nil || err == io.EOF {
+		t.Errorf("expected error reading truncated record, got %v", err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	for name, tc := range map[string]struct {
+		speed    float64
+		min, max time.Duration
+	}{
+		"max speed": {speed: 0, max: 200 * time.Millisecond},
+		"scaled":    {speed: 10, min: 200 * time.Millisecond, max: 2 * time.Second},
+	} {
+		t.Run(name, func(t *testing.T) {
+			buf, reqs := testRecording(t, time.Now().Add(-time.Hour), time.Second, 3)
+			var sent []*gnmi.SetRequest
+			client := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+				req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+				sent = append(sent, req)
+				return &gnmi.SetResponse{}, nil
+			})
+			start := time.Now()
+			n, err := Replay(context.Background(), NewReader(buf), client, tc.speed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The 2s between the first and last record take 2s/speed.
+			if elapsed := time.Since(start); elapsed < tc.min || elapsed > tc.max {
+				t.Errorf("replay took %v, expected between %v and %v", elapsed,
+					tc.min, tc.max)
+			}
+			if n != len(reqs) || len(sent) != len(reqs) {
+				t.Fatalf("expected %d requests replayed, got %d", len(reqs), n)
+			}
+			for i := range reqs {
+				if !proto.Equal(sent[i], reqs[i]) {
+					t.Errorf("expected request %v, got %v", reqs[i], sent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReplayCanceled(t *testing.T) {
+	buf, _ := testRecording(t, time.Now(), time.Hour, 2)
+	client := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return &gnmi.SetResponse{}, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	n, err := Replay(ctx, NewReader(buf), client, 1)
+	if err != context.DeadlineExceeded || n != 1 {
+		t.Errorf("expected replay to stop after 1 request, got %d requests and %v", n, err)
+	}
+}
+
+func TestReplayV1Metadata(t *testing.T) {
+	// A v1 client describes its device only through the outgoing
+	// metadata of its SetRequests, so it must be replayed with them.
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	var recorded []metadata.MD
+	recorder := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		recorded = append(recorded, md)
+		return &gnmi.SetResponse{}, w.Write(New(ctx, time.Now(), "dev1", req))
+	})
+	ctx := context.Background()
+	c := v1client.NewV1Client(recorder, "dev1", false)
+	if err := c.SendHeartbeat(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Set(ctx, &gnmi.SetRequest{
+		Update: []*gnmi.Update{pgnmi.Update(pgnmi.Path("counter"), agnmi.TypedValue(1))},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var replayed []metadata.MD
+	client := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		replayed = append(replayed, md)
+		return &gnmi.SetResponse{}, nil
+	})
+	if _, err := Replay(ctx, NewReader(&buf), client, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != 2 || recorded[0].Get("deviceID")[0] != "dev1" {
+		t.Fatalf("unexpected recorded metadata %v", recorded)
+	}
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("expected replayed metadata %v, got %v", recorded, replayed)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonSchema is a JSON Schema (draft-07) or subschema.
+type jsonSchema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is a JSON type name or a list of them.
+	Type      interface{}   `json:"type,omitempty"`
+	Const     interface{}   `json:"const,omitempty"`
+	Enum      []interface{} `json:"enum,omitempty"`
+	Default   interface{}   `json:"default,omitempty"`
+	Pattern   string        `json:"pattern,omitempty"`
+	Format    string        `json:"format,omitempty"`
+	MinLength int           `json:"minLength,omitempty"`
+	Minimum   *int64        `json:"minimum,omitempty"`
+	Maximum   *int64        `json:"maximum,omitempty"`
+
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	// AdditionalProperties is a bool or a schema.
+	AdditionalProperties interface{}   `json:"additionalProperties,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	Items                *jsonSchema   `json:"items,omitempty"`
+	MinItems             int           `json:"minItems,omitempty"`
+	OneOf                []*jsonSchema `json:"oneOf,omitempty"`
+	AnyOf                []*jsonSchema `json:"anyOf,omitempty"`
+	AllOf                []*jsonSchema `json:"allOf,omitempty"`
+
+	Definitions map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+// scalarType is the type of option values, which can be written as
+// any YAML scalar.
+var scalarType = []string{"string", "number", "boolean"}
+
+// boolValues are the values accepted by bool options.
+var boolValues = []interface{}{true, false, 1, 0,
+	"1", "t", "T", "TRUE", "true", "True", "0", "f", "F", "FALSE", "false", "False"}
+
+// durationPattern matches the durations accepted by
+// time.ParseDuration.
+const durationPattern = `^[-+]?(0|([0-9]*(\.[0-9]*)?(ns|us|µs|μs|ms|s|m|h))+)$`
+
+// envReferenceSchema matches values that are only a reference to an
+// environment variable, which ReadConfigs expands before the options
+// of a device are parsed.
+var envReferenceSchema = &jsonSchema{
+	Type:    "string",
+	Pattern: `^\$\{[A-Za-z_][A-Za-z0-9_]*\}$`,
+}
+
+// orEnvReference returns the alternatives of the values matching a
+// schema and of environment references, for the anyOf of a schema.
+func orEnvReference(s *jsonSchema) []*jsonSchema {
+	return []*jsonSchema{s, envReferenceSchema}
+}
+
+// enumValues returns the JSON values matching the values of an enum
+// option, which include numbers for the ones YAML reads as such.
+func enumValues(values []string) []interface{} {
+	var enum []interface{}
+	for _, v := range values {
+		enum = append(enum, v)
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && strconv.FormatInt(n, 10) == v {
+			enum = append(enum, n)
+		}
+	}
+	return enum
+}
+
+// bound returns the minimum or maximum of an int or port option for
+// a JSON Schema, or nil if it has none.
+func (o Option) bound(v string, fallback *int64) *int64 {
+	if v == "" {
+		return fallback
+	}
+	n, err := o.parseBound(v)
+	if err != nil {
+		return fallback
+	}
+	return &n
+}
+
+func int64Ptr(n int64) *int64 {
+	return &n
+}
+
+// jsonSchema returns the JSON Schema of the values of an option.
+func (o Option) jsonSchema() *jsonSchema {
+	s := &jsonSchema{Description: o.Description}
+	if td := o.typeDesc(); td != "" {
+		s.Description += " [" + td + "]"
+	}
+	if o.Secret {
+		s.Description += " (secret)"
+	}
+	if o.Default != "" {
+		s.Default = o.Default
+	}
+	switch o.Type {
+	case OptionTypeInt:
+		s.AnyOf = orEnvReference(&jsonSchema{
+			Type:    []string{"integer", "string"},
+			Pattern: `^[-+]?[0-9]+$`,
+			Minimum: o.bound(o.Min, nil),
+			Maximum: o.bound(o.Max, nil),
+		})
+	case OptionTypePort:
+		s.AnyOf = orEnvReference(&jsonSchema{
+			Type:    []string{"integer", "string"},
+			Pattern: `^[0-9]+$`,
+			Minimum: o.bound(o.Min, int64Ptr(0)),
+			Maximum: o.bound(o.Max, int64Ptr(65535)),
+		})
+	case OptionTypeBool:
+		s.Enum = boolValues
+	case OptionTypeDuration:
+		s.AnyOf = orEnvReference(&jsonSchema{Type: "string", Pattern: durationPattern})
+	case OptionTypeAddress:
+		s.AnyOf = orEnvReference(&jsonSchema{
+			Type: "string",
+			AnyOf: []*jsonSchema{
+				{Format: "ipv4"},
+				{Format: "ipv6"},
+				{Pattern: hostnameRegexp.String()},
+			},
+		})
+	case OptionTypeEnum:
+		s.Enum = enumValues(o.Values)
+	case OptionTypeList:
+		s.Type = "string"
+		if len(o.Values) > 0 {
+			quoted := make([]string, len(o.Values))
+			for i, v := range o.Values {
+				quoted[i] = regexp.QuoteMeta(v)
+			}
+			value := "(" + strings.Join(quoted, "|") + ")"
+			s.Pattern = "^" + value + "(," + value + ")*$"
+		}
+	default:
+		s.Type = scalarType
+	}
+	if o.Pattern != "" {
+		// Values must match the pattern in full.
+		s.AllOf = []*jsonSchema{{Pattern: "^(?:" + o.Pattern + ")$"}}
+	}
+	return s
+}
+
+// optionsSchema returns the JSON Schema of the options of a device
+// type, requiring its required options if required is set.
+func optionsSchema(options map[string]Option, required bool) *jsonSchema {
+	s := &jsonSchema{
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{},
+		AdditionalProperties: false,
+	}
+	for name, o := range options {
+		s.Properties[name] = o.jsonSchema()
+		if required && o.Required {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+// stringMapSchema is the JSON Schema of credentials.
+var stringMapSchema = &jsonSchema{
+	Type:                 "object",
+	AdditionalProperties: &jsonSchema{Type: scalarType},
+}
+
+// configSchema returns the JSON Schema of a config of a device type.
+func configSchema(name string, options map[string]Option) *jsonSchema {
+	s := &jsonSchema{
+		Title: "Config of a " + name + " device",
+		Type:  "object",
+		Properties: map[string]*jsonSchema{
+			"Name":        {Type: "string"},
+			"Device":      {Const: name},
+			"NoStream":    {Type: "boolean"},
+			"Options":     optionsSchema(options, true),
+			"LogLevel":    {Type: "string"},
+			"Credentials": stringMapSchema,
+			"Enabled":     {Type: "boolean"},
+			"ForceUpdate": {Type: "integer"},
+		},
+		Required:             []string{"Device"},
+		AdditionalProperties: false,
+	}
+	if len(s.Properties["Options"].Required) > 0 {
+		s.Required = append(s.Required, "Options")
+	}
+	return s
+}
+
+// groupSchema returns the JSON Schema of a group of devices of a
+// device type. Required options aren't required since members can
+// set them.
+func groupSchema(name string, options map[string]Option) *jsonSchema {
+	member := &jsonSchema{
+		Type: "object",
+		Properties: map[string]*jsonSchema{
+			"Address":     {Type: "string"},
+			"NoStream":    {Type: "boolean"},
+			"Options":     optionsSchema(options, false),
+			"LogLevel":    {Type: "string"},
+			"Credentials": stringMapSchema,
+		},
+		Required:             []string{"Address"},
+		AdditionalProperties: false,
+	}
+	return &jsonSchema{
+		Title: "Group of " + name + " devices",
+		Type:  "object",
+		Properties: map[string]*jsonSchema{
+			"Group":         {Type: "string", MinLength: 1},
+			"Device":        {Const: name},
+			"NoStream":      {Type: "boolean"},
+			"Options":       optionsSchema(options, false),
+			"LogLevel":      {Type: "string"},
+			"Credentials":   stringMapSchema,
+			"AddressOption": {Type: "string"},
+			"Members": {
+				Type: "array",
+				Items: &jsonSchema{OneOf: []*jsonSchema{
+					{Type: "string"},
+					member,
+				}},
+			},
+		},
+		Required:             []string{"Group", "Device"},
+		AdditionalProperties: false,
+	}
+}
+
+// includeSchema is the JSON Schema of Include directives.
+var includeSchema = &jsonSchema{
+	Title: "Include directive",
+	Type:  "object",
+	Properties: map[string]*jsonSchema{
+		includeKey: {OneOf: []*jsonSchema{
+			{Type: "string"},
+			{Type: "array", Items: &jsonSchema{Type: "string"}, MinItems: 1},
+		}},
+	},
+	Required:             []string{includeKey},
+	AdditionalProperties: false,
+}
+
+// ConfigJSONSchema returns a JSON Schema of config files, as read by
+// ReadConfigs, for the registered device types. Each entry of a config
+// file must match one of the configs or device groups of a device
+// type, whose options are checked against the ones the device type
+// was registered with, or an Include directive.
+func ConfigJSONSchema() ([]byte, error) {
+	names := Registered()
+	sort.Strings(names)
+	s := &jsonSchema{
+		Schema:      "http://json-schema.org/draft-07/schema#",
+		Title:       "Collector device configs",
+		Type:        "array",
+		Items:       &jsonSchema{},
+		Definitions: map[string]*jsonSchema{},
+	}
+	for _, name := range names {
+		options := deviceMap[name].options
+		s.Definitions["config-"+name] = configSchema(name, options)
+		s.Definitions["group-"+name] = groupSchema(name, options)
+		s.Items.OneOf = append(s.Items.OneOf,
+			&jsonSchema{Ref: "#/definitions/config-" + name},
+			&jsonSchema{Ref: "#/definitions/group-" + name})
+	}
+	s.Definitions["include"] = includeSchema
+	s.Items.OneOf = append(s.Items.OneOf, &jsonSchema{Ref: "#/definitions/include"})
+	return json.MarshalIndent(s, "", "  ")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestConfigJSONSchema(t *testing.T) {
+	for name := range deviceMap {
+		defer Register(name, deviceMap[name].creator, deviceMap[name].options)
+		Unregister(name)
+	}
+	Register("schematest", NewTestDevice, map[string]Option{
+		"host":     {Description: "host", Type: OptionTypeAddress, Required: true},
+		"id":       {Description: "device ID", Pattern: `[a-z]+`, Required: true},
+		"count":    {Description: "count", Type: OptionTypeInt, Min: "1", Max: "10"},
+		"port":     {Description: "port", Type: OptionTypePort, Default: "161"},
+		"mode":     {Description: "mode", Type: OptionTypeEnum, Values: []string{"2c", "3"}},
+		"colors":   {Description: "colors", Type: OptionTypeList, Values: []string{"a.b", "c"}},
+		"password": {Description: "password", Secret: true},
+	})
+	defer Unregister("schematest")
+
+	b, err := ConfigJSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatal(err)
+	}
+	get := func(path ...string) interface{} {
+		t.Helper()
+		var v interface{} = schema
+		for _, p := range path {
+			if l, ok := v.([]interface{}); ok {
+				i, err := strconv.Atoi(p)
+				if err != nil || i >= len(l) {
+					t.Fatalf("no %v in schema", path)
+				}
+				v = l[i]
+				continue
+			}
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				t.Fatalf("no %v in schema", path)
+			}
+			v = m[p]
+		}
+		return v
+	}
+	check := func(expect interface{}, path ...string) {
+		t.Helper()
+		if v := get(path...); !reflect.DeepEqual(v, expect) {
+			t.Errorf("expected %v at %v, got %v", expect, path, v)
+		}
+	}
+
+	check([]interface{}{
+		map[string]interface{}{"$ref": "#/definitions/config-schematest"},
+		map[string]interface{}{"$ref": "#/definitions/group-schematest"},
+		map[string]interface{}{"$ref": "#/definitions/include"},
+	}, "items", "oneOf")
+
+	options := []string{"definitions", "config-schematest", "properties", "Options"}
+	option := func(name string, keys ...string) []string {
+		return append(append(append([]string{}, options...), "properties", name), keys...)
+	}
+	check([]interface{}{"Device", "Options"}, "definitions", "config-schematest", "required")
+	check(map[string]interface{}{"const": "schematest"},
+		"definitions", "config-schematest", "properties", "Device")
+	check(false, append(options, "additionalProperties")...)
+	check([]interface{}{"host", "id"}, append(options, "required")...)
+	check([]interface{}{map[string]interface{}{"pattern": "^(?:[a-z]+)$"}},
+		option("id", "allOf")...)
+	check(1.0, option("count", "anyOf", "0", "minimum")...)
+	check(10.0, option("count", "anyOf", "0", "maximum")...)
+	check(65535.0, option("port", "anyOf", "0", "maximum")...)
+	envReference := map[string]interface{}{
+		"type":    "string",
+		"pattern": `^\$\{[A-Za-z_][A-Za-z0-9_]*\}$`,
+	}
+	for _, name := range []string{"host", "count", "port"} {
+		check(envReference, option(name, "anyOf", "1")...)
+	}
+	check("string", option("host", "anyOf", "0", "type")...)
+	check("161", option("port", "default")...)
+	check([]interface{}{"2c", "3", 3.0}, option("mode", "enum")...)
+	check(`^(a\.b|c)(,(a\.b|c))*$`, option("colors", "pattern")...)
+	check("password (secret)", option("password", "description")...)
+
+	// Members of groups can set required options.
+	check(nil, "definitions", "group-schematest", "properties", "Options", "required")
+	check([]interface{}{"Group", "Device"}, "definitions", "group-schematest", "required")
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Redacted is printed in place of a secret value.
+const Redacted = "<redacted>"
+
+// Secret holds a sensitive value such as a password, key or
+// community string. Formatting a Secret with any fmt verb, or
+// marshalling it to JSON or YAML, produces Redacted rather than the
+// value; the value itself is only available through Value.
+type Secret struct {
+	value *string
+}
+
+// NewSecret returns a Secret holding the specified value.
+func NewSecret(value string) Secret {
+	return Secret{value: &value}
+}
+
+// Value returns the secret value.
+func (s Secret) Value() string {
+	if s.value == nil {
+		return ""
+	}
+	return *s.value
+}
+
+// IsEmpty returns true if the secret has no value.
+func (s Secret) IsEmpty() bool {
+	return s.Value() == ""
+}
+
+func (s Secret) String() string {
+	return Redacted
+}
+
+// GoString implements fmt.GoStringer.
+func (s Secret) GoString() string {
+	return Redacted
+}
+
+// Format implements fmt.Formatter so that no verb prints the value.
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, Redacted)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (s Secret) MarshalYAML() (interface{}, error) {
+	return Redacted, nil
+}
+
+// GetSecretOption returns the option specified by optionName as a
+// Secret.
+func GetSecretOption(optionName string,
+	options map[string]string) (Secret, error) {
+	o, ok := options[optionName]
+	if !ok {
+		return Secret{}, fmt.Errorf("No option '%s'", optionName)
+	}
+	return NewSecret(o), nil
+}
+
+// IsSecretOption returns true if the named option of the specified
+// device type is registered as secret.
+func IsSecretOption(deviceType, optionName string) bool {
+	registrationInfo, ok := deviceMap[deviceType]
+	if !ok {
+		return false
+	}
+	return registrationInfo.options[optionName].Secret
+}
+
+// isSecretOptionName returns true if an option with the specified
+// name is secret for any registered device type. It's used where
+// the device type isn't known.
+func isSecretOptionName(optionName string) bool {
+	for _, registrationInfo := range deviceMap {
+		if registrationInfo.options[optionName].Secret {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactedOptions returns a copy of the options of a device of the
+// specified type, with the values of its secret options replaced by
+// Redacted. If the device type isn't registered, every option that
+// is secret for some registered device type is redacted.
+func RedactedOptions(deviceType string,
+	options map[string]string) map[string]string {
+	_, registered := deviceMap[deviceType]
+	redacted := make(map[string]string, len(options))
+	for k, v := range options {
+		if v != "" && (IsSecretOption(deviceType, k) ||
+			(!registered && isSecretOptionName(k))) {
+			v = Redacted
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// RedactedCredentials returns a copy of the credentials with every
+// value replaced by Redacted.
+func RedactedCredentials(credentials map[string]string) map[string]string {
+	redacted := make(map[string]string, len(credentials))
+	for k := range credentials {
+		redacted[k] = Redacted
+	}
+	return redacted
+}
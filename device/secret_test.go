@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	pm "github.com/aristanetworks/cloudvision-go/provider/mock"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	testPassword   = "hunter2!"
+	testCredential = "s3cr3t-token"
+)
+
+var secretTestDeviceInfo = registrationInfo{
+	name:    "secret",
+	creator: NewTestDevice,
+	options: map[string]Option{
+		"address": {
+			Description: "device address",
+		},
+		"password": {
+			Description: "device password",
+			Pattern:     "[a-z0-9!]+",
+			Secret:      true,
+		},
+	},
+}
+
+func TestSecretFormat(t *testing.T) {
+	s := NewSecret(testPassword)
+	if s.Value() != testPassword {
+		t.Fatalf("expected value %q, got %q", testPassword, s.Value())
+	}
+	if s.IsEmpty() || !(Secret{}).IsEmpty() || !NewSecret("").IsEmpty() {
+		t.Fatalf("unexpected IsEmpty result")
+	}
+
+	wrapped := struct {
+		Name     string
+		Password Secret
+	}{"dev", s}
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d", "%T%v"} {
+		for _, v := range []interface{}{s, &s, wrapped, &wrapped} {
+			if out := fmt.Sprintf(verb, v); strings.Contains(out, testPassword) {
+				t.Errorf("%s leaked secret: %s", verb, out)
+			}
+		}
+	}
+
+	b, err := json.Marshal(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["Password"] != Redacted {
+		t.Errorf("unexpected JSON: %s", b)
+	}
+	b, err = yaml.Marshal(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), testPassword) || !strings.Contains(string(b), Redacted) {
+		t.Errorf("unexpected YAML: %s", b)
+	}
+}
+
+func TestRedactedOptions(t *testing.T) {
+	Register(secretTestDeviceInfo.name, secretTestDeviceInfo.creator,
+		secretTestDeviceInfo.options)
+	defer Unregister(secretTestDeviceInfo.name)
+
+	options := map[string]string{"address": "1.2.3.4", "password": testPassword}
+	for _, tc := range []struct {
+		deviceType string
+		options    map[string]string
+		expected   map[string]string
+	}{{
+		deviceType: "secret",
+		options:    options,
+		expected:   map[string]string{"address": "1.2.3.4", "password": Redacted},
+	}, {
+		// Empty values aren't worth hiding.
+		deviceType: "secret",
+		options:    map[string]string{"password": ""},
+		expected:   map[string]string{"password": ""},
+	}, {
+		// Unknown device types fall back on option names.
+		deviceType: "unknown",
+		options:    options,
+		expected:   map[string]string{"address": "1.2.3.4", "password": Redacted},
+	}} {
+		got := RedactedOptions(tc.deviceType, tc.options)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.deviceType, tc.expected, got)
+		}
+	}
+	if options["password"] != testPassword {
+		t.Errorf("RedactedOptions modified its input")
+	}
+
+	creds := RedactedCredentials(map[string]string{"token": testCredential})
+	if !reflect.DeepEqual(creds, map[string]string{"token": Redacted}) {
+		t.Errorf("unexpected redacted credentials: %v", creds)
+	}
+}
+
+// TestSecretLogging logs configs in all the ways the sensor and
+// collector do and checks that no secret makes it into the output.
+func TestSecretLogging(t *testing.T) {
+	Register(secretTestDeviceInfo.name, secretTestDeviceInfo.creator,
+		secretTestDeviceInfo.options)
+	defer Unregister(secretTestDeviceInfo.name)
+
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	defer logrus.SetOutput(os.Stderr)
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.TraceLevel)
+	defer logrus.SetLevel(level)
+
+	config := &Config{
+		Name:        "dev",
+		Device:      "secret",
+		Options:     map[string]string{"address": "1.2.3.4", "password": testPassword},
+		Credentials: map[string]string{"token": testCredential},
+	}
+	info, err := NewDeviceInfo(context.Background(), config, pm.NewMockMonitor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logrus.Infof("info: %v", info)
+	logrus.Infof("config: %v %+v", config, config)
+
+	dscfg := datasourceConfig{
+		name:       "dev",
+		typ:        "secret",
+		option:     config.Options,
+		credential: config.Credentials,
+	}
+	logrus.Tracef("Trying to deploy config: %v. Current: %v", dscfg, &dscfg)
+
+	// Options that fail validation are reported without their values.
+	_, err = NewDeviceInfo(context.Background(), &Config{
+		Device:  "secret",
+		Options: map[string]string{"password": testPassword + "#"},
+	}, pm.NewMockMonitor())
+	if err == nil {
+		t.Fatal("expected error for bad password")
+	}
+	logrus.Infof("Error in device.NewDeviceInfo: %v", err)
+
+	// Raw config subscription responses are traced by the sensor.
+	prefix := pgnmi.PathFromString("datasource/config/sensor[id=s1]/source[name=dev]")
+	resp := &gnmi.SubscribeResponse{
+		Response: &gnmi.SubscribeResponse_Update{
+			Update: &gnmi.Notification{
+				Prefix: prefix,
+				Update: []*gnmi.Update{
+					pgnmi.Update(pgnmi.PathFromString("type"), agnmi.TypedValue("secret")),
+					pgnmi.Update(pgnmi.PathFromString("option[key=password]/value"),
+						agnmi.TypedValue(testPassword)),
+					pgnmi.Update(pgnmi.PathFromString("option[key=address]/value"),
+						agnmi.TypedValue("1.2.3.4")),
+					pgnmi.Update(pgnmi.PathFromString("credential[key=token]/value"),
+						agnmi.TypedValue(testCredential)),
+				},
+			},
+		},
+	}
+	s := &Sensor{log: logrus.WithField("sensor", "s1")}
+	s.traceResponse(resp)
+	if resp.GetUpdate().Update[1].Val.GetStringVal() != testPassword {
+		t.Errorf("traceResponse modified the response")
+	}
+
+	out := buf.String()
+	for _, secret := range []string{testPassword, testCredential} {
+		if strings.Contains(out, secret) {
+			t.Errorf("secret %q leaked into log output:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "1.2.3.4") || !strings.Contains(out, Redacted) {
+		t.Errorf("expected redacted config in log output:\n%s", out)
+	}
+}
+
+func TestWriteConfigsMode(t *testing.T) {
+	Register(secretTestDeviceInfo.name, secretTestDeviceInfo.creator,
+		secretTestDeviceInfo.options)
+	defer Unregister(secretTestDeviceInfo.name)
+	t.Setenv("SECRET_TEST_PASSWORD", testPassword)
+	t.Setenv("SECRET_TEST_TOKEN", testCredential)
+
+	path := filepath.Join(t.TempDir(), "configs.yaml")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Secrets aren't written in clear text.
+	for _, config := range []*Config{{
+		Name:    "option",
+		Device:  "secret",
+		Options: map[string]string{"password": testPassword},
+	}, {
+		Name:        "credential",
+		Device:      "secret",
+		Credentials: map[string]string{"token": testCredential},
+	}} {
+		if err := WriteConfigs(path, []*Config{config}); err == nil {
+			t.Errorf("expected error writing config %s with a secret in clear text",
+				config.Name)
+		}
+	}
+	if data, err := os.ReadFile(path); err != nil || len(data) != 0 {
+		t.Fatalf("expected config file to be left alone, got %q (%v)", data, err)
+	}
+
+	configs := []*Config{{
+		Device:      "secret",
+		Options:     map[string]string{"password": "${SECRET_TEST_PASSWORD}"},
+		Credentials: map[string]string{"token": "${SECRET_TEST_TOKEN}"},
+	}}
+	if err := WriteConfigs(path, configs); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %v", fi.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(testPassword)) || bytes.Contains(data, []byte(testCredential)) {
+		t.Errorf("secret written in clear text:\n%s", data)
+	}
+	// The references are resolved when the configs are read back.
+	read, err := ReadConfigs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Config{
+		Device:      "secret",
+		Options:     map[string]string{"password": testPassword},
+		Credentials: map[string]string{"token": testCredential},
+	}
+	if len(read) != 1 || !read[0].Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, read)
+	}
+}
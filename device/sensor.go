@@ -17,19 +17,24 @@ import (
 	"github.com/aristanetworks/cloudvision-go/device/cvclient"
 	"github.com/aristanetworks/cloudvision-go/provider"
 	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/aristanetworks/cloudvision-go/tracing"
 	"github.com/aristanetworks/cloudvision-go/version"
 	agnmi "github.com/aristanetworks/goarista/gnmi"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
 	// precreate more frequently used gnmi paths
-	lastErrorKey = pgnmi.PathFromString("last-error")
-	lastSeenKey  = pgnmi.Path("last-seen")
+	lastErrorKey  = pgnmi.PathFromString("last-error")
+	lastSeenKey   = pgnmi.Path("last-seen")
+	stopReasonKey = pgnmi.Path("stop-reason")
 
 	undefinedPathElem = &gnmi.PathElem{Name: "___undefined___"}
 )
@@ -112,8 +117,10 @@ func (c datasourceConfig) clone() *datasourceConfig {
 }
 
 func (c datasourceConfig) String() string {
-	return fmt.Sprintf("name: %s, typ: %s, enabled: %t, option: %v, loglevel: %s, forceupdate: %d",
-		c.name, c.typ, c.enabled, c.option, c.loglevel, c.forceupdate)
+	return fmt.Sprintf("name: %s, typ: %s, enabled: %t, option: %v, credential: %v, "+
+		"loglevel: %s, forceupdate: %d", c.name, c.typ, c.enabled,
+		RedactedOptions(c.typ, c.option), RedactedCredentials(c.credential),
+		c.loglevel, c.forceupdate)
 }
 
 func (c datasourceConfig) equals(other *datasourceConfig) bool {
@@ -168,6 +175,8 @@ type datasource struct {
 	redeployTimer *time.Timer
 	cancel        context.CancelFunc
 	execGroup     *errgroup.Group
+	// pollGate lets the polls of the providers be drained on shutdown.
+	pollGate *provider.PollGate
 
 	info     *Info
 	grpcc    *grpc.ClientConn
@@ -183,6 +192,21 @@ type datasource struct {
 	limitDatasourcesToRun int
 	metricTracker         MetricTracker
 	metricIntervalTime    time.Duration
+
+	// crash-loop detection
+	crashLoopThreshold int
+	crashLoopWindow    time.Duration
+	quarantineBackoff  time.Duration
+
+	// stateLock protects the fields below, which are reported by the
+	// sensor's admin API.
+	stateLock     sync.Mutex
+	deviceID      string
+	lastError     string
+	lastErrorTime time.Time
+	restartAt     time.Time
+	failures      []datasourceFailure
+	quarantined   bool
 }
 
 func (d *datasource) submitDatasourceUpdates(ctx context.Context,
@@ -197,6 +221,9 @@ func (d *datasource) submitDatasourceUpdates(ctx context.Context,
 
 func (d *datasource) handleDatasourceError(ctx context.Context, e error, errorName string) {
 	d.log.Error(e)
+	d.stateLock.Lock()
+	d.lastError, d.lastErrorTime = e.Error(), time.Now()
+	d.stateLock.Unlock()
 	d.metricTracker.TrackDatasourceErrors(ctx, d.config.typ, errorName)
 	err := d.submitDatasourceUpdates(ctx,
 		pgnmi.Update(lastErrorKey, agnmi.TypedValue(e.Error())))
@@ -218,11 +245,17 @@ func (d *datasource) handleDatasourceMessage(ctx context.Context, message string
 func (d *datasource) scheduleRestart(ctx context.Context, in time.Duration) {
 	// If was already triggered we are ok in triggering it again
 	_ = d.redeployTimer.Reset(in)
+	d.stateLock.Lock()
+	d.restartAt = time.Now().Add(in)
+	d.stateLock.Unlock()
 	d.metricTracker.TrackDatasourceRestarts(ctx, d.config.typ)
 }
 
 func (d *datasource) deploy(ctx context.Context, cfg *datasourceConfig,
 	dsRunningTracker *atomic.Int32, dsStopped chan string) error {
+	ctx = tracing.ContextWithDatasource(ctx, cfg.name, cfg.typ)
+	ctx, span := tracing.Start(ctx, "datasource.deploy")
+	defer span.End()
 	d.log.Tracef("Trying to deploy config: %v. Current: %v", cfg, d.config)
 	d.metricTracker.TrackDatasourceDeploys(ctx, cfg.typ)
 
@@ -234,11 +267,15 @@ func (d *datasource) deploy(ctx context.Context, cfg *datasourceConfig,
 
 	// Start processing new config
 	d.stop()
+	if !cfg.equals(d.config) {
+		d.releaseQuarantine(ctx, "config changed")
+	}
 	d.config = cfg.clone()
 
 	d.log.Info("Starting run")
 
 	ctx, cancel := context.WithCancel(ctx)
+	ctx, d.pollGate = provider.WithPollGate(ctx)
 	d.execGroup, ctx = errgroup.WithContext(ctx)
 	d.cancel = cancel
 
@@ -254,6 +291,7 @@ func (d *datasource) deploy(ctx context.Context, cfg *datasourceConfig,
 			cancel() // make sure to cancel everything if the Run function returns for any reason.
 		}()
 
+		started := time.Now()
 		err := d.Run(runCtx)
 
 		// If main context was canceled, do not retry.
@@ -293,9 +331,16 @@ func (d *datasource) deploy(ctx context.Context, cfg *datasourceConfig,
 		}
 
 		// Handle return error by pushing it to datasource last-error state
+		quarantined, entered := d.recordFailure(ctx, err, time.Since(started))
 		backoff := d.failureRetryTimer.Backoff()
+		if quarantined {
+			backoff = d.quarantineBackoff
+		}
 		err = fmt.Errorf("Datasource stopped unexpectedly: %w. Retrying in %v", err, backoff)
 		d.handleDatasourceError(ctx, err, dsErrUnexpectedStop)
+		if quarantined {
+			d.publishQuarantine(ctx, time.Now().Add(backoff), entered)
+		}
 		d.scheduleRestart(ctx, backoff)
 		return err
 	})
@@ -308,6 +353,9 @@ func (d *datasource) stop() {
 	if d.redeployTimer != nil {
 		_ = d.redeployTimer.Stop()
 	}
+	d.stateLock.Lock()
+	d.restartAt = time.Time{}
+	d.stateLock.Unlock()
 	if d.cancel != nil {
 		d.cancel()
 	}
@@ -333,13 +381,21 @@ func (d *datasource) resolveCredentials(ctx context.Context,
 
 // Run executes the datasource
 func (d *datasource) Run(ctx context.Context) (err error) {
-	// Submit initial status information initially as the next operations can be slow
-	if err := d.submitDatasourceUpdates(ctx,
-		// last-seen is not sent as to not qualify the datasource as streaming yet
-		pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
-		pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue(d.config.typ)),
-		pgnmi.Update(pgnmi.Path("enabled"), agnmi.TypedValue(true)),
-	); err != nil {
+	ctx, span := tracing.Start(ctx, "datasource.Run")
+	defer func() { tracing.End(span, err) }()
+
+	// Submit initial status information initially as the next operations can be slow.
+	// The reason of a previous stop no longer applies.
+	if _, err := d.gnmic.Set(ctx, &gnmi.SetRequest{
+		Prefix: d.statePrefix,
+		Delete: []*gnmi.Path{stopReasonKey},
+		Update: []*gnmi.Update{
+			// last-seen is not sent as to not qualify the datasource as streaming yet
+			pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
+			pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue(d.config.typ)),
+			pgnmi.Update(pgnmi.Path("enabled"), agnmi.TypedValue(true)),
+		},
+	}); err != nil {
 		return err
 	}
 
@@ -356,9 +412,20 @@ func (d *datasource) Run(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	// Everything using the device, including its providers, has
+	// returned by the time Run does, so it's safe to close it then.
+	defer func() {
+		if err := CloseDevice(ctx, info.Device); err != nil {
+			d.log.Errorf("Error closing device: %v", err)
+		}
+	}()
 	d.cvClient = d.clientFactory(d.gnmic, info)
 	d.info = info
 	deviceID := info.ID
+	d.stateLock.Lock()
+	d.deviceID = deviceID
+	d.stateLock.Unlock()
+	span.SetAttributes(tracing.DeviceIDKey.String(deviceID))
 
 	var updates []*gnmi.Update
 	if len(deviceID) > 0 {
@@ -690,6 +757,13 @@ type Sensor struct {
 	datasource         map[string]*datasource
 	clientFactory      func(gnmi.GNMIClient, *Info) cvclient.CVClient
 
+	// disabledLocally holds the datasources disabled through the
+	// admin API, which don't run whatever their config says.
+	disabledLocally map[string]bool
+	// adminRequests receives the requests of the admin API, which
+	// are run by the main loop.
+	adminRequests chan func(context.Context)
+
 	// channel to receive custom configs from.
 	configCh chan *Config
 
@@ -722,6 +796,23 @@ type Sensor struct {
 
 	// metricIntervalTime represents the time interval at which metric data is published to server
 	metricIntervalTime time.Duration
+
+	// datasources that stop unexpectedly crashLoopThreshold times
+	// within crashLoopWindow are only retried every quarantineBackoff
+	crashLoopThreshold int
+	crashLoopWindow    time.Duration
+	quarantineBackoff  time.Duration
+
+	// datasource sharding across the replicas of the sensor
+	shardCoordinator ShardCoordinator
+	shardReplica     string
+	shardLeaseTTL    time.Duration
+	shardRing        *hashRing
+	shardLeases      map[string]bool // datasources whose lease the replica holds
+
+	// shutDown is set by Shutdown once the datasources are drained,
+	// for the main loop to return.
+	shutDown bool
 }
 
 // SensorOption is used to configure the Sensor.
@@ -822,13 +913,54 @@ func WithLimitDatasourcesToRun(limit int) SensorOption {
 	return func(s *Sensor) { s.limitDatasourcesToRun = limit }
 }
 
+// WithSensorCrashLoopDetection makes the sensor quarantine datasources
+// that stop unexpectedly threshold times within window. A run lasting
+// longer than window releases the datasource from quarantine. A
+// threshold of 0, the default, disables crash-loop detection.
+func WithSensorCrashLoopDetection(threshold int, window time.Duration) SensorOption {
+	return func(s *Sensor) {
+		s.crashLoopThreshold = threshold
+		s.crashLoopWindow = window
+	}
+}
+
+// WithSensorQuarantineBackoff sets how long quarantined datasources
+// wait before being retried. They stay quarantined until their config
+// changes, they're restarted through the admin API, or a run of theirs
+// outlasts the crash-loop window.
+func WithSensorQuarantineBackoff(d time.Duration) SensorOption {
+	return func(s *Sensor) { s.quarantineBackoff = d }
+}
+
+// WithSensorSharding makes the sensor one of several replicas
+// sharing its datasources through coordinator. Each datasource is
+// run by a single replica, picked by consistent hashing among the
+// live ones, which holds a lease on it. replica must be unique among
+// the sensor's replicas.
+func WithSensorSharding(coordinator ShardCoordinator, replica string) SensorOption {
+	return func(s *Sensor) {
+		s.shardCoordinator = coordinator
+		s.shardReplica = replica
+	}
+}
+
+// WithSensorShardLeaseTTL sets how long the leases of a sharded
+// sensor last without being renewed, which bounds how long the
+// datasources of a replica that died go without running.
+func WithSensorShardLeaseTTL(d time.Duration) SensorOption {
+	return func(s *Sensor) { s.shardLeaseTTL = d }
+}
+
 // WithMetricTracker adds a metric tracker to the sensor to track metrics
 func WithMetricTracker(metricTracker MetricTracker) SensorOption {
 	return func(s *Sensor) { s.metricTracker = metricTracker }
 }
 
 func (s *Sensor) handleConfigUpdate(ctx context.Context,
-	resp *gnmi.Notification, postSync bool) error {
+	resp *gnmi.Notification, postSync bool) (err error) {
+	ctx, span := tracing.Start(ctx, "Sensor.handleConfigUpdate",
+		trace.WithAttributes(attribute.Bool("post-sync", postSync)))
+	defer func() { tracing.End(span, err) }()
 	// For each deleted datasource name, cancel that datasource and
 	// delete it and its config from our collections.
 	dsUpdated := map[string]map[string]bool{}
@@ -1026,8 +1158,12 @@ func (s *Sensor) removeDatasource(ctx context.Context, name string) {
 
 	s.log.Debugf("Removing datasource: %s", name)
 	ds.stop()
+	if s.shardLeases[name] {
+		s.releaseDatasource(ctx, name)
+	}
 	delete(s.datasource, name)
 	delete(s.datasourceConfig, name)
+	delete(s.disabledLocally, name)
 	if _, err := s.gnmic.Set(ctx, &gnmi.SetRequest{
 		Delete: []*gnmi.Path{ds.statePrefix},
 	}); err != nil {
@@ -1127,6 +1263,9 @@ func (s *Sensor) getDatasource(ctx context.Context, name string) *datasource {
 		limitDatasourcesToRun: s.limitDatasourcesToRun,
 		metricTracker:         s.metricTracker,
 		metricIntervalTime:    s.metricIntervalTime,
+		crashLoopThreshold:    s.crashLoopThreshold,
+		crashLoopWindow:       s.crashLoopWindow,
+		quarantineBackoff:     s.quarantineBackoff,
 	}
 	// Setup monitor for datasource
 	runtime.monitor = newDatasourceMonitor(runtime.log, runtime.config.loglevel)
@@ -1145,6 +1284,11 @@ func (s *Sensor) runDatasourceConfig(ctx context.Context, name string) error {
 	if !ok {
 		return fmt.Errorf("config not found: %v", name)
 	}
+	// Sharded datasources are only run by the replica that owns them.
+	if !s.ownsDatasource(ctx, name) {
+		s.releaseDatasource(ctx, name)
+		return nil
+	}
 	runtime := s.getDatasource(ctx, name)
 	if !s.clockSynced {
 		msg := fmt.Errorf("Sensor clock is not in sync, skipping data source deployment")
@@ -1152,6 +1296,13 @@ func (s *Sensor) runDatasourceConfig(ctx context.Context, name string) error {
 		return nil
 	}
 
+	// Datasources disabled locally stay disabled until they're
+	// enabled locally again.
+	if s.disabledLocally[name] && cfg.enabled {
+		cfg = cfg.clone()
+		cfg.enabled = false
+	}
+
 	// if cfg is disabled then stop the run and set scheduled to false
 	if !cfg.enabled {
 		s.disableDatasource(ctx, runtime, cfg)
@@ -1175,6 +1326,32 @@ func (s *Sensor) runDatasourceConfig(ctx context.Context, name string) error {
 	return runtime.deploy(ctx, cfg, &s.numDatasourcesRunning, s.datasourceStopped)
 }
 
+// redactedResponse returns a copy of a config subscription response
+// with its credential values and secret option values redacted.
+func redactedResponse(resp *gnmi.SubscribeResponse) *gnmi.SubscribeResponse {
+	if resp.GetUpdate() == nil {
+		return resp
+	}
+	out := proto.Clone(resp).(*gnmi.SubscribeResponse)
+	notif := out.GetUpdate()
+	for _, upd := range notif.Update {
+		for _, elem := range pgnmi.PathJoin(notif.Prefix, upd.Path).Elem {
+			if elem.Name == "credential" ||
+				(elem.Name == "option" && isSecretOptionName(elem.Key["key"])) {
+				upd.Val = agnmi.TypedValue(Redacted)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (s *Sensor) traceResponse(resp *gnmi.SubscribeResponse) {
+	if s.log.Logger.IsLevelEnabled(logrus.TraceLevel) {
+		s.log.Tracef("Got response: %v", redactedResponse(resp))
+	}
+}
+
 type handleUpdateFn func(context.Context, *gnmi.Notification, bool) error
 type handleSyncResponseFn func(context.Context) error
 
@@ -1193,6 +1370,13 @@ func (s *Sensor) subscribe(ctx context.Context, opts *agnmi.SubscribeOptions,
 		// Main sensor loop, reading new configs or redeploying datasources after config changes.
 		postSync := false
 		configChPostSync := false
+		var shardTick <-chan time.Time
+		if s.shardCoordinator != nil {
+			// Renew leases well before they expire.
+			ticker := time.NewTicker(s.shardLeaseTTL / 3)
+			defer ticker.Stop()
+			shardTick = ticker.C
+		}
 		for {
 			select {
 			case dsName, ok := <-s.datasourceStopped:
@@ -1210,11 +1394,18 @@ func (s *Sensor) subscribe(ctx context.Context, opts *agnmi.SubscribeOptions,
 				if err := s.runDatasourceConfig(ctx, name); err != nil {
 					s.log.Errorf("redeploy failed: %v", err)
 				}
+			case request := <-s.adminRequests:
+				request(ctx)
+				if s.shutDown {
+					return errSensorShutDown
+				}
+			case <-shardTick:
+				s.rebalanceShards(ctx)
 			case resp, ok := <-respCh:
 				if !ok {
 					return nil
 				}
-				s.log.Tracef("Got response: %v", resp)
+				s.traceResponse(resp)
 				switch subResp := resp.Response.(type) {
 				case *gnmi.SubscribeResponse_Update:
 					if err := handleUpdate(ctx, subResp.Update, postSync); err != nil {
@@ -1376,6 +1567,10 @@ func (s *Sensor) Run(ctx context.Context) error {
 			}
 		}
 	}
+	if s.shardCoordinator != nil {
+		s.rebalanceShards(ctx)
+		defer s.leaveShards()
+	}
 	sensorConfigPath := fmt.Sprintf("/datasource/config/sensor[id=%s]", s.id)
 	sensorStatePath := fmt.Sprintf("/datasource/state/sensor[id=%s]", s.id)
 
@@ -1444,7 +1639,9 @@ func (s *Sensor) Run(ctx context.Context) error {
 			Paths:  [][]string{agnmi.SplitPath(sensorConfigPath)},
 			Mode:   "stream",
 		}, handleConfigUpdate, handleConfigSync)
-	if err != nil {
+	if errors.Is(err, errSensorShutDown) {
+		err = nil
+	} else if err != nil {
 		s.log.Infof("Config subscription returned: error: %v", err)
 	}
 
@@ -1475,7 +1672,7 @@ func (s *Sensor) handleSensorState(ctx context.Context, opts *agnmi.SubscribeOpt
 				if !ok {
 					return nil
 				}
-				s.log.Tracef("Got response: %v", resp)
+				s.traceResponse(resp)
 				switch subResp := resp.Response.(type) {
 				case *gnmi.SubscribeResponse_Update:
 					if err := handleUpdate(ctx, subResp.Update, postSync); err != nil {
@@ -1521,6 +1718,12 @@ func NewSensor(name string, logRate float64, opts ...SensorOption) *Sensor {
 		logRate:                 logRate,
 		datasourceConfig:        map[string]*datasourceConfig{},
 		datasource:              map[string]*datasource{},
+		disabledLocally:         map[string]bool{},
+		adminRequests:           make(chan func(context.Context)),
+		crashLoopWindow:         time.Minute,
+		quarantineBackoff:       6 * time.Hour,
+		shardLeaseTTL:           15 * time.Second,
+		shardLeases:             map[string]bool{},
 		deviceRedeployTimer:     2 * time.Second,
 		redeployDatasource:      make(chan string),
 		statePrefix:             prefix,
@@ -1706,7 +1909,7 @@ func (s *Sensor) publishSensorMetrics(ctx context.Context) {
 			if !s.active {
 				continue
 			}
-			podStats := s.fetchSensorPodStats()
+			podStats := fetchSensorPodStats()
 			for metric, value := range podStats {
 				err := sensorMetric.SetMetricInt(metric, value)
 				if err != nil {
@@ -1767,36 +1970,27 @@ func publishMetrics(ctx context.Context, metrics *metricCollector, firstUpdate b
 	}
 }
 
+// sensorPodMetrics are the runtime stats of the sensor, as returned by
+// fetchSensorPodStats.
+var sensorPodMetrics = []struct{ name, unit, description string }{
+	{"sensor_go_routines", "Number", "total go routines in sensor pod"},
+	{"sensor_pod_memory_allocation", "MiB", "Sensor pod memory utilization in MiB"},
+	{"sensor_pod_heap_sys_allocation", "MiB", "Sensor pod heap system allocation in MiB"},
+	{"sensor_pod_heap_in_use", "MiB", "Sensor pod heap in use in MiB"},
+	{"sensor_pod_heap_released", "MiB", "Sensor pod heap released in MiB"},
+}
+
 func (s *Sensor) createSensorMetrics(sensorMetric *metricCollector) error {
-	err := sensorMetric.CreateMetric("sensor_go_routines", "Number",
-		"total go routines in sensor pod")
-	if err != nil {
-		return fmt.Errorf("Failed to create metric, Error: %v", err)
-	}
-	err = sensorMetric.CreateMetric("sensor_pod_memory_allocation", "MiB",
-		"Sensor pod memory utilization in MiB")
-	if err != nil {
-		return fmt.Errorf("Failed to create metric, Error: %v", err)
-	}
-	err = sensorMetric.CreateMetric("sensor_pod_heap_sys_allocation", "MiB",
-		"Sensor pod heap system allocation in MiB")
-	if err != nil {
-		return fmt.Errorf("Failed to create metric, Error: %v", err)
-	}
-	err = sensorMetric.CreateMetric("sensor_pod_heap_in_use", "MiB",
-		"Sensor pod heap in use in MiB")
-	if err != nil {
-		return fmt.Errorf("Failed to create metric, Error: %v", err)
-	}
-	err = sensorMetric.CreateMetric("sensor_pod_heap_released", "MiB",
-		"Sensor pod heap released in MiB")
-	if err != nil {
-		return fmt.Errorf("Failed to create metric, Error: %v", err)
+	for _, m := range sensorPodMetrics {
+		err := sensorMetric.CreateMetric(m.name, m.unit, m.description)
+		if err != nil {
+			return fmt.Errorf("Failed to create metric, Error: %v", err)
+		}
 	}
 	return nil
 }
 
-func (s *Sensor) fetchSensorPodStats() map[string]int64 {
+func fetchSensorPodStats() map[string]int64 {
 	podStats := make(map[string]int64, 0)
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
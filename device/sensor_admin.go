@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrDatasourceNotFound is returned by the sensor's admin methods
+// for datasources the sensor has no config for.
+var ErrDatasourceNotFound = errors.New("datasource not found")
+
+// DatasourceStatus is the status of a sensor datasource, as reported
+// by the sensor's admin methods. Secret options and credentials are
+// redacted.
+type DatasourceStatus struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Enabled is whether the datasource is enabled in its config.
+	Enabled bool `json:"enabled"`
+	// DisabledLocally is whether the datasource was disabled with
+	// DisableDatasource, which overrides its config.
+	DisabledLocally bool              `json:"disabledLocally"`
+	Running         bool              `json:"running"`
+	DeviceID        string            `json:"deviceID,omitempty"`
+	Options         map[string]string `json:"options,omitempty"`
+	Credentials     map[string]string `json:"credentials,omitempty"`
+	LogLevel        string            `json:"logLevel"`
+	// NextRestart is when the datasource is scheduled to restart, if
+	// it is.
+	NextRestart   *time.Time `json:"nextRestart,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+	// Quarantined is whether the datasource is crash looping and
+	// only retried with a long backoff. Restarting it releases it.
+	Quarantined bool `json:"quarantined"`
+}
+
+// do runs f in the sensor's main loop, so that it can safely access
+// the datasources, and returns its error. It fails if ctx is done
+// before the main loop gets to f.
+func (s *Sensor) do(ctx context.Context, f func(ctx context.Context) error) error {
+	errc := make(chan error, 1)
+	select {
+	case s.adminRequests <- func(ctx context.Context) { errc <- f(ctx) }:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sensor) datasourceStatus(name string) DatasourceStatus {
+	cfg := s.datasourceConfig[name]
+	status := DatasourceStatus{
+		Name:            name,
+		Type:            cfg.typ,
+		Enabled:         cfg.enabled,
+		DisabledLocally: s.disabledLocally[name],
+		Options:         RedactedOptions(cfg.typ, cfg.option),
+		Credentials:     RedactedCredentials(cfg.credential),
+		LogLevel:        cfg.loglevel.String(),
+	}
+	ds, ok := s.datasource[name]
+	if !ok {
+		return status
+	}
+	status.Running = ds.running.Load()
+	ds.stateLock.Lock()
+	defer ds.stateLock.Unlock()
+	status.DeviceID = ds.deviceID
+	status.LastError = ds.lastError
+	status.Quarantined = ds.quarantined
+	if !ds.lastErrorTime.IsZero() {
+		t := ds.lastErrorTime
+		status.LastErrorTime = &t
+	}
+	if ds.restartAt.After(time.Now()) {
+		t := ds.restartAt
+		status.NextRestart = &t
+	}
+	return status
+}
+
+// Datasources returns the status of the sensor's datasources, sorted
+// by name.
+func (s *Sensor) Datasources(ctx context.Context) ([]DatasourceStatus, error) {
+	var statuses []DatasourceStatus
+	err := s.do(ctx, func(ctx context.Context) error {
+		names := make([]string, 0, len(s.datasourceConfig))
+		for name := range s.datasourceConfig {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		statuses = make([]DatasourceStatus, 0, len(names))
+		for _, name := range names {
+			statuses = append(statuses, s.datasourceStatus(name))
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Datasource returns the status of the named datasource.
+func (s *Sensor) Datasource(ctx context.Context, name string) (DatasourceStatus, error) {
+	var status DatasourceStatus
+	err := s.do(ctx, func(ctx context.Context) error {
+		if _, ok := s.datasourceConfig[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrDatasourceNotFound, name)
+		}
+		status = s.datasourceStatus(name)
+		return nil
+	})
+	return status, err
+}
+
+// RestartDatasource stops the named datasource and schedules it to
+// run again right away, releasing it from quarantine. Disabled
+// datasources can't be restarted.
+func (s *Sensor) RestartDatasource(ctx context.Context, name string) error {
+	return s.do(ctx, func(ctx context.Context) error {
+		cfg, ok := s.datasourceConfig[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrDatasourceNotFound, name)
+		}
+		if !cfg.enabled || s.disabledLocally[name] {
+			return fmt.Errorf("datasource %s is disabled", name)
+		}
+		ds := s.getDatasource(ctx, name)
+		ds.stop()
+		ds.releaseQuarantine(ctx, "restarted locally")
+		// A restart requested by hand starts over with the base
+		// failure backoff.
+		ds.failureRetryTimer.Reset()
+		ds.handleDatasourceMessage(ctx, "Data source restart requested locally")
+		ds.scheduleRestart(ctx, 0)
+		return nil
+	})
+}
+
+// DisableDatasource stops the named datasource and keeps it from
+// running, whatever its config says, until EnableDatasource is called
+// for it. It isn't persisted: the datasource runs again if the sensor
+// restarts.
+func (s *Sensor) DisableDatasource(ctx context.Context, name string) error {
+	return s.do(ctx, func(ctx context.Context) error {
+		if _, ok := s.datasourceConfig[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrDatasourceNotFound, name)
+		}
+		if s.disabledLocally[name] {
+			return nil
+		}
+		s.disabledLocally[name] = true
+		if err := s.runDatasourceConfig(ctx, name); err != nil {
+			return err
+		}
+		s.getDatasource(ctx, name).handleDatasourceMessage(ctx,
+			"Data source disabled locally")
+		return nil
+	})
+}
+
+// EnableDatasource undoes DisableDatasource, running the named
+// datasource again if its config has it enabled.
+func (s *Sensor) EnableDatasource(ctx context.Context, name string) error {
+	return s.do(ctx, func(ctx context.Context) error {
+		if _, ok := s.datasourceConfig[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrDatasourceNotFound, name)
+		}
+		if !s.disabledLocally[name] {
+			return nil
+		}
+		delete(s.disabledLocally, name)
+		return s.runDatasourceConfig(ctx, name)
+	})
+}
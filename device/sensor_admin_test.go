@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/device/internal"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+)
+
+func TestSensorAdmin(t *testing.T) {
+	const deviceName = "dev1"
+	const deviceType = "admintest"
+
+	var lock sync.Mutex
+	var devices []*closingDevice
+	Register(deviceType, func(ctx context.Context, m map[string]string,
+		monitor provider.Monitor) (Device, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		d := newClosingDevice(m["id"])
+		devices = append(devices, d)
+		return d, nil
+	}, map[string]Option{
+		"id":       {Description: "device ID", Required: true},
+		"password": {Description: "password", Secret: true},
+	})
+	defer Unregister(deviceType)
+
+	gnmic := &internal.MockClient{
+		SubscribeStream: make(chan *internal.MockClientStream),
+		SetReq:          make(chan *gnmi.SetRequest, 1000),
+		SetResp:         make(chan *gnmi.SetResponse),
+	}
+	close(gnmic.SetResp)
+	sensor := NewSensor("default", 100.0, WithSensorClientFactory(
+		func(gc gnmi.GNMIClient, info *Info) cvclient.CVClient {
+			return newMockCVClient(gnmic, info, make(chan string, 100))
+		},
+	), WithSensorGNMIClient(gnmic))
+	sensor.clockSynced = true
+	sensor.datasourceConfig[deviceName] = &datasourceConfig{
+		name:       deviceName,
+		typ:        deviceType,
+		enabled:    true,
+		option:     map[string]string{"id": "1", "password": "secret"},
+		credential: map[string]string{},
+		loglevel:   logrus.InfoLevel,
+	}
+
+	// Stand in for the main loop of Run.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case request := <-sensor.adminRequests:
+				request(ctx)
+			case name := <-sensor.redeployDatasource:
+				if err := sensor.runDatasourceConfig(ctx, name); err != nil {
+					t.Error(err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// waitForDevice waits for the provider of the nth device to run.
+	waitForDevice := func(n int) *closingDevice {
+		t.Helper()
+		deadline := time.After(5 * time.Second)
+		for {
+			lock.Lock()
+			var last *closingDevice
+			if len(devices) == n {
+				last = devices[n-1]
+			}
+			lock.Unlock()
+			if last != nil && last.provider.running.Load() {
+				return last
+			}
+			select {
+			case <-deadline:
+				t.Fatalf("timed out waiting for device %d to run", n)
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	checkStatus := func(expected DatasourceStatus) {
+		t.Helper()
+		status, err := sensor.Datasource(ctx, deviceName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(status, expected) {
+			t.Errorf("expected status %+v, got %+v", expected, status)
+		}
+	}
+
+	statuses, err := sensor.Datasources(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := DatasourceStatus{
+		Name:        deviceName,
+		Type:        deviceType,
+		Enabled:     true,
+		Options:     map[string]string{"id": "1", "password": Redacted},
+		Credentials: map[string]string{},
+		LogLevel:    "info",
+	}
+	if !reflect.DeepEqual(statuses, []DatasourceStatus{expected}) {
+		t.Fatalf("expected statuses %+v, got %+v", expected, statuses)
+	}
+
+	// Restarting a datasource that isn't running starts it.
+	if err := sensor.RestartDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	first := waitForDevice(1)
+	expected.Running = true
+	expected.DeviceID = "1"
+	checkStatus(expected)
+
+	// Metrics of running datasources are reported with their type.
+	if err := sensor.do(ctx, func(ctx context.Context) error {
+		monitor := sensor.datasource[deviceName].monitor
+		if err := monitor.CreateMetric("polls", "Number", "polls made"); err != nil {
+			return err
+		}
+		return monitor.SetMetricInt("polls", 3)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	metrics, err := sensor.DatasourceMetrics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedMetrics := []DatasourceMetrics{{
+		Name: deviceName,
+		Type: deviceType,
+		Metrics: []Metric{
+			{Name: "polls", Unit: "Number", Description: "polls made", Value: int64(3)},
+		},
+	}}
+	if !reflect.DeepEqual(metrics, expectedMetrics) {
+		t.Errorf("expected metrics %+v, got %+v", expectedMetrics, metrics)
+	}
+
+	// Restarts replace the device.
+	if err := sensor.RestartDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	second := waitForDevice(2)
+	first.checkClosed(t, 1)
+
+	if err := sensor.DisableDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	second.checkClosed(t, 1)
+	expected.Running = false
+	expected.DisabledLocally = true
+	checkStatus(expected)
+	if err := sensor.RestartDatasource(ctx, deviceName); err == nil {
+		t.Error("expected error restarting disabled datasource")
+	}
+
+	if err := sensor.EnableDatasource(ctx, deviceName); err != nil {
+		t.Fatal(err)
+	}
+	third := waitForDevice(3)
+	expected.Running = true
+	expected.DisabledLocally = false
+	checkStatus(expected)
+
+	for name, f := range map[string]func(context.Context, string) error{
+		"restart": sensor.RestartDatasource,
+		"disable": sensor.DisableDatasource,
+		"enable":  sensor.EnableDatasource,
+		"get": func(ctx context.Context, name string) error {
+			_, err := sensor.Datasource(ctx, name)
+			return err
+		},
+	} {
+		if err := f(ctx, "nope"); !errors.Is(err, ErrDatasourceNotFound) {
+			t.Errorf("%s: expected not found error, got %v", name, err)
+		}
+	}
+
+	sensor.removeDatasource(ctx, deviceName)
+	third.checkClosed(t, 1)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"sort"
+)
+
+// Metric is a snapshot of a metric created through a
+// provider.MetricCollector.
+type Metric struct {
+	Name        string
+	Unit        string
+	Description string
+	// Value is an int64, a float64 or a string, or nil if the metric
+	// wasn't set yet.
+	Value any
+}
+
+// DatasourceMetrics are the metrics of a datasource.
+type DatasourceMetrics struct {
+	Name    string
+	Type    string
+	Metrics []Metric
+}
+
+// snapshot returns the metrics of m, sorted by name.
+func (m *metricCollector) snapshot() []Metric {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	metrics := make([]Metric, 0, len(m.metricMap))
+	for name, info := range m.metricMap {
+		metrics = append(metrics, Metric{
+			Name:        name,
+			Unit:        info.unit,
+			Description: info.description,
+			Value:       info.value,
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+// DatasourceMetrics returns the metrics the sensor's datasources
+// created through their monitors, sorted by datasource name.
+func (s *Sensor) DatasourceMetrics(ctx context.Context) ([]DatasourceMetrics, error) {
+	var metrics []DatasourceMetrics
+	err := s.do(ctx, func(ctx context.Context) error {
+		for name, ds := range s.datasource {
+			if ds.monitor == nil {
+				continue
+			}
+			metrics = append(metrics, DatasourceMetrics{
+				Name:    name,
+				Type:    ds.config.typ,
+				Metrics: ds.monitor.metricCollector.snapshot(),
+			})
+		}
+		return nil
+	})
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics, err
+}
+
+// SensorPodMetrics returns the runtime stats of the sensor process, as
+// published by the sensor to CloudVision.
+func SensorPodMetrics() []Metric {
+	stats := fetchSensorPodStats()
+	metrics := make([]Metric, 0, len(sensorPodMetrics))
+	for _, m := range sensorPodMetrics {
+		metrics = append(metrics, Metric{
+			Name:        m.name,
+			Unit:        m.unit,
+			Description: m.description,
+			Value:       stats[m.name],
+		})
+	}
+	return metrics
+}
@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -726,6 +727,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -776,6 +778,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -790,6 +793,7 @@ func TestSensor(t *testing.T) {
 				},
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -850,6 +854,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -864,6 +869,7 @@ func TestSensor(t *testing.T) {
 				},
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -921,6 +927,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -935,6 +942,7 @@ func TestSensor(t *testing.T) {
 				},
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -973,6 +981,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1014,6 +1023,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1049,6 +1059,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "bad1", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("invalidtype")),
@@ -1066,6 +1077,7 @@ func TestSensor(t *testing.T) {
 				},
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1101,6 +1113,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "bad1", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("invalidtype")),
@@ -1118,6 +1131,7 @@ func TestSensor(t *testing.T) {
 				},
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1153,6 +1167,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1178,6 +1193,7 @@ func TestSensor(t *testing.T) {
 				// Should eventually be restarted, and we want to see the retry time increase
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1222,6 +1238,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1264,6 +1281,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1309,6 +1327,7 @@ func TestSensor(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "xyz", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1378,6 +1397,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1421,6 +1441,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1486,6 +1507,7 @@ func TestSensor(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1567,6 +1589,7 @@ func TestSensor(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "xyz", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1629,6 +1652,7 @@ func TestSensor(t *testing.T) {
 						},
 						{
 							Prefix: datasourcePath("state", "abc", "xyz", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1723,6 +1747,7 @@ func TestSensor(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "device1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1754,6 +1779,7 @@ func TestSensor(t *testing.T) {
 					expectSet: []*gnmi.SetRequest{
 						{
 							Prefix: datasourcePath("state", "abc", "device1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1794,6 +1820,7 @@ func TestSensor(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "xyz", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -1853,6 +1880,7 @@ func TestSensor(t *testing.T) {
 				initialSetReq("abc", nil),
 				{
 					Prefix: datasourcePath("state", "abc", "xyz", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2324,6 +2352,7 @@ func TestSensorWithSkipSubscribe(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2416,6 +2445,7 @@ func TestSensorWithSkipSubscribe(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("")),
@@ -2447,6 +2477,7 @@ func TestSensorWithSkipSubscribe(t *testing.T) {
 					expectSet: []*gnmi.SetRequest{
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2505,6 +2536,7 @@ func TestSensorWithSkipSubscribe(t *testing.T) {
 						}),
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2566,6 +2598,7 @@ func TestSensorWithSkipSubscribe(t *testing.T) {
 						}),
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2604,6 +2637,7 @@ func TestSensorWithSkipSubscribe(t *testing.T) {
 					expectSet: []*gnmi.SetRequest{
 						{
 							Prefix: datasourcePath("state", "abc", "device-2", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2702,6 +2736,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-2
 						{
 							Prefix: datasourcePath("state", "abc", "device-2", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2770,6 +2805,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-3 updates
 						{
 							Prefix: datasourcePath("state", "abc", "device-3", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2828,6 +2864,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-2 updates
 						{
 							Prefix: datasourcePath("state", "abc", "device-2", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2876,6 +2913,7 @@ func TestSensorWithLimit(t *testing.T) {
 				// device-1
 				{
 					Prefix: datasourcePath("state", "abc", "device-1", ""),
+					Delete: []*gnmi.Path{stopReasonKey},
 					Update: []*gnmi.Update{
 						pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 						pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2933,6 +2971,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-1
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2948,6 +2987,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-2
 						{
 							Prefix: datasourcePath("state", "abc", "device-2", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -2991,6 +3031,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device1 updates
 						{
 							Prefix: datasourcePath("state", "abc", "device1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3050,6 +3091,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device3
 						{
 							Prefix: datasourcePath("state", "abc", "device3", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3113,6 +3155,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device1 starts running
 						{
 							Prefix: datasourcePath("state", "abc", "device1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3172,6 +3215,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device1 updates
 						{
 							Prefix: datasourcePath("state", "abc", "device1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3187,6 +3231,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-2
 						{
 							Prefix: datasourcePath("state", "abc", "device-2", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3228,6 +3273,7 @@ func TestSensorWithLimit(t *testing.T) {
 						initialSetReq("abc", nil),
 						{
 							Prefix: datasourcePath("state", "abc", "xyz", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3292,6 +3338,7 @@ func TestSensorWithLimit(t *testing.T) {
 						},
 						{
 							Prefix: datasourcePath("state", "abc", "xyz", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3337,6 +3384,7 @@ func TestSensorWithLimit(t *testing.T) {
 						// device-1
 						{
 							Prefix: datasourcePath("state", "abc", "device-1", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("invalidType")),
@@ -3370,6 +3418,7 @@ func TestSensorWithLimit(t *testing.T) {
 					expectSet: []*gnmi.SetRequest{
 						{
 							Prefix: datasourcePath("state", "abc", "device-2", ""),
+							Delete: []*gnmi.Path{stopReasonKey},
 							Update: []*gnmi.Update{
 								pgnmi.Update(lastErrorKey, agnmi.TypedValue("Datasource started")),
 								pgnmi.Update(pgnmi.Path("type"), agnmi.TypedValue("mock")),
@@ -3760,3 +3809,92 @@ func TestSensorMetadataProvider(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDatasourceClose(t *testing.T) {
+	const deviceName = "dev1"
+	const deviceType = "closing"
+
+	var lock sync.Mutex
+	var devices []*closingDevice
+	Register(deviceType, func(ctx context.Context, m map[string]string,
+		monitor provider.Monitor) (Device, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		d := newClosingDevice(m["id"])
+		devices = append(devices, d)
+		return d, nil
+	}, map[string]Option{"id": {Description: "device ID", Required: true}})
+	defer Unregister(deviceType)
+
+	gnmic := &internal.MockClient{
+		SubscribeStream: make(chan *internal.MockClientStream),
+		SetReq:          make(chan *gnmi.SetRequest, 1000),
+		SetResp:         make(chan *gnmi.SetResponse),
+	}
+	close(gnmic.SetResp)
+	sensor := NewSensor("default", 100.0, WithSensorClientFactory(
+		func(gc gnmi.GNMIClient, info *Info) cvclient.CVClient {
+			return newMockCVClient(gnmic, info, make(chan string, 100))
+		},
+	), WithSensorGNMIClient(gnmic))
+	sensor.clockSynced = true
+	cfg := &datasourceConfig{
+		name:       deviceName,
+		typ:        deviceType,
+		enabled:    true,
+		option:     map[string]string{"id": "1"},
+		credential: map[string]string{},
+	}
+	sensor.datasourceConfig[deviceName] = cfg
+
+	ctx := context.Background()
+	// deploy runs the current config and waits for its device's
+	// provider to start.
+	deploy := func(expectDevices int) *closingDevice {
+		t.Helper()
+		if err := sensor.runDatasourceConfig(ctx, deviceName); err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.After(5 * time.Second)
+		for {
+			lock.Lock()
+			n := len(devices)
+			var last *closingDevice
+			if n > 0 {
+				last = devices[n-1]
+			}
+			lock.Unlock()
+			if n == expectDevices && last.provider.running.Load() {
+				return last
+			}
+			select {
+			case <-deadline:
+				t.Fatalf("timed out waiting for device %d to run", expectDevices)
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+
+	first := deploy(1)
+
+	// Redeploying the same config keeps the same device.
+	deploy(1)
+	first.checkClosed(t, 0)
+
+	// Changing the config replaces the device, closing the old one.
+	cfg.option["id"] = "2"
+	second := deploy(2)
+	first.checkClosed(t, 1)
+	second.checkClosed(t, 0)
+
+	// Scheduled restarts create a new device too.
+	cfg.forceupdate++
+	third := deploy(3)
+	second.checkClosed(t, 1)
+
+	// Removing the datasource stops and closes its device.
+	sensor.removeDatasource(ctx, deviceName)
+	first.checkClosed(t, 1)
+	second.checkClosed(t, 1)
+	third.checkClosed(t, 1)
+}
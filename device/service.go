@@ -6,13 +6,133 @@ package device
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"sync"
 
 	"github.com/aristanetworks/cloudvision-go/device/gen"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type inventoryService struct {
-	inventory Inventory
+	gen.UnimplementedDeviceInventoryServer
+
+	inventory  Inventory
+	configFile string
+	// lock serializes changes so that they're persisted in order.
+	lock sync.Mutex
+}
+
+// InventoryServiceOption configures an inventory service.
+type InventoryServiceOption func(*inventoryService)
+
+// WithInventoryServiceConfigFile makes the inventory service save
+// the devices it adds, updates and deletes to the config file or
+// directory at the specified path. Configs are matched by name and
+// changed in the file defining them, which may be an included one,
+// and the other entries of the files are left alone. Members of
+// device groups can't be changed, since they don't have entries of
+// their own. Secrets aren't saved in clear text, so devices whose
+// secret options or credentials aren't references to environment
+// variables kept from the config files can't be saved. Devices are
+// added to the config file, so they can't be added when the path is
+// a directory.
+func WithInventoryServiceConfigFile(path string) InventoryServiceOption {
+	return func(i *inventoryService) {
+		i.configFile = path
+	}
+}
+
+// newServiceConfig returns the config of a device created by the
+// service.
+func newServiceConfig(name, deviceType string, options map[string]string) *Config {
+	if len(options) == 0 {
+		// Empty options aren't written to the config file.
+		options = nil
+	}
+	return &Config{
+		Name:    name,
+		Device:  deviceType,
+		Options: options,
+		Enabled: true,
+	}
+}
+
+// prepareUpdate prepares the update of the config files saving the
+// config with the specified name, or deleting it if config is nil,
+// and returns nil if the service doesn't save configs. It's called
+// before the inventory is changed, so that changes that can't be
+// saved fail first.
+func (i *inventoryService) prepareUpdate(name string, config *Config,
+	adding bool) (*configFileUpdate, error) {
+	if i.configFile == "" {
+		return nil, nil
+	}
+	file, err := i.configFileOf(name, adding)
+	if err != nil {
+		return nil, err
+	}
+	update, err := prepareConfigFileUpdate(file, name, config)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"failed to update config file: %v", err)
+	}
+	return update, nil
+}
+
+// configFileOf returns the config file the config with the specified
+// name is saved to. Only configs being added may be missing from the
+// config files.
+func (i *inventoryService) configFileOf(name string, adding bool) (string, error) {
+	source, err := findConfig(i.configFile, name)
+	if err != nil {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"failed to read config file: %v", err)
+	}
+	if source.group != "" {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"config %s is a member of device group %s defined at %s, "+
+				"which must be changed in the config file", name, source.group, source)
+	}
+	if source.file != "" {
+		return source.file, nil
+	}
+	if !adding {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"config %s isn't defined in config file %s", name, i.configFile)
+	}
+	if info, err := os.Stat(i.configFile); err == nil && info.IsDir() {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"can't add config %s to config directory %s", name, i.configFile)
+	}
+	return i.configFile, nil
+}
+
+// persist makes an update prepared by prepareUpdate.
+func (i *inventoryService) persist(update *configFileUpdate) error {
+	if update == nil {
+		return nil
+	}
+	if err := update.write(); err != nil {
+		return fmt.Errorf("failed to update config file: %w", err)
+	}
+	return nil
+}
+
+// persisted returns the part of a config that's saved to the config
+// file.
+func persisted(c *Config) *Config {
+	return &Config{
+		Name:        c.Name,
+		Device:      c.Device,
+		NoStream:    c.NoStream,
+		Options:     c.Options,
+		LogLevel:    c.LogLevel,
+		Credentials: c.Credentials,
+	}
 }
 
 func (i *inventoryService) Add(ctx context.Context,
@@ -20,30 +140,56 @@ func (i *inventoryService) Add(ctx context.Context,
 	logrus.Infof("InventoryService: Add request for device type: %s",
 		req.DeviceConfig.DeviceType)
 
-	info, err := NewDeviceInfo(ctx,
-		&Config{
-			Device:  req.DeviceConfig.DeviceType,
-			Options: req.DeviceConfig.Options,
-		}, nil)
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	config := newServiceConfig(req.DeviceConfig.Name, req.DeviceConfig.DeviceType,
+		req.DeviceConfig.Options)
+	info, err := NewDeviceInfo(ctx, config, nil)
 	if err != nil {
 		logrus.Errorf("InventoryService: error creating DeviceInfo: %s", err)
 		return nil, err
 	}
+	if config.Name == "" {
+		config.Name = info.ID
+	}
+	update, err := i.prepareUpdate(config.Name, persisted(config), true)
+	if err != nil {
+		return nil, err
+	}
 	if err := i.inventory.Add(info); err != nil {
 		return nil, err
 	}
+	if err := i.persist(update); err != nil {
+		return nil, err
+	}
 	return &gen.AddResponse{
-		DeviceInfo: &gen.DeviceInfo{
-			DeviceConfig: req.DeviceConfig,
-			DeviceID:     info.ID,
-		},
+		DeviceInfo: i.newGenDeviceInfo(info),
 	}, nil
 }
 
 func (i *inventoryService) Delete(ctx context.Context,
 	req *gen.DeleteRequest) (*gen.DeleteResponse, error) {
 	logrus.Infof("InventoryService: Delete request: %v", req)
-	return &gen.DeleteResponse{}, i.inventory.Delete(req.DeviceID)
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	info, err := i.inventory.Get(req.DeviceID)
+	if err != nil {
+		// Deleting a device that's not there is a no-op.
+		return &gen.DeleteResponse{}, i.inventory.Delete(req.DeviceID)
+	}
+	var update *configFileUpdate
+	if info.Config != nil && info.Config.Name != "" {
+		if update, err = i.prepareUpdate(info.Config.Name, nil, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := i.inventory.Delete(req.DeviceID); err != nil {
+		return nil, err
+	}
+	if err := i.persist(update); err != nil {
+		return nil, err
+	}
+	return &gen.DeleteResponse{}, nil
 }
 
 func (i *inventoryService) Get(ctx context.Context,
@@ -55,7 +201,7 @@ func (i *inventoryService) Get(ctx context.Context,
 	if err != nil {
 		return ret, err
 	}
-	ret.DeviceInfo = newGenDeviceInfo(info)
+	ret.DeviceInfo = i.newGenDeviceInfo(info)
 	return ret, nil
 }
 
@@ -64,23 +210,131 @@ func (i *inventoryService) List(ctx context.Context,
 	ret := &gen.ListResponse{}
 	infos := i.inventory.List()
 	for _, info := range infos {
-		ret.DeviceInfos = append(ret.DeviceInfos, newGenDeviceInfo(info))
+		ret.DeviceInfos = append(ret.DeviceInfos, i.newGenDeviceInfo(info))
 	}
 	return ret, nil
 }
 
-func newGenDeviceInfo(info *Info) *gen.DeviceInfo {
+func (i *inventoryService) Update(ctx context.Context,
+	req *gen.UpdateRequest) (*gen.UpdateResponse, error) {
+	logrus.Infof("InventoryService: Update request for device: %s", req.DeviceID)
+	if req.DeviceConfig == nil {
+		return nil, status.Error(codes.InvalidArgument, "no device config in update")
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	old, err := i.inventory.Get(req.DeviceID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	deviceType := req.DeviceConfig.DeviceType
+	name := req.DeviceID
+	if old.Config != nil {
+		if deviceType == "" {
+			deviceType = old.Config.Device
+		}
+		if old.Config.Name != "" {
+			name = old.Config.Name
+		}
+	}
+	// Get and List redact secret options, so keep the values of any
+	// that are passed back as is.
+	options := make(map[string]string, len(req.DeviceConfig.Options))
+	for k, v := range req.DeviceConfig.Options {
+		if v == Redacted && IsSecretOption(deviceType, k) && old.Config != nil {
+			v = old.Config.Options[k]
+		}
+		options[k] = v
+	}
+	config := newServiceConfig(name, deviceType, options)
+	if old.Config != nil {
+		config.NoStream = old.Config.NoStream
+		config.LogLevel = old.Config.LogLevel
+		config.Credentials = old.Config.Credentials
+	}
+	info, err := NewDeviceInfo(ctx, config, nil)
+	if err != nil {
+		logrus.Errorf("InventoryService: error creating DeviceInfo: %s", err)
+		return nil, err
+	}
+	update, err := i.prepareUpdate(name, persisted(config), false)
+	if err != nil {
+		return nil, err
+	}
+	// Adding a device with the same ID replaces it.
+	if err := i.inventory.Add(info); err != nil {
+		return nil, err
+	}
+	if info.ID != req.DeviceID {
+		if err := i.inventory.Delete(req.DeviceID); err != nil {
+			return nil, err
+		}
+	}
+	if err := i.persist(update); err != nil {
+		return nil, err
+	}
+	return &gen.UpdateResponse{
+		DeviceInfo: i.newGenDeviceInfo(info),
+	}, nil
+}
+
+func (i *inventoryService) Watch(req *gen.WatchRequest,
+	stream gen.DeviceInventory_WatchServer) error {
+	watcher, ok := i.inventory.(InventoryWatcher)
+	if !ok {
+		return status.Error(codes.Unimplemented, "inventory can't be watched")
+	}
+	ctx := stream.Context()
+	for event := range watcher.Watch(ctx) {
+		if err := stream.Send(&gen.WatchResponse{
+			Type:       gen.WatchResponse_Type(event.Type),
+			DeviceInfo: newGenDeviceInfo(event.Info, event.State),
+		}); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return status.Error(codes.ResourceExhausted, "watch fell too far behind")
+}
+
+// newGenDeviceInfo returns the gen.DeviceInfo of a device in the
+// service's inventory.
+func (i *inventoryService) newGenDeviceInfo(info *Info) *gen.DeviceInfo {
+	var state DeviceState
+	if watcher, ok := i.inventory.(InventoryWatcher); ok {
+		state, _ = watcher.State(info.ID)
+	}
+	return newGenDeviceInfo(info, state)
+}
+
+func newGenDeviceInfo(info *Info, state DeviceState) *gen.DeviceInfo {
 	ret := &gen.DeviceInfo{}
 	ret.DeviceID = info.ID
+	ret.Status = string(info.Status)
+	if !state.LastHeartbeat.IsZero() {
+		ret.LastHeartbeat = timestamppb.New(state.LastHeartbeat)
+	}
+	if state.LastError != nil {
+		ret.LastError = state.LastError.Error()
+	}
 	if info.Config == nil {
 		return ret
 	}
 	ret.DeviceConfig = &gen.DeviceConfig{DeviceType: info.Config.Device,
-		Options: info.Config.Options}
+		Options: RedactedOptions(info.Config.Device, info.Config.Options),
+		Name:    info.Config.Name}
 	return ret
 }
 
 // NewInventoryService returns a protobuf DeviceInventoryServer from an Inventory.
-func NewInventoryService(inventory Inventory) gen.DeviceInventoryServer {
-	return &inventoryService{inventory: inventory}
+func NewInventoryService(inventory Inventory,
+	options ...InventoryServiceOption) gen.DeviceInventoryServer {
+	i := &inventoryService{inventory: inventory}
+	for _, opt := range options {
+		opt(i)
+	}
+	return i
 }
@@ -0,0 +1,397 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	v1client "github.com/aristanetworks/cloudvision-go/device/cvclient/v1"
+	"github.com/aristanetworks/cloudvision-go/device/gen"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// failingProvider fails with its error, if it has one, and otherwise
+// runs until canceled.
+type failingProvider struct {
+	err error
+}
+
+func (p *failingProvider) InitGNMI(client gnmi.GNMIClient) {}
+func (p *failingProvider) OpenConfig() bool                { return true }
+func (p *failingProvider) Origin() string                  { return "" }
+
+func (p *failingProvider) Run(ctx context.Context) error {
+	if p.err != nil {
+		return p.err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+type serviceTestDevice struct {
+	testDevice
+	provider *failingProvider
+}
+
+func (d *serviceTestDevice) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{d.provider}, nil
+}
+
+func newServiceTestDevice(ctx context.Context, options map[string]string,
+	monitor provider.Monitor) (Device, error) {
+	d := &serviceTestDevice{
+		testDevice: testDevice{deviceID: options["id"]},
+		provider:   &failingProvider{},
+	}
+	if options["fail"] != "" {
+		d.provider.err = errors.New(options["fail"])
+	}
+	return d, nil
+}
+
+func TestInventoryService(t *testing.T) {
+	Register("servicetest", newServiceTestDevice, map[string]Option{
+		"id":       {Description: "device ID", Required: true},
+		"password": {Description: "password", Secret: true},
+		"fail":     {Description: "provider error"},
+	})
+	defer Unregister("servicetest")
+
+	const password = "s3cret-pw"
+	t.Setenv("SERVICE_TEST_PASSWORD", password)
+	configFile := filepath.Join(t.TempDir(), "configs.yaml")
+	other := &Config{
+		Name:    "other",
+		Device:  "servicetest",
+		Options: map[string]string{"id": "other"},
+	}
+	if err := WriteConfigs(configFile, []*Config{other}); err != nil {
+		t.Fatal(err)
+	}
+	checkConfigs := func(expected ...*Config) {
+		t.Helper()
+		configs, err := ReadConfigs(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(configs) != len(expected) {
+			t.Fatalf("expected configs %v, got %v", expected, configs)
+		}
+		for i, c := range configs {
+			if !c.Equal(expected[i]) {
+				t.Fatalf("expected config %v, got %v", expected[i], c)
+			}
+		}
+	}
+
+	processor := func(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return nil, nil
+	}
+	inv := NewInventory(context.Background(), pgnmi.NewSimpleGNMIClient(processor),
+		func(gc gnmi.GNMIClient, i *Info) cvclient.CVClient {
+			return v1client.NewV1Client(gc, i.ID, false)
+		})
+	server := grpc.NewServer()
+	gen.RegisterDeviceInventoryServer(server,
+		NewInventoryService(inv, WithInventoryServiceConfigFile(configFile)))
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+	conn, err := grpc.Dial(listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := gen.NewDeviceInventoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.Add(ctx, &gen.AddRequest{DeviceConfig: &gen.DeviceConfig{
+		DeviceType: "servicetest",
+		Options:    map[string]string{"id": "dev0"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	// Devices added without a name are saved under their device ID.
+	dev0 := &Config{
+		Name:    "dev0",
+		Device:  "servicetest",
+		Options: map[string]string{"id": "dev0"},
+	}
+	checkConfigs(other, dev0)
+
+	watch, err := client.Watch(ctx, &gen.WatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEvent := func(typ gen.WatchResponse_Type, id string) *gen.DeviceInfo {
+		t.Helper()
+		resp, err := watch.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Type != typ || resp.DeviceInfo.DeviceID != id {
+			t.Fatalf("expected %v event for %s, got %v", typ, id, resp)
+		}
+		return resp.DeviceInfo
+	}
+	// Watches start with the devices already in the inventory.
+	expectEvent(gen.WatchResponse_EXISTING, "dev0")
+
+	// Secrets aren't saved in clear text, so devices with secrets given
+	// through the service can't be added.
+	_, err = client.Add(ctx, &gen.AddRequest{DeviceConfig: &gen.DeviceConfig{
+		Name:       "dev",
+		DeviceType: "servicetest",
+		Options:    map[string]string{"id": "dev1", "password": password},
+	}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition adding device with a secret, got %v", err)
+	}
+	if _, err := inv.Get("dev1"); err == nil {
+		t.Fatal("device with unsaved secret added to inventory")
+	}
+	checkConfigs(other, dev0)
+
+	// Devices whose secrets reference environment variables in the
+	// config file can be changed.
+	f, err := os.OpenFile(configFile, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.WriteString("- Name: dev\n  Device: servicetest\n  Options:\n" +
+		"    id: dev1\n    password: ${SERVICE_TEST_PASSWORD}\n")
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := &Config{
+		Name:    "dev",
+		Device:  "servicetest",
+		Options: map[string]string{"id": "dev1", "password": password},
+	}
+	checkConfigs(other, dev0, dev)
+	info, err := NewDeviceInfo(ctx, dev, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.Add(info); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(gen.WatchResponse_ADDED, "dev1")
+
+	get, err := client.Get(ctx, &gen.GetRequest{DeviceID: "dev1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOptions := map[string]string{"id": "dev1", "password": Redacted}
+	if info := get.DeviceInfo; info.DeviceConfig.Name != "dev" ||
+		!reflect.DeepEqual(info.DeviceConfig.Options, expectedOptions) ||
+		info.Status != "" || info.LastError != "" {
+		t.Fatalf("unexpected device info %v", info)
+	}
+
+	// Updates keep redacted secrets and report provider errors.
+	_, err = client.Update(ctx, &gen.UpdateRequest{
+		DeviceID: "dev1",
+		DeviceConfig: &gen.DeviceConfig{
+			Options: map[string]string{"id": "dev1", "password": Redacted, "fail": "boom"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(gen.WatchResponse_UPDATED, "dev1")
+	if info := expectEvent(gen.WatchResponse_UPDATED, "dev1"); info.LastError != "boom" {
+		t.Fatalf("expected provider error in %v", info)
+	}
+	dev.Options = map[string]string{"id": "dev1", "password": password, "fail": "boom"}
+	checkConfigs(other, dev0, dev)
+	// The secret is saved as the reference it was read from.
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), password) ||
+		!strings.Contains(string(data), "${SERVICE_TEST_PASSWORD}") {
+		t.Fatalf("expected secret saved as a reference in config file:\n%s", data)
+	}
+	get, err = client.Get(ctx, &gen.GetRequest{DeviceID: "dev1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.DeviceInfo.LastError != "boom" {
+		t.Fatalf("expected provider error in %v", get.DeviceInfo)
+	}
+
+	// Changed secrets can't be saved.
+	_, err = client.Update(ctx, &gen.UpdateRequest{
+		DeviceID: "dev1",
+		DeviceConfig: &gen.DeviceConfig{
+			Options: map[string]string{"id": "dev1", "password": "changed"},
+		},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition changing a secret, got %v", err)
+	}
+	checkConfigs(other, dev0, dev)
+
+	_, err = client.Update(ctx, &gen.UpdateRequest{
+		DeviceID:     "nope",
+		DeviceConfig: &gen.DeviceConfig{},
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound updating missing device, got %v", err)
+	}
+
+	if _, err := client.Delete(ctx, &gen.DeleteRequest{DeviceID: "dev1"}); err != nil {
+		t.Fatal(err)
+	}
+	if info := expectEvent(gen.WatchResponse_DELETED, "dev1"); info.Status !=
+		string(StatusRemoved) {
+		t.Fatalf("expected removed status in %v", info)
+	}
+	checkConfigs(other, dev0)
+}
+
+func TestInventoryServiceIncludedConfigs(t *testing.T) {
+	Register("servicetest", newServiceTestDevice, map[string]Option{
+		"id":   {Description: "device ID", Required: true},
+		"fail": {Description: "provider error"},
+	})
+	defer Unregister("servicetest")
+
+	dir := t.TempDir()
+	main := "- Name: top\n  Device: servicetest\n  Options:\n    id: top\n" +
+		"- Include: sites\n" +
+		"- Group: core\n  Device: servicetest\n  AddressOption: id\n" +
+		"  Members:\n  - 10.0.0.1\n"
+	writeTestFiles(t, dir, map[string]string{
+		"main.yaml":       main,
+		"sites/site.yaml": "- Name: inc\n  Device: servicetest\n  Options:\n    id: inc\n",
+	})
+	mainFile := filepath.Join(dir, "main.yaml")
+	siteFile := filepath.Join(dir, "sites", "site.yaml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	processor := func(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return nil, nil
+	}
+	newInventory := func() Inventory {
+		return NewInventory(ctx, pgnmi.NewSimpleGNMIClient(processor),
+			func(gc gnmi.GNMIClient, i *Info) cvclient.CVClient {
+				return v1client.NewV1Client(gc, i.ID, false)
+			})
+	}
+	inv := newInventory()
+	configs, err := ReadConfigs(mainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, config := range configs {
+		info, err := NewDeviceInfo(ctx, config, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inv.Add(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+	service := NewInventoryService(inv, WithInventoryServiceConfigFile(mainFile))
+
+	// Configs are updated and deleted in the included file defining
+	// them.
+	if _, err := service.Update(ctx, &gen.UpdateRequest{
+		DeviceID: "inc",
+		DeviceConfig: &gen.DeviceConfig{
+			Options: map[string]string{"id": "inc", "fail": "boom"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	configs, err = ReadConfigs(mainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 3 || configs[1].Options["fail"] != "boom" {
+		t.Fatalf("unexpected configs after update %v", configs)
+	}
+	if data, err := os.ReadFile(mainFile); err != nil || string(data) != main {
+		t.Fatalf("unexpected main config file %q (%v)", data, err)
+	}
+	if _, err := service.Delete(ctx, &gen.DeleteRequest{DeviceID: "inc"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(siteFile); err != nil || string(data) != "[]\n" {
+		t.Fatalf("unexpected site config file %q (%v)", data, err)
+	}
+
+	// Group members don't have entries of their own, so they can't be
+	// changed.
+	_, err = service.Update(ctx, &gen.UpdateRequest{
+		DeviceID:     "10.0.0.1",
+		DeviceConfig: &gen.DeviceConfig{Options: map[string]string{"id": "10.0.0.1"}},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition updating group member, got %v", err)
+	}
+	_, err = service.Delete(ctx, &gen.DeleteRequest{DeviceID: "10.0.0.1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition deleting group member, got %v", err)
+	}
+	if _, err := inv.Get("10.0.0.1"); err != nil {
+		t.Fatalf("expected group member in inventory: %v", err)
+	}
+	if data, err := os.ReadFile(mainFile); err != nil || string(data) != main {
+		t.Fatalf("unexpected main config file %q (%v)", data, err)
+	}
+
+	// Devices can't be added to a config directory, and the inventory
+	// isn't changed when the config file can't be.
+	inv = newInventory()
+	service = NewInventoryService(inv,
+		WithInventoryServiceConfigFile(filepath.Join(dir, "sites")))
+	_, err = service.Add(ctx, &gen.AddRequest{DeviceConfig: &gen.DeviceConfig{
+		DeviceType: "servicetest",
+		Options:    map[string]string{"id": "new"},
+	}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition adding to a directory, got %v", err)
+	}
+	if infos := inv.List(); len(infos) != 0 {
+		t.Fatalf("expected empty inventory, got %v", infos)
+	}
+}
+
+func TestNewGenDeviceInfo(t *testing.T) {
+	heartbeat := time.Unix(1700000000, 0)
+	info := newGenDeviceInfo(&Info{ID: "dev", Status: StatusActive},
+		DeviceState{LastHeartbeat: heartbeat, LastError: errors.New("oops")})
+	if info.Status != string(StatusActive) || info.LastError != "oops" ||
+		!info.LastHeartbeat.AsTime().Equal(heartbeat) {
+		t.Fatalf("unexpected device info %v", info)
+	}
+}
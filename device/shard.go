@@ -0,0 +1,331 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A ShardCoordinator lets sensor replicas sharing a sensor name split
+// its datasources between them. Replicas hold leases: one on their
+// membership, and one on each datasource they run. Leases that aren't
+// renewed before their TTL runs out expire, so that the datasources of
+// a replica that dies are taken over by the others.
+type ShardCoordinator interface {
+	// Heartbeat takes or renews the membership lease of replica and
+	// returns the replicas with live membership leases, sorted.
+	Heartbeat(ctx context.Context, replica string, ttl time.Duration) ([]string, error)
+	// Acquire takes or renews the lease of replica on the named
+	// datasource. It returns false if another replica holds a live
+	// lease on it.
+	Acquire(ctx context.Context, name, replica string, ttl time.Duration) (bool, error)
+	// Renew renews the leases of replica on the named datasources at
+	// once. It returns the names of those on which another replica
+	// holds a live lease, which replica no longer holds.
+	Renew(ctx context.Context, replica string, names []string,
+		ttl time.Duration) ([]string, error)
+	// Release gives up the lease of replica on the named datasource,
+	// if it holds it.
+	Release(ctx context.Context, name, replica string) error
+	// Leave gives up the membership lease of replica and all its
+	// datasource leases.
+	Leave(ctx context.Context, replica string) error
+}
+
+// shardLease is a lease held by a replica.
+type shardLease struct {
+	Replica string    `json:"replica"`
+	Expires time.Time `json:"expires"`
+}
+
+// shardState is the state of the leases of a ShardCoordinator.
+type shardState struct {
+	Members map[string]time.Time  `json:"members"`
+	Leases  map[string]shardLease `json:"leases"`
+}
+
+func (st *shardState) expire(now time.Time) {
+	for replica, expires := range st.Members {
+		if !now.Before(expires) {
+			delete(st.Members, replica)
+		}
+	}
+	for name, lease := range st.Leases {
+		if !now.Before(lease.Expires) {
+			delete(st.Leases, name)
+		}
+	}
+}
+
+func (st *shardState) heartbeat(now time.Time, replica string, ttl time.Duration) []string {
+	st.expire(now)
+	if st.Members == nil {
+		st.Members = map[string]time.Time{}
+	}
+	st.Members[replica] = now.Add(ttl)
+	members := make([]string, 0, len(st.Members))
+	for member := range st.Members {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+func (st *shardState) acquire(now time.Time, name, replica string, ttl time.Duration) bool {
+	st.expire(now)
+	if lease, ok := st.Leases[name]; ok && lease.Replica != replica {
+		return false
+	}
+	if st.Leases == nil {
+		st.Leases = map[string]shardLease{}
+	}
+	st.Leases[name] = shardLease{Replica: replica, Expires: now.Add(ttl)}
+	return true
+}
+
+func (st *shardState) renew(now time.Time, replica string, names []string,
+	ttl time.Duration) []string {
+	var lost []string
+	for _, name := range names {
+		if !st.acquire(now, name, replica, ttl) {
+			lost = append(lost, name)
+		}
+	}
+	return lost
+}
+
+func (st *shardState) release(name, replica string) {
+	if lease, ok := st.Leases[name]; ok && lease.Replica == replica {
+		delete(st.Leases, name)
+	}
+}
+
+func (st *shardState) leave(replica string) {
+	delete(st.Members, replica)
+	for name, lease := range st.Leases {
+		if lease.Replica == replica {
+			delete(st.Leases, name)
+		}
+	}
+}
+
+// memoryShardCoordinator keeps leases in memory, for replicas
+// running in the same process.
+type memoryShardCoordinator struct {
+	lock  sync.Mutex
+	state shardState
+}
+
+// NewMemoryShardCoordinator returns a ShardCoordinator for sensors
+// running in the same process, such as in tests.
+func NewMemoryShardCoordinator() ShardCoordinator {
+	return &memoryShardCoordinator{}
+}
+
+func (m *memoryShardCoordinator) Heartbeat(ctx context.Context, replica string,
+	ttl time.Duration) ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.state.heartbeat(time.Now(), replica, ttl), nil
+}
+
+func (m *memoryShardCoordinator) Acquire(ctx context.Context, name, replica string,
+	ttl time.Duration) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.state.acquire(time.Now(), name, replica, ttl), nil
+}
+
+func (m *memoryShardCoordinator) Renew(ctx context.Context, replica string, names []string,
+	ttl time.Duration) ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.state.renew(time.Now(), replica, names, ttl), nil
+}
+
+func (m *memoryShardCoordinator) Release(ctx context.Context, name, replica string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.state.release(name, replica)
+	return nil
+}
+
+func (m *memoryShardCoordinator) Leave(ctx context.Context, replica string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.state.leave(replica)
+	return nil
+}
+
+// shardRingPoints is the number of points each replica has on the
+// hash ring, which evens out the share of datasources each gets.
+const shardRingPoints = 64
+
+// hashRing assigns datasources to replicas by consistent hashing, so
+// that replicas joining or leaving only move the datasources they
+// take or give up.
+type hashRing struct {
+	points   []uint64
+	replicas map[uint64]string
+}
+
+func ringHash(s string) uint64 {
+	sum := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+func newHashRing(replicas []string) *hashRing {
+	r := &hashRing{replicas: make(map[uint64]string, len(replicas)*shardRingPoints)}
+	for _, replica := range replicas {
+		for i := 0; i < shardRingPoints; i++ {
+			h := ringHash(replica + "#" + strconv.Itoa(i))
+			r.points = append(r.points, h)
+			r.replicas[h] = replica
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// owner returns the replica the named datasource is assigned to, or
+// "" if there are no replicas.
+func (r *hashRing) owner(name string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := ringHash(name)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.replicas[r.points[i]]
+}
+
+// ownsDatasource reports whether the sensor should run the named
+// datasource: always if it isn't sharded, and otherwise only if the
+// hash ring assigns it to the sensor's replica and the replica holds
+// or can take its lease.
+func (s *Sensor) ownsDatasource(ctx context.Context, name string) bool {
+	if s.shardCoordinator == nil {
+		return true
+	}
+	if s.shardRing == nil || s.shardRing.owner(name) != s.shardReplica {
+		return false
+	}
+	if s.shardLeases[name] {
+		return true
+	}
+	ok, err := s.shardCoordinator.Acquire(ctx, name, s.shardReplica, s.shardLeaseTTL)
+	if err != nil {
+		s.log.Errorf("Failed to acquire lease on datasource %s: %v", name, err)
+		return false
+	}
+	if ok {
+		s.log.Infof("Replica %s acquired datasource %s", s.shardReplica, name)
+		s.shardLeases[name] = true
+	}
+	return ok
+}
+
+// releaseDatasource stops the named datasource, if it's running, and
+// gives up its lease so that another replica can run it.
+func (s *Sensor) releaseDatasource(ctx context.Context, name string) {
+	if ds, ok := s.datasource[name]; ok {
+		ds.stop()
+	}
+	if !s.shardLeases[name] {
+		return
+	}
+	delete(s.shardLeases, name)
+	s.log.Infof("Replica %s released datasource %s", s.shardReplica, name)
+	if err := s.shardCoordinator.Release(ctx, name, s.shardReplica); err != nil {
+		s.log.Errorf("Failed to release lease on datasource %s: %v", name, err)
+	}
+}
+
+// rebalanceShards renews the leases of the sensor's replica and
+// updates the datasources it runs to match the live replicas.
+func (s *Sensor) rebalanceShards(ctx context.Context) {
+	members, err := s.shardCoordinator.Heartbeat(ctx, s.shardReplica, s.shardLeaseTTL)
+	if err != nil {
+		// Without its membership lease, the replica's datasources
+		// may be taken over at any time, so give them all up.
+		s.log.Errorf("Shard heartbeat failed, stopping datasources: %v", err)
+		s.shardRing = nil
+	} else {
+		s.shardRing = newHashRing(members)
+	}
+
+	names := make([]string, 0, len(s.shardLeases)+len(s.datasourceConfig))
+	for name := range s.shardLeases {
+		if _, ok := s.datasourceConfig[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for name := range s.datasourceConfig {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var held []string
+	wasHeld := map[string]bool{}
+	for _, name := range names {
+		if !s.shardLeases[name] {
+			continue
+		}
+		_, ok := s.datasourceConfig[name]
+		if !ok || s.shardRing == nil || s.shardRing.owner(name) != s.shardReplica {
+			s.releaseDatasource(ctx, name)
+			continue
+		}
+		held = append(held, name)
+		wasHeld[name] = true
+	}
+	s.renewShardLeases(ctx, held)
+	// Leases just lost aren't acquired again until the next rebalance.
+	for _, name := range names {
+		if _, ok := s.datasourceConfig[name]; !ok || wasHeld[name] {
+			continue
+		}
+		if s.ownsDatasource(ctx, name) {
+			if err := s.runDatasourceConfig(ctx, name); err != nil {
+				s.log.Errorf("Failed to run datasource %s: %v", name, err)
+			}
+		}
+	}
+}
+
+// renewShardLeases renews the leases of the sensor's replica on the
+// named datasources, and releases those it lost.
+func (s *Sensor) renewShardLeases(ctx context.Context, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	lost, err := s.shardCoordinator.Renew(ctx, s.shardReplica, names, s.shardLeaseTTL)
+	if err != nil {
+		s.log.Errorf("Failed to renew leases on datasources: %v", err)
+		lost = names
+	}
+	for _, name := range lost {
+		s.log.Errorf("Lost lease on datasource %s", name)
+		s.releaseDatasource(ctx, name)
+	}
+}
+
+// leaveShards gives up the leases of the sensor's replica, once its
+// datasources are stopped.
+func (s *Sensor) leaveShards() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shardLeaseTTL)
+	defer cancel()
+	if err := s.shardCoordinator.Leave(ctx, s.shardReplica); err != nil {
+		s.log.Errorf("Failed to leave shard coordinator: %v", err)
+	}
+	s.shardLeases = map[string]bool{}
+}
@@ -0,0 +1,425 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/device/internal"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+)
+
+// fileShardCoordinator keeps leases in a JSON file, serializing
+// updates with a lock file next to it. A lock file left behind by a
+// process that died holding it is never broken, so it's only fit for
+// tests.
+type fileShardCoordinator struct {
+	path string
+}
+
+func newFileShardCoordinator(path string) ShardCoordinator {
+	return &fileShardCoordinator{path: path}
+}
+
+// lock takes the lock file, returning a function releasing it.
+func (f *fileShardCoordinator) lock(ctx context.Context) (func(), error) {
+	lockPath := f.path + ".lock"
+	for {
+		lf, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			lf.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock shard state: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// update applies f to the state in the file.
+func (f *fileShardCoordinator) update(ctx context.Context, fn func(st *shardState)) error {
+	unlock, err := f.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var st shardState
+	b, err := os.ReadFile(f.path)
+	if err == nil {
+		if err := json.Unmarshal(b, &st); err != nil {
+			return fmt.Errorf("failed to read shard state %s: %w", f.path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	fn(&st)
+	if b, err = json.Marshal(&st); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *fileShardCoordinator) Heartbeat(ctx context.Context, replica string,
+	ttl time.Duration) ([]string, error) {
+	var members []string
+	err := f.update(ctx, func(st *shardState) {
+		members = st.heartbeat(time.Now(), replica, ttl)
+	})
+	return members, err
+}
+
+func (f *fileShardCoordinator) Acquire(ctx context.Context, name, replica string,
+	ttl time.Duration) (bool, error) {
+	var ok bool
+	err := f.update(ctx, func(st *shardState) {
+		ok = st.acquire(time.Now(), name, replica, ttl)
+	})
+	return ok, err
+}
+
+func (f *fileShardCoordinator) Renew(ctx context.Context, replica string, names []string,
+	ttl time.Duration) ([]string, error) {
+	var lost []string
+	err := f.update(ctx, func(st *shardState) {
+		lost = st.renew(time.Now(), replica, names, ttl)
+	})
+	return lost, err
+}
+
+func (f *fileShardCoordinator) Release(ctx context.Context, name, replica string) error {
+	return f.update(ctx, func(st *shardState) { st.release(name, replica) })
+}
+
+func (f *fileShardCoordinator) Leave(ctx context.Context, replica string) error {
+	return f.update(ctx, func(st *shardState) { st.leave(replica) })
+}
+
+func TestShardCoordinators(t *testing.T) {
+	for name, c := range map[string]ShardCoordinator{
+		"memory": NewMemoryShardCoordinator(),
+		"file":   newFileShardCoordinator(filepath.Join(t.TempDir(), "shards.json")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			const ttl = 200 * time.Millisecond
+			for _, replica := range []string{"r1", "r0"} {
+				if _, err := c.Heartbeat(ctx, replica, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			}
+			members, err := c.Heartbeat(ctx, "r2", ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := []string{"r0", "r1", "r2"}; !reflect.DeepEqual(members, expected) {
+				t.Fatalf("expected members %v, got %v", expected, members)
+			}
+
+			acquire := func(name, replica string, expected bool) {
+				t.Helper()
+				ok, err := c.Acquire(ctx, name, replica, ttl)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ok != expected {
+					t.Fatalf("%s acquiring %s: expected %t, got %t", replica, name, expected, ok)
+				}
+			}
+			acquire("ds1", "r2", true)
+			acquire("ds1", "r2", true) // renewal
+			acquire("ds1", "r0", false)
+			if err := c.Release(ctx, "ds1", "r0"); err != nil {
+				t.Fatal(err)
+			}
+			acquire("ds1", "r0", false)
+			if err := c.Release(ctx, "ds1", "r2"); err != nil {
+				t.Fatal(err)
+			}
+			acquire("ds1", "r0", true)
+
+			// Leaving gives up all the leases of a replica.
+			acquire("ds2", "r1", true)
+			if err := c.Leave(ctx, "r1"); err != nil {
+				t.Fatal(err)
+			}
+			acquire("ds2", "r0", true)
+
+			// Leases expire if they aren't renewed.
+			acquire("ds3", "r2", true)
+			time.Sleep(ttl)
+			acquire("ds3", "r0", true)
+
+			// Renewals report the leases lost.
+			acquire("ds4", "r2", true)
+			lost, err := c.Renew(ctx, "r0", []string{"ds1", "ds3", "ds4"}, ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := []string{"ds4"}; !reflect.DeepEqual(lost, expected) {
+				t.Fatalf("expected lost leases %v, got %v", expected, lost)
+			}
+			acquire("ds3", "r2", false)
+			members, err = c.Heartbeat(ctx, "r0", time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := []string{"r0"}; !reflect.DeepEqual(members, expected) {
+				t.Fatalf("expected members %v, got %v", expected, members)
+			}
+		})
+	}
+}
+
+func TestHashRing(t *testing.T) {
+	var names []string
+	for i := 0; i < 1000; i++ {
+		names = append(names, fmt.Sprintf("ds%d", i))
+	}
+	owners := func(r *hashRing) map[string]string {
+		m := map[string]string{}
+		for _, name := range names {
+			m[name] = r.owner(name)
+		}
+		return m
+	}
+	if owner := newHashRing(nil).owner("ds"); owner != "" {
+		t.Fatalf("expected no owner on empty ring, got %s", owner)
+	}
+	three := owners(newHashRing([]string{"r0", "r1", "r2"}))
+	counts := map[string]int{}
+	for _, owner := range three {
+		counts[owner]++
+	}
+	for _, replica := range []string{"r0", "r1", "r2"} {
+		if counts[replica] < 200 {
+			t.Errorf("replica %s only owns %d of %d datasources", replica, counts[replica],
+				len(names))
+		}
+	}
+	// Only the datasources of a replica leaving move.
+	two := owners(newHashRing([]string{"r0", "r2"}))
+	for name, owner := range three {
+		if owner != "r1" && two[name] != owner {
+			t.Errorf("datasource %s moved from %s to %s", name, owner, two[name])
+		}
+	}
+}
+
+// shardTestClient accepts all sets and answers subscriptions with a
+// sync response.
+type shardTestClient struct {
+	gnmi.GNMIClient
+}
+
+func (c shardTestClient) Set(ctx context.Context, req *gnmi.SetRequest,
+	opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+	return &gnmi.SetResponse{}, nil
+}
+
+func (c shardTestClient) Subscribe(ctx context.Context,
+	opts ...grpc.CallOption) (gnmi.GNMI_SubscribeClient, error) {
+	stream := &internal.MockClientStream{
+		SubReq:  make(chan *gnmi.SubscribeRequest, 1),
+		SubResp: make(chan *gnmi.SubscribeResponse, 1),
+	}
+	stream.SubResp <- &gnmi.SubscribeResponse{
+		Response: &gnmi.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}
+	close(stream.SubResp)
+	return stream, nil
+}
+
+// runCounter counts the providers running for each datasource.
+type runCounter struct {
+	lock    sync.Mutex
+	running map[string]int
+	overlap []string
+}
+
+func (c *runCounter) add(name string, n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.running[name] += n
+	if c.running[name] > 1 {
+		c.overlap = append(c.overlap, name)
+	}
+}
+
+// countingProvider counts itself as running until canceled.
+type countingProvider struct {
+	name    string
+	counter *runCounter
+}
+
+func (p *countingProvider) InitGNMI(client gnmi.GNMIClient) {}
+func (p *countingProvider) OpenConfig() bool                { return true }
+func (p *countingProvider) Origin() string                  { return "" }
+
+func (p *countingProvider) Run(ctx context.Context) error {
+	p.counter.add(p.name, 1)
+	defer p.counter.add(p.name, -1)
+	<-ctx.Done()
+	return nil
+}
+
+type countingDevice struct {
+	testDevice
+	provider *countingProvider
+}
+
+func (d *countingDevice) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{d.provider}, nil
+}
+
+func TestSensorSharding(t *testing.T) {
+	const deviceType = "sharded"
+	counter := &runCounter{running: map[string]int{}}
+	Register(deviceType, func(ctx context.Context, m map[string]string,
+		monitor provider.Monitor) (Device, error) {
+		return &countingDevice{
+			testDevice: testDevice{deviceID: m["id"]},
+			provider:   &countingProvider{name: m["id"], counter: counter},
+		}, nil
+	}, map[string]Option{"id": {Description: "device ID", Required: true}})
+	defer Unregister(deviceType)
+
+	var names []string
+	for i := 0; i < 12; i++ {
+		names = append(names, fmt.Sprintf("ds%d", i))
+	}
+	metadata := make(chan string)
+	go func() {
+		for range metadata {
+		}
+	}()
+	defer close(metadata)
+
+	coordinator := NewMemoryShardCoordinator().(*memoryShardCoordinator)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	// start runs a sensor replica, returning a function stopping it.
+	start := func(replica string) context.CancelFunc {
+		configCh := make(chan *Config, len(names)+1)
+		for _, name := range names {
+			configCh <- &Config{
+				Name:    name,
+				Device:  deviceType,
+				Enabled: true,
+				Options: map[string]string{"id": name},
+			}
+		}
+		configCh <- NewSyncEndConfig()
+		sensor := NewSensor("abc", 100.0,
+			WithSensorGNMIClient(shardTestClient{}),
+			WithSensorClientFactory(func(gc gnmi.GNMIClient, info *Info) cvclient.CVClient {
+				return newMockCVClient(gc, info, metadata)
+			}),
+			WithSensorSkipSubscribe(true),
+			WithSensorConfigChan(configCh),
+			WithSensorSharding(coordinator, replica),
+			WithSensorShardLeaseTTL(300*time.Millisecond))
+		ctx, cancel := context.WithCancel(context.Background())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sensor.Run(ctx)
+		}()
+		return cancel
+	}
+
+	// waitForOwners waits for each datasource to run exactly once,
+	// held by one of replicas, and for every replica to hold some.
+	waitForOwners := func(replicas ...string) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			coordinator.lock.Lock()
+			owners := map[string]int{}
+			held := 0
+			for _, name := range names {
+				if lease, ok := coordinator.state.Leases[name]; ok {
+					owners[lease.Replica]++
+					held++
+				}
+			}
+			coordinator.lock.Unlock()
+			counter.lock.Lock()
+			settled := held == len(names) && len(owners) == len(replicas)
+			for _, name := range names {
+				settled = settled && counter.running[name] == 1
+			}
+			counter.lock.Unlock()
+			for _, replica := range replicas {
+				settled = settled && owners[replica] > 0
+			}
+			if settled {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %v to share datasources, got %v, running %v",
+					replicas, owners, counter.running)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	stop0 := start("r0")
+	stop1 := start("r1")
+	stop2 := start("r2")
+	waitForOwners("r0", "r1", "r2")
+
+	// The datasources of a replica that stops are taken over.
+	stop1()
+	waitForOwners("r0", "r2")
+
+	// Replicas that join take their share.
+	stop3 := start("r3")
+	waitForOwners("r0", "r2", "r3")
+
+	stop0()
+	stop2()
+	stop3()
+	wg.Wait()
+	counter.lock.Lock()
+	defer counter.lock.Unlock()
+	if len(counter.overlap) > 0 {
+		t.Errorf("datasources ran more than once: %v", counter.overlap)
+	}
+	for name, n := range counter.running {
+		if n != 0 {
+			t.Errorf("datasource %s still running %d times", name, n)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/log"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// StopReasonShutdown is the stop-reason published by datasources
+// stopped because the sensor shut down. Unlike unreachable ones, they
+// haven't lost contact with their device.
+const StopReasonShutdown = "shutdown"
+
+var errSensorShutDown = errors.New("sensor shut down")
+
+// drainTimedOut wraps the error of a drain that didn't finish in time.
+func drainTimedOut(what string, err error) error {
+	return fmt.Errorf("%s did not finish before the shutdown deadline: %w", what, err)
+}
+
+// shutdown drains the datasource for the sensor to shut down: its
+// providers get to finish the polls in flight until ctx is done, and
+// the Sets they made are flushed before its final state is published.
+// It returns an error if the datasource couldn't be drained.
+func (d *datasource) shutdown(ctx context.Context) error {
+	if !d.running.Load() {
+		return d.stopBefore(ctx)
+	}
+	d.log.Info("Draining data source")
+	var drainErr error
+	if err := d.pollGate.Close(ctx); err != nil {
+		drainErr = drainTimedOut("in-flight polls", err)
+	}
+	if err := d.stopBefore(ctx); err != nil {
+		// The datasource can't be flushed while it's running.
+		if drainErr == nil {
+			drainErr = err
+		}
+		return drainErr
+	}
+	// d.cvClient isn't changed once Run has returned.
+	if err := cvclient.Flush(ctx, d.cvClient); err != nil && drainErr == nil {
+		drainErr = fmt.Errorf("failed to flush pending Sets: %w", err)
+	}
+	if err := d.submitDatasourceUpdates(ctx,
+		pgnmi.Update(lastErrorKey, agnmi.TypedValue("Data source stopped: sensor shutting down")),
+		pgnmi.Update(stopReasonKey, agnmi.TypedValue(StopReasonShutdown)),
+	); err != nil && drainErr == nil {
+		drainErr = fmt.Errorf("failed to publish final state: %w", err)
+	}
+	return drainErr
+}
+
+// stopBefore stops the datasource, and returns an error if it didn't
+// stop by the time ctx is done.
+func (d *datasource) stopBefore(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		d.stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return drainTimedOut("providers", ctx.Err())
+	}
+}
+
+// Shutdown shuts the sensor down gracefully: it stops taking configs,
+// drains its datasources, each of which publishes a final state saying
+// it stopped because of the shutdown, and makes Run return. It returns
+// an error if the datasources weren't all drained by the time ctx is
+// done.
+func (s *Sensor) Shutdown(ctx context.Context) error {
+	drainCtx := ctx
+	var drainErr error
+	if err := s.do(ctx, func(context.Context) error {
+		s.log.Infof("Shutting down, draining %d datasources...", len(s.datasource))
+		// Stop heartbeats so that they don't override the final state.
+		s.heartbeatLock.Lock()
+		s.active = false
+		s.heartbeatLock.Unlock()
+
+		var lock sync.Mutex
+		var wg sync.WaitGroup
+		for _, ds := range s.datasource {
+			wg.Add(1)
+			go func(ds *datasource) {
+				defer wg.Done()
+				if err := ds.shutdown(drainCtx); err != nil {
+					ds.log.Errorf("Failed to drain data source: %v", err)
+					lock.Lock()
+					drainErr = errors.Join(drainErr,
+						fmt.Errorf("datasource %s: %w", ds.config.name, err))
+					lock.Unlock()
+				}
+			}(ds)
+		}
+		wg.Wait()
+
+		if _, err := s.gnmic.Set(drainCtx, &gnmi.SetRequest{
+			Prefix: s.statePrefix,
+			Update: []*gnmi.Update{
+				pgnmi.Update(lastErrorKey, agnmi.TypedValue("Sensor shutting down")),
+			},
+		}); err != nil {
+			s.log.Errorf("Failed to publish sensor shutdown: %v", err)
+		}
+		s.shutDown = true
+		return nil
+	}); err != nil {
+		return err
+	}
+	if drainErr != nil {
+		return drainErr
+	}
+	s.log.Info("All datasources drained")
+	return nil
+}
+
+// An InventoryDrainer is an Inventory that can be shut down
+// gracefully.
+type InventoryDrainer interface {
+	Inventory
+	// Shutdown stops the devices of the inventory and marks them
+	// inactive in CloudVision, once their providers have finished the
+	// polls in flight and the Sets they made are flushed. Devices
+	// can't be added anymore afterwards. It returns an error if the
+	// devices weren't all drained by the time ctx is done.
+	Shutdown(ctx context.Context) error
+}
+
+// shutdown drains the device for the inventory to shut down.
+func (dc *deviceConn) shutdown(ctx context.Context) error {
+	logger := log.Log(dc.info.Device)
+	logger.Infof("Draining device %s", dc.info.ID)
+	var drainErr error
+	if err := dc.pollGate.Close(ctx); err != nil {
+		drainErr = drainTimedOut("in-flight polls", err)
+	}
+	dc.cancel()
+	stopped := make(chan struct{})
+	go func() {
+		dc.group.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		dc.close(ctx)
+	case <-ctx.Done():
+		// The device can't be closed while its providers are running.
+		if drainErr == nil {
+			drainErr = drainTimedOut("providers", ctx.Err())
+		}
+	}
+	if err := cvclient.Flush(ctx, dc.cvClient); err != nil && drainErr == nil {
+		drainErr = fmt.Errorf("failed to flush pending Sets: %w", err)
+	}
+	dc.setStatus(StatusInactive)
+	if err := dc.cvClient.SendDeviceMetadata(ctx); err != nil && drainErr == nil {
+		drainErr = fmt.Errorf("failed to publish final state: %w", err)
+	}
+	return drainErr
+}
+
+func (i *inventory) Shutdown(ctx context.Context) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.shutDown = true
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	var drainErr error
+	for key, dc := range i.devices {
+		wg.Add(1)
+		go func(dc *deviceConn) {
+			defer wg.Done()
+			if err := dc.shutdown(ctx); err != nil {
+				log.Log(dc.info.Device).Errorf("Failed to drain device %s: %v", key, err)
+				lock.Lock()
+				drainErr = errors.Join(drainErr, fmt.Errorf("device %s: %w", key, err))
+				lock.Unlock()
+			}
+			i.notify(InventoryUpdated, dc)
+		}(dc)
+		delete(i.devices, key)
+	}
+	wg.Wait()
+	return drainErr
+}
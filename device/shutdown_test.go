@@ -0,0 +1,282 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/device/cvclient"
+	"github.com/aristanetworks/cloudvision-go/device/cvclient/batch"
+	"github.com/aristanetworks/cloudvision-go/device/internal"
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+)
+
+// pollingProvider polls until canceled, each poll waiting to be
+// released before making its Set.
+type pollingProvider struct {
+	client  gnmi.GNMIClient
+	polling chan struct{}
+	release chan struct{}
+}
+
+func (p *pollingProvider) InitGNMI(client gnmi.GNMIClient) { p.client = client }
+func (p *pollingProvider) OpenConfig() bool                { return true }
+func (p *pollingProvider) Origin() string                  { return "" }
+
+func (p *pollingProvider) Run(ctx context.Context) error {
+	for {
+		if end, ok := provider.BeginPoll(ctx); ok {
+			p.polling <- struct{}{}
+			select {
+			case <-p.release:
+			case <-ctx.Done():
+				end()
+				return nil
+			}
+			_, err := p.client.Set(ctx, &gnmi.SetRequest{
+				Update: []*gnmi.Update{pgnmi.Update(pgnmi.Path("polled"),
+					agnmi.TypedValue(true))},
+			})
+			end()
+			if err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+type pollingDevice struct {
+	testDevice
+	provider *pollingProvider
+}
+
+func (d *pollingDevice) Providers() ([]provider.Provider, error) {
+	return []provider.Provider{d.provider}, nil
+}
+
+func TestSensorShutdown(t *testing.T) {
+	const deviceType = "shutdowntest"
+	p := &pollingProvider{polling: make(chan struct{}), release: make(chan struct{})}
+	Register(deviceType, func(ctx context.Context, m map[string]string,
+		monitor provider.Monitor) (Device, error) {
+		return &pollingDevice{testDevice: testDevice{deviceID: m["id"]}, provider: p}, nil
+	}, map[string]Option{"id": {Description: "device ID", Required: true}})
+	defer Unregister(deviceType)
+
+	gnmic := &internal.MockClient{
+		SubscribeStream: make(chan *internal.MockClientStream),
+		SetReq:          make(chan *gnmi.SetRequest, 1000),
+		SetResp:         make(chan *gnmi.SetResponse),
+	}
+	close(gnmic.SetResp)
+	// Sets are held back until flushed.
+	batcher, err := batch.New(batch.WithMaxLatency(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sensor := NewSensor("default", 100.0, WithSensorClientFactory(
+		func(gc gnmi.GNMIClient, info *Info) cvclient.CVClient {
+			return batcher.Client(newMockCVClient(gnmic, info, make(chan string, 100)))
+		},
+	), WithSensorGNMIClient(gnmic))
+	sensor.clockSynced = true
+	sensor.datasourceConfig["dev1"] = &datasourceConfig{
+		name:       "dev1",
+		typ:        deviceType,
+		enabled:    true,
+		option:     map[string]string{"id": "1"},
+		credential: map[string]string{},
+		loglevel:   logrus.InfoLevel,
+	}
+
+	// Stand in for the main loop of Run.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	loopDone := make(chan struct{})
+	go func() {
+		defer close(loopDone)
+		for {
+			select {
+			case request := <-sensor.adminRequests:
+				request(ctx)
+				if sensor.shutDown {
+					return
+				}
+			case name := <-sensor.redeployDatasource:
+				if err := sensor.runDatasourceConfig(ctx, name); err != nil {
+					t.Error(err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	if err := sensor.RestartDatasource(ctx, "dev1"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-p.polling:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for poll")
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer shutdownCancel()
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- sensor.Shutdown(shutdownCtx) }()
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("shutdown returned with a poll in flight: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	p.release <- struct{}{}
+	if err := <-shutdownErr; err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-loopDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("main loop didn't return after shutdown")
+	}
+
+	// The Set of the drained poll is flushed before the final state of
+	// the datasource is published.
+	var polled, final, sensorFinal bool
+	for len(gnmic.SetReq) > 0 {
+		req := <-gnmic.SetReq
+		for _, upd := range req.Update {
+			switch {
+			case proto.Equal(upd.Path, pgnmi.Path("polled")):
+				polled = true
+			case proto.Equal(upd.Path, stopReasonKey):
+				if !polled {
+					t.Error("final state published before poll was flushed")
+				}
+				if upd.Val.GetStringVal() != StopReasonShutdown {
+					t.Errorf("unexpected stop reason %v", upd.Val)
+				}
+				final = true
+			case proto.Equal(upd.Path, lastErrorKey) &&
+				upd.Val.GetStringVal() == "Sensor shutting down":
+				sensorFinal = true
+			}
+		}
+	}
+	if !polled || !final || !sensorFinal {
+		t.Errorf("expected flushed poll and final states, got polled=%t, final=%t, "+
+			"sensor final=%t", polled, final, sensorFinal)
+	}
+	if sensor.datasource["dev1"].running.Load() {
+		t.Error("datasource still running after shutdown")
+	}
+}
+
+func TestPollGateTimeout(t *testing.T) {
+	ctx, gate := provider.WithPollGate(context.Background())
+	end, ok := provider.BeginPoll(ctx)
+	if !ok {
+		t.Fatal("poll blocked by open gate")
+	}
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := gate.Close(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded with a poll in flight, got %v", err)
+	}
+	if _, ok := provider.BeginPoll(ctx); ok {
+		t.Error("poll started through closed gate")
+	}
+	end()
+	if err := gate.Close(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if _, ok := provider.BeginPoll(context.Background()); !ok {
+		t.Error("poll blocked without a gate")
+	}
+}
+
+func TestDatasourceShutdownDeadline(t *testing.T) {
+	// Providers that don't stop when canceled don't hold the shutdown
+	// past its deadline.
+	hung := make(chan struct{})
+	defer close(hung)
+	_, gate := provider.WithPollGate(context.Background())
+	d := &datasource{
+		log:       logrus.WithField("datasource", "dev1"),
+		execGroup: &errgroup.Group{},
+		pollGate:  gate,
+	}
+	d.execGroup.Go(func() error {
+		<-hung
+		return nil
+	})
+	for _, running := range []bool{true, false} {
+		d.running.Store(running)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := d.shutdown(ctx)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded shutting down datasource with "+
+				"running=%t, got %v", running, err)
+		}
+	}
+}
+
+func TestInventoryShutdown(t *testing.T) {
+	processor := func(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return nil, nil
+	}
+	var statuses []ManagedDeviceStatus
+	inv := NewInventory(context.Background(), pgnmi.NewSimpleGNMIClient(processor),
+		func(gc gnmi.GNMIClient, i *Info) cvclient.CVClient {
+			return &statusClient{CVClient: newMockCVClient(gc, i, make(chan string, 10)),
+				info: i, statuses: &statuses}
+		})
+	d := newClosingDevice("dev")
+	if err := inv.Add(&Info{Config: &Config{NoStream: true}, Device: d, ID: "dev"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.(InventoryDrainer).Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	d.checkClosed(t, 1)
+	if len(statuses) != 2 || statuses[1] != StatusInactive {
+		t.Errorf("expected device to be marked inactive, got statuses %v", statuses)
+	}
+	if len(inv.List()) != 0 {
+		t.Errorf("expected no devices after shutdown, got %v", inv.List())
+	}
+	err := inv.Add(&Info{Config: &Config{NoStream: true}, Device: newClosingDevice("dev2"),
+		ID: "dev2"})
+	if err == nil {
+		t.Error("expected error adding device after shutdown")
+	}
+}
+
+// statusClient records the status of the device it sends metadata
+// for.
+type statusClient struct {
+	cvclient.CVClient
+	info     *Info
+	statuses *[]ManagedDeviceStatus
+}
+
+func (c *statusClient) SendDeviceMetadata(ctx context.Context) error {
+	*c.statuses = append(*c.statuses, c.info.Status)
+	return c.CVClient.SendDeviceMetadata(ctx)
+}
@@ -11,6 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aristanetworks/cloudvision-go/provider"
+	"github.com/aristanetworks/cloudvision-go/tracing"
+
 	agnmi "github.com/aristanetworks/goarista/gnmi"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/sirupsen/logrus"
@@ -202,7 +205,15 @@ func PathCopy(oldPath *gnmi.Path) *gnmi.Path {
 type PollFn func() ([]*gnmi.SetRequest, error)
 
 func pollOnce(ctx context.Context, client gnmi.GNMIClient,
-	poller PollFn) error {
+	poller PollFn) (err error) {
+	// Polls are skipped once the datasource is draining.
+	end, ok := provider.BeginPoll(ctx)
+	if !ok {
+		return nil
+	}
+	defer end()
+	ctx, span := tracing.StartPoll(ctx, "gnmi.PollOnce")
+	defer func() { tracing.End(span, err) }()
 	setreqs, err := poller()
 	if err != nil {
 		return err
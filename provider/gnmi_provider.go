@@ -5,6 +5,8 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/openconfig/gnmi/proto/gnmi"
 )
 
@@ -24,3 +26,20 @@ type GNMIProvider interface {
 	// is used only by v2 client.
 	Origin() string
 }
+
+// A Flusher is a gNMI client that holds back some of the SetRequests
+// made through it, such as to batch them, so that a Set returning
+// without error doesn't mean the update was applied.
+type Flusher interface {
+	// Flush sends the SetRequests held back by the client and by the
+	// clients it returned for providers.
+	Flush(ctx context.Context) error
+}
+
+// Flush flushes client if it's a Flusher.
+func Flush(ctx context.Context, client gnmi.GNMIClient) error {
+	if f, ok := client.(Flusher); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
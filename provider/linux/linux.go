@@ -0,0 +1,416 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aristanetworks/cloudvision-go/provider"
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// ARPHRD_* values from linux/if_arp.h, as found in
+	// /sys/class/net/<intf>/type.
+	arphrdEther    = 1
+	arphrdLoopback = 772
+
+	// IFF_UP from linux/if.h, as found in /sys/class/net/<intf>/flags.
+	iffUp = 0x1
+
+	// ChassisComponent is the name of the component under which the
+	// host's DMI information is published.
+	ChassisComponent = "Chassis"
+)
+
+var now = time.Now
+
+type linux struct {
+	client       gnmi.GNMIClient
+	errc         chan error
+	pollInterval time.Duration
+	root         string
+}
+
+// ReadFile returns the whitespace-trimmed contents of the file at
+// the given path relative to root.
+func ReadFile(root, name string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(root, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Hostname returns the hostname of the system rooted at root.
+func Hostname(root string) (string, error) {
+	return ReadFile(root, "proc/sys/kernel/hostname")
+}
+
+// Serial returns the DMI system serial number of the system rooted
+// at root. Placeholder values left in by vendors are ignored.
+func Serial(root string) (string, error) {
+	serial, err := ReadFile(root, "sys/class/dmi/id/product_serial")
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(serial) {
+	case "", "0", "none", "not specified", "to be filled by o.e.m.", "default string":
+		return "", nil
+	}
+	return serial, nil
+}
+
+// MachineID returns the systemd machine ID of the system rooted at root.
+func MachineID(root string) (string, error) {
+	return ReadFile(root, "etc/machine-id")
+}
+
+// DefaultRouteInterface returns the name of the interface that holds
+// the IPv4 default route, as listed in /proc/net/route.
+func DefaultRouteInterface(root string) (string, error) {
+	s, err := ReadFile(root, "proc/net/route")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 8 || fields[0] == "Iface" {
+			continue
+		}
+		// Destination and Mask are both zero for the default route.
+		if fields[1] == "00000000" && fields[7] == "00000000" {
+			return fields[0], nil
+		}
+	}
+	return "", nil
+}
+
+// netDevCounters holds the per-interface counters from /proc/net/dev.
+type netDevCounters struct {
+	name                                       string
+	inOctets, inPkts, inMulticastPkts          uint64
+	inErrors, inDiscards                       uint64
+	outOctets, outPkts, outErrors, outDiscards uint64
+}
+
+// parseNetDev parses the contents of /proc/net/dev, which looks like
+// this:
+//
+//	Inter-|   Receive                            ...|  Transmit
+//	 face |bytes    packets errs drop fifo frame ...|bytes    packets errs drop ...
+//	    lo: 2776770   11307    0    0    0     0 ...
+func parseNetDev(s string) ([]netDevCounters, error) {
+	var counters []netDevCounters
+	for _, line := range strings.Split(s, "\n") {
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		name := strings.TrimSpace(line[:i])
+		fields := strings.Fields(line[i+1:])
+		if len(fields) < 12 {
+			return nil, fmt.Errorf("unexpected /proc/net/dev line for %q: %q", name, line)
+		}
+		vals := make([]uint64, len(fields))
+		for j, f := range fields {
+			v, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad /proc/net/dev counter for %q: %v", name, err)
+			}
+			vals[j] = v
+		}
+		counters = append(counters, netDevCounters{
+			name:            name,
+			inOctets:        vals[0],
+			inPkts:          vals[1],
+			inErrors:        vals[2],
+			inDiscards:      vals[3],
+			inMulticastPkts: vals[7],
+			outOctets:       vals[8],
+			outPkts:         vals[9],
+			outErrors:       vals[10],
+			outDiscards:     vals[11],
+		})
+	}
+	return counters, nil
+}
+
+func interfaceType(arphrd int64) string {
+	switch arphrd {
+	case arphrdEther:
+		return "ethernetCsmacd"
+	case arphrdLoopback:
+		return "softwareLoopback"
+	}
+	return "other"
+}
+
+// operStatus converts the RFC 2863 operstate reported by the kernel
+// into its OpenConfig equivalent.
+func operStatus(operstate string) string {
+	switch operstate {
+	case "up":
+		return "UP"
+	case "down":
+		return "DOWN"
+	case "testing":
+		return "TESTING"
+	case "dormant":
+		return "DORMANT"
+	case "notpresent":
+		return "NOT_PRESENT"
+	case "lowerlayerdown":
+		return "LOWER_LAYER_DOWN"
+	}
+	return "UNKNOWN"
+}
+
+// sysClassNetInt reads an integer attribute of an interface from
+// /sys/class/net. Attributes that can't be read or parsed are
+// reported as not present.
+func (l *linux) sysClassNetInt(intfName, attr string, base int) (int64, bool) {
+	s, err := ReadFile(l.root, filepath.Join("sys/class/net", intfName, attr))
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(s, base, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func (l *linux) interfaceUpdates(c netDevCounters) []*gnmi.Update {
+	intfName := c.name
+	updates := []*gnmi.Update{
+		pgnmi.Update(pgnmi.IntfPath(intfName, "name"), pgnmi.Strval(intfName)),
+		pgnmi.Update(pgnmi.IntfConfigPath(intfName, "name"), pgnmi.Strval(intfName)),
+		pgnmi.Update(pgnmi.IntfStatePath(intfName, "name"), pgnmi.Strval(intfName)),
+	}
+
+	if t, ok := l.sysClassNetInt(intfName, "type", 10); ok {
+		updates = append(updates, pgnmi.Update(pgnmi.IntfStatePath(intfName, "type"),
+			pgnmi.Strval(interfaceType(t))))
+	}
+	if flags, ok := l.sysClassNetInt(intfName, "flags", 0); ok {
+		adminStatus := "DOWN"
+		if flags&iffUp != 0 {
+			adminStatus = "UP"
+		}
+		updates = append(updates, pgnmi.Update(pgnmi.IntfStatePath(intfName, "admin-status"),
+			pgnmi.Strval(adminStatus)))
+	}
+	if operstate, err := ReadFile(l.root,
+		filepath.Join("sys/class/net", intfName, "operstate")); err == nil {
+		updates = append(updates, pgnmi.Update(pgnmi.IntfStatePath(intfName, "oper-status"),
+			pgnmi.Strval(operStatus(operstate))))
+	}
+	if ifindex, ok := l.sysClassNetInt(intfName, "ifindex", 10); ok {
+		updates = append(updates, pgnmi.Update(pgnmi.IntfStatePath(intfName, "ifindex"),
+			pgnmi.Uintval(uint64(ifindex))))
+	}
+	if mtu, ok := l.sysClassNetInt(intfName, "mtu", 10); ok {
+		updates = append(updates, pgnmi.Update(pgnmi.IntfStatePath(intfName, "mtu"),
+			pgnmi.Uintval(uint64(mtu))))
+	}
+	if mac, err := ReadFile(l.root,
+		filepath.Join("sys/class/net", intfName, "address")); err == nil && mac != "" {
+		updates = append(updates, pgnmi.Update(
+			pgnmi.IntfEthernetStatePath(intfName, "mac-address"), pgnmi.Strval(mac)))
+	}
+
+	return append(updates,
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "in-octets"),
+			pgnmi.Uintval(c.inOctets)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "in-pkts"),
+			pgnmi.Uintval(c.inPkts)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "in-multicast-pkts"),
+			pgnmi.Uintval(c.inMulticastPkts)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "in-errors"),
+			pgnmi.Uintval(c.inErrors)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "in-discards"),
+			pgnmi.Uintval(c.inDiscards)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "out-octets"),
+			pgnmi.Uintval(c.outOctets)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "out-pkts"),
+			pgnmi.Uintval(c.outPkts)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "out-errors"),
+			pgnmi.Uintval(c.outErrors)),
+		pgnmi.Update(pgnmi.IntfStateCountersPath(intfName, "out-discards"),
+			pgnmi.Uintval(c.outDiscards)),
+	)
+}
+
+func (l *linux) updateInterfaces() (*gnmi.SetRequest, error) {
+	s, err := ReadFile(l.root, "proc/net/dev")
+	if err != nil {
+		return nil, err
+	}
+	counters, err := parseNetDev(s)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].name < counters[j].name })
+
+	updates := make([]*gnmi.Update, 0)
+	for _, c := range counters {
+		updates = append(updates, l.interfaceUpdates(c)...)
+	}
+	return &gnmi.SetRequest{
+		Delete:  []*gnmi.Path{pgnmi.Path("interfaces")},
+		Replace: updates,
+	}, nil
+}
+
+func systemStatePath(leafName string) *gnmi.Path {
+	return pgnmi.Path("system", "state", leafName)
+}
+
+func (l *linux) updateSystem() (*gnmi.SetRequest, error) {
+	var updates []*gnmi.Update
+
+	hostname, err := Hostname(l.root)
+	if err != nil {
+		return nil, err
+	}
+	if hostname != "" {
+		updates = append(updates, pgnmi.Update(systemStatePath("hostname"),
+			pgnmi.Strval(hostname)))
+	}
+
+	// /proc/uptime holds the seconds since boot followed by the
+	// aggregate idle time of all CPUs.
+	s, err := ReadFile(l.root, "proc/uptime")
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("unexpected /proc/uptime contents: %q", s)
+	}
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad uptime in /proc/uptime: %v", err)
+	}
+	t := now()
+	bootTime := t.UnixNano() - int64(uptime*float64(time.Second))
+	updates = append(updates,
+		pgnmi.Update(systemStatePath("boot-time"), pgnmi.Intval(bootTime)),
+		pgnmi.Update(systemStatePath("current-datetime"),
+			pgnmi.Strval(t.Format(time.RFC3339))))
+
+	return &gnmi.SetRequest{Update: updates}, nil
+}
+
+func (l *linux) updateComponents() (*gnmi.SetRequest, error) {
+	serial, err := Serial(l.root)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if serial == "" {
+		// No DMI data: nothing to say about the hardware.
+		return nil, nil
+	}
+
+	name := ChassisComponent
+	updates := []*gnmi.Update{
+		pgnmi.Update(pgnmi.PlatformComponentPath(name, "name"), pgnmi.Strval(name)),
+		pgnmi.Update(pgnmi.PlatformComponentConfigPath(name, "name"), pgnmi.Strval(name)),
+		pgnmi.Update(pgnmi.PlatformComponentStatePath(name, "name"), pgnmi.Strval(name)),
+		pgnmi.Update(pgnmi.PlatformComponentStatePath(name, "type"),
+			pgnmi.Strval("openconfig-platform-types:CHASSIS")),
+		pgnmi.Update(pgnmi.PlatformComponentStatePath(name, "serial-no"),
+			pgnmi.Strval(serial)),
+	}
+	for leaf, file := range map[string]string{
+		"mfg-name":         "sys_vendor",
+		"part-no":          "product_name",
+		"hardware-version": "product_version",
+	} {
+		v, err := ReadFile(l.root, filepath.Join("sys/class/dmi/id", file))
+		if err != nil || v == "" {
+			continue
+		}
+		updates = append(updates,
+			pgnmi.Update(pgnmi.PlatformComponentStatePath(name, leaf), pgnmi.Strval(v)))
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Path.String() < updates[j].Path.String()
+	})
+	return &gnmi.SetRequest{Update: updates}, nil
+}
+
+// poll returns one SetRequest for each of the /interfaces, /system
+// and /components subtrees.
+func (l *linux) poll() ([]*gnmi.SetRequest, error) {
+	var setRequests []*gnmi.SetRequest
+	for _, f := range []func() (*gnmi.SetRequest, error){
+		l.updateInterfaces,
+		l.updateSystem,
+		l.updateComponents,
+	} {
+		sr, err := f()
+		if err != nil {
+			return nil, err
+		}
+		if sr != nil {
+			setRequests = append(setRequests, sr)
+		}
+	}
+	return setRequests, nil
+}
+
+func (l *linux) handleErrors(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-l.errc:
+			return fmt.Errorf("Error in linux provider: %v", err)
+		}
+	}
+}
+
+func (l *linux) Run(ctx context.Context) error {
+	if l.client == nil {
+		return fmt.Errorf("provider is uninitialized")
+	}
+	go pgnmi.PollForever(ctx, l.client, l.pollInterval, l.poll, l.errc)
+
+	// handleErrors only returns if it sees an error.
+	return l.handleErrors(ctx)
+}
+
+func (l *linux) InitGNMI(client gnmi.GNMIClient) {
+	l.client = client
+}
+
+func (l *linux) OpenConfig() bool {
+	return true
+}
+
+func (l *linux) Origin() string {
+	return "openconfig"
+}
+
+// NewLinuxProvider returns a provider that streams interface,
+// system and platform state of the Linux host whose proc and sys
+// filesystems are found under root.
+func NewLinuxProvider(root string, pollInterval time.Duration) provider.GNMIProvider {
+	return &linux{
+		errc:         make(chan error),
+		pollInterval: pollInterval,
+		root:         root,
+	}
+}
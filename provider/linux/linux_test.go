@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const fixtureRoot = "testdata/root"
+
+// flatten returns the updates in a SetRequest as a map of path to value.
+func flatten(updates []*gnmi.Update) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, u := range updates {
+		v := pgnmi.Unmarshal(u.Val)
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		m[agnmi.StrPath(u.Path)] = v
+	}
+	return m
+}
+
+func checkUpdates(t *testing.T, got map[string]interface{}, expected map[string]string) {
+	t.Helper()
+	for path, val := range expected {
+		v, ok := got[path]
+		if !ok {
+			t.Errorf("missing update for %s", path)
+			continue
+		}
+		if v != val {
+			t.Errorf("%s: expected %s, got %v", path, val, v)
+		}
+	}
+}
+
+func TestPoll(t *testing.T) {
+	now = func() time.Time { return time.Unix(1700000000, 0).UTC() }
+	defer func() { now = time.Now }()
+
+	l := NewLinuxProvider(fixtureRoot, time.Second).(*linux)
+	setReqs, err := l.poll()
+	if err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	if len(setReqs) != 3 {
+		t.Fatalf("expected 3 SetRequests, got %d: %v", len(setReqs), setReqs)
+	}
+
+	intfs := setReqs[0]
+	if len(intfs.Delete) != 1 || agnmi.StrPath(intfs.Delete[0]) != "/interfaces" {
+		t.Errorf("expected delete of /interfaces, got %v", intfs.Delete)
+	}
+	checkUpdates(t, flatten(intfs.Replace), map[string]string{
+		"/interfaces/interface[name=eth0]/name":                             `"eth0"`,
+		"/interfaces/interface[name=eth0]/config/name":                      `"eth0"`,
+		"/interfaces/interface[name=eth0]/state/type":                       `"ethernetCsmacd"`,
+		"/interfaces/interface[name=eth0]/state/admin-status":               `"UP"`,
+		"/interfaces/interface[name=eth0]/state/oper-status":                `"UP"`,
+		"/interfaces/interface[name=eth0]/state/ifindex":                    `"2"`,
+		"/interfaces/interface[name=eth0]/state/mtu":                        `"1500"`,
+		"/interfaces/interface[name=eth0]/ethernet/state/mac-address":       `"52:54:00:12:34:56"`,
+		"/interfaces/interface[name=eth0]/state/counters/in-octets":         `"1215645"`,
+		"/interfaces/interface[name=eth0]/state/counters/in-pkts":           `"2751"`,
+		"/interfaces/interface[name=eth0]/state/counters/in-errors":         `"1"`,
+		"/interfaces/interface[name=eth0]/state/counters/in-discards":       `"2"`,
+		"/interfaces/interface[name=eth0]/state/counters/in-multicast-pkts": `"17"`,
+		"/interfaces/interface[name=eth0]/state/counters/out-octets":        `"1782404"`,
+		"/interfaces/interface[name=eth0]/state/counters/out-pkts":          `"4744"`,
+		"/interfaces/interface[name=eth0]/state/counters/out-errors":        `"3"`,
+		"/interfaces/interface[name=eth0]/state/counters/out-discards":      `"4"`,
+		"/interfaces/interface[name=lo]/state/type":                         `"softwareLoopback"`,
+		"/interfaces/interface[name=lo]/state/oper-status":                  `"UNKNOWN"`,
+		"/interfaces/interface[name=lo]/state/counters/in-octets":           `"2776770"`,
+	})
+
+	bootTime := now().UnixNano() - int64(3600.5*float64(time.Second))
+	checkUpdates(t, flatten(setReqs[1].Update), map[string]string{
+		"/system/state/hostname":         `"testhost"`,
+		"/system/state/boot-time":        `"` + strconv.FormatInt(bootTime, 10) + `"`,
+		"/system/state/current-datetime": `"2023-11-14T22:13:20Z"`,
+	})
+
+	chassis := "/components/component[name=Chassis]/state/"
+	checkUpdates(t, flatten(setReqs[2].Update), map[string]string{
+		chassis + "type":             `"openconfig-platform-types:CHASSIS"`,
+		chassis + "serial-no":        `"ABC1234XYZ"`,
+		chassis + "mfg-name":         `"Acme Corp"`,
+		chassis + "part-no":          `"Server 9000"`,
+		chassis + "hardware-version": `"Rev B"`,
+	})
+}
+
+func TestPollNoDMI(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"proc/net/dev", "proc/uptime",
+		"proc/sys/kernel/hostname"} {
+		b, err := os.ReadFile(filepath.Join(fixtureRoot, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := NewLinuxProvider(root, time.Second).(*linux)
+	setReqs, err := l.poll()
+	if err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	// No /components without DMI data, and interfaces without any
+	// /sys/class/net attributes still get their names and counters.
+	if len(setReqs) != 2 {
+		t.Fatalf("expected 2 SetRequests, got %d: %v", len(setReqs), setReqs)
+	}
+	checkUpdates(t, flatten(setReqs[0].Replace), map[string]string{
+		"/interfaces/interface[name=eth0]/state/name":               `"eth0"`,
+		"/interfaces/interface[name=eth0]/state/counters/in-octets": `"1215645"`,
+	})
+	if _, ok := flatten(setReqs[0].Replace)["/interfaces/interface[name=eth0]/state/mtu"]; ok {
+		t.Errorf("unexpected mtu update without /sys/class/net")
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	for name, tc := range map[string]struct {
+		f        func(string) (string, error)
+		expected string
+	}{
+		"Hostname":              {Hostname, "testhost"},
+		"Serial":                {Serial, "ABC1234XYZ"},
+		"MachineID":             {MachineID, "0123456789abcdef0123456789abcdef"},
+		"DefaultRouteInterface": {DefaultRouteInterface, "eth0"},
+	} {
+		got, err := tc.f(fixtureRoot)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		} else if got != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, got)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	setCh := make(chan *gnmi.SetRequest)
+	p := NewLinuxProvider(fixtureRoot, time.Hour)
+	if err := p.Run(ctx); err == nil {
+		t.Fatalf("expected error running uninitialized provider")
+	}
+	p.InitGNMI(pgnmi.NewSimpleGNMIClient(
+		func(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+			select {
+			case setCh <- req:
+			case <-ctx.Done():
+			}
+			return &gnmi.SetResponse{}, nil
+		}))
+	errc := make(chan error)
+	go func() { errc <- p.Run(ctx) }()
+
+	// The first poll happens immediately.
+	for i := 0; i < 3; i++ {
+		select {
+		case <-setCh:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for SetRequest %d", i)
+		}
+	}
+	cancel()
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error from Run: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+// A PollGate tracks the polls of the providers of a device, so that
+// they can be drained before the device is stopped: once the gate is
+// closed, no new poll starts, and the polls in flight get to finish.
+type PollGate struct {
+	lock     sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+type pollGateKey struct{}
+
+// WithPollGate returns a copy of ctx carrying a new PollGate, which
+// providers run with the returned context go through when polling.
+func WithPollGate(ctx context.Context) (context.Context, *PollGate) {
+	g := &PollGate{}
+	return context.WithValue(ctx, pollGateKey{}, g), g
+}
+
+// BeginPoll is called by providers before polling. If ok is false, the
+// gate of ctx is closed and the poll should be skipped. Otherwise end
+// must be called once the poll, including the Sets it makes, is done.
+// Contexts without a gate never block polls.
+func BeginPoll(ctx context.Context) (end func(), ok bool) {
+	g, _ := ctx.Value(pollGateKey{}).(*PollGate)
+	if g == nil {
+		return func() {}, true
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.closed {
+		return nil, false
+	}
+	g.inFlight.Add(1)
+	return g.inFlight.Done, true
+}
+
+// Close stops new polls from starting and waits for the polls in
+// flight to finish. It returns ctx's error if ctx is done first.
+func (g *PollGate) Close(ctx context.Context) error {
+	g.lock.Lock()
+	g.closed = true
+	g.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
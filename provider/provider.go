@@ -48,6 +48,14 @@ type Provider interface {
 	Run(ctx context.Context) error
 }
 
+// A Closer is a Provider holding resources, such as sockets or
+// connections, that must be released once it's no longer used. Close
+// is called once, after Run has returned, with a context whose
+// deadline bounds how long it may take.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
 // SensorMetadataProvider gives way for provider to initialize itself with sensor metadata.
 type SensorMetadataProvider interface {
 	// Init provider with metadata about sensor environment.
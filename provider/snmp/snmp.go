@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -24,11 +25,16 @@ import (
 
 const (
 	snmpEntPhysicalClass          = ".1.3.6.1.2.1.47.1.1.1.1.5"
+	snmpEntPhysicalSoftwareRev    = ".1.3.6.1.2.1.47.1.1.1.1.10"
 	snmpEntPhysicalSerialNum      = ".1.3.6.1.2.1.47.1.1.1.1.11"
+	snmpEntPhysicalMfgName        = ".1.3.6.1.2.1.47.1.1.1.1.12"
+	snmpEntPhysicalModelName      = ".1.3.6.1.2.1.47.1.1.1.1.13"
 	snmpLldpLocChassisID          = ".1.0.8802.1.1.2.1.3.2.0"
 	snmpLldpLocChassisIDSubtype   = ".1.0.8802.1.1.2.1.3.1.0"
 	snmpLldpV2LocChassisID        = ".1.3.111.2.802.1.1.13.1.3.2.0"
 	snmpLldpV2LocChassisIDSubtype = ".1.3.111.2.802.1.1.13.1.3.1.0"
+	snmpSysDescrInstance          = ".1.3.6.1.2.1.1.1.0"
+	snmpSysNameInstance           = ".1.3.6.1.2.1.1.5.0"
 	snmpSysUpTimeInstance         = ".1.3.6.1.2.1.1.3.0"
 )
 
@@ -50,6 +56,10 @@ type Snmp struct {
 	mock       bool           // if true, don't do any network init
 	translator *snmpoc.Translator
 
+	// translatorLock protects translator, which is created by Run
+	// but may be read concurrently by MappedModels.
+	translatorLock sync.Mutex
+
 	// gosnmp can't handle parallel gets, so we also need to lock
 	// access to its connection object.
 	connectionLock sync.Mutex
@@ -59,6 +69,15 @@ type Snmp struct {
 	initialized  bool
 	deviceID     string
 
+	// chassisIndex is the entPhysicalIndex of the chassis entity once
+	// a walk of entPhysicalClass has found it, or "" if there's none,
+	// and chassis caches the values of that entity SystemInfo got
+	// along with the sysDescr of the device at the time. Both are
+	// refreshed when the sysDescr changes, as it does on upgrades.
+	chassisLock  sync.Mutex
+	chassisIndex *string
+	chassis      *SystemInfo
+
 	// List of files or directories to search for supported MIBs.
 	mibs     []string
 	mibStore smi.Store
@@ -193,11 +212,14 @@ func (s *Snmp) walk(rootOid string, walkFn gosnmp.WalkFunc) error {
 
 var errStopWalk = errors.New("stop walk")
 
+// snmpEntPhysicalClassTypeChassis is the entPhysicalClass of a chassis.
+const snmpEntPhysicalClassTypeChassis = 3
+
 func (s *Snmp) getSerialNumber() (string, error) {
 	serial := ""
 	var done bool
 	chassisIndex := ""
-	var snmpEntPhysicalClassTypeChassis = 3
+	firstChassisIndex := ""
 
 	// Get the serial number corresponding to the index whose class
 	// type is chassis(3).
@@ -216,6 +238,9 @@ func (s *Snmp) getSerialNumber() (string, error) {
 		if baseOid == snmpEntPhysicalClass {
 			if data.Value == snmpEntPhysicalClassTypeChassis {
 				chassisIndex = index
+				if firstChassisIndex == "" {
+					firstChassisIndex = index
+				}
 			}
 		}
 		if baseOid == snmpEntPhysicalSerialNum {
@@ -236,6 +261,12 @@ func (s *Snmp) getSerialNumber() (string, error) {
 	if err := s.walk(snmpEntPhysicalClass, entPhysicalWalk); err != nil {
 		return "", err
 	}
+	// Save SystemInfo walking entPhysicalClass again.
+	s.chassisLock.Lock()
+	if s.chassisIndex == nil {
+		s.chassisIndex = &firstChassisIndex
+	}
+	s.chassisLock.Unlock()
 	if err := s.walk(snmpEntPhysicalSerialNum, entPhysicalWalk); err != nil {
 		if err != errStopWalk {
 			return "", err
@@ -245,6 +276,133 @@ func (s *Snmp) getSerialNumber() (string, error) {
 	return serial, nil
 }
 
+// SystemInfo is the description of a device found in its system and
+// entity MIBs.
+type SystemInfo struct {
+	Hostname        string
+	Description     string
+	Vendor          string
+	Model           string
+	SerialNumber    string
+	SoftwareVersion string
+}
+
+// sysDescrVersion matches the software version in a sysDescr, such as
+// "Arista Networks EOS version 4.21.3F running on ...".
+var sysDescrVersion = regexp.MustCompile(`(?i)\bversion\s+([^\s,]+)`)
+
+// getString returns the value of the OctetString oid, or "" if the
+// device doesn't have it.
+func (s *Snmp) getString(oid string) (string, error) {
+	pdu, err := s.getFirstPDU(oid)
+	if err != nil {
+		return "", err
+	}
+	if !oidExists(*pdu) {
+		return "", nil
+	}
+	if b, ok := pdu.Value.([]byte); ok {
+		return strings.TrimSpace(string(b)), nil
+	}
+	return "", nil
+}
+
+// getChassisIndex returns the entPhysicalIndex of the first entity of
+// class chassis(3), or "" if there's none.
+func (s *Snmp) getChassisIndex() (string, error) {
+	chassisIndex := ""
+	if err := s.walk(snmpEntPhysicalClass, func(data gosnmp.SnmpPDU) error {
+		if data.Value != snmpEntPhysicalClassTypeChassis {
+			return nil
+		}
+		_, index, err := oidSplitEnd(data.Name)
+		if err != nil {
+			return err
+		}
+		chassisIndex = index
+		return errStopWalk
+	}); err != nil && err != errStopWalk {
+		return "", err
+	}
+	return chassisIndex, nil
+}
+
+// chassisEntity returns the manufacturer, model, serial number and
+// software revision of the chassis entity of the device, whose sysDescr
+// is descr. They're cached until the sysDescr changes.
+func (s *Snmp) chassisEntity(descr string) (*SystemInfo, error) {
+	s.chassisLock.Lock()
+	defer s.chassisLock.Unlock()
+	if s.chassis != nil {
+		if s.chassis.Description == descr {
+			return s.chassis, nil
+		}
+		// The entities may have changed along with the software.
+		s.chassisIndex = nil
+	}
+	if s.chassisIndex == nil {
+		index, err := s.getChassisIndex()
+		if err != nil {
+			return nil, err
+		}
+		s.chassisIndex = &index
+	}
+	chassis := &SystemInfo{Description: descr}
+	if index := *s.chassisIndex; index != "" {
+		for _, f := range []struct {
+			oid string
+			val *string
+		}{
+			{snmpEntPhysicalMfgName, &chassis.Vendor},
+			{snmpEntPhysicalModelName, &chassis.Model},
+			{snmpEntPhysicalSerialNum, &chassis.SerialNumber},
+			{snmpEntPhysicalSoftwareRev, &chassis.SoftwareVersion},
+		} {
+			var err error
+			if *f.val, err = s.getString(f.oid + "." + index); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.chassis = chassis
+	return chassis, nil
+}
+
+// SystemInfo returns the system information of the device: its sysName
+// and sysDescr, and the manufacturer, model, serial number and software
+// revision of its chassis entity. The software version is taken from the
+// sysDescr if the chassis has none.
+func (s *Snmp) SystemInfo(ctx context.Context) (*SystemInfo, error) {
+	s.monitor.Tracef("Snmp.SystemInfo")
+	if err := s.snmpNetworkInit(); err != nil {
+		return nil, fmt.Errorf("Error connecting to device %q: %w",
+			s.gsnmp.Target, err)
+	}
+	info := &SystemInfo{}
+	var err error
+	if info.Hostname, err = s.getString(snmpSysNameInstance); err != nil {
+		return nil, err
+	}
+	if info.Description, err = s.getString(snmpSysDescrInstance); err != nil {
+		return nil, err
+	}
+
+	chassis, err := s.chassisEntity(info.Description)
+	if err != nil {
+		return nil, err
+	}
+	info.Vendor = chassis.Vendor
+	info.Model = chassis.Model
+	info.SerialNumber = chassis.SerialNumber
+	info.SoftwareVersion = chassis.SoftwareVersion
+	if info.SoftwareVersion == "" {
+		if m := sysDescrVersion.FindStringSubmatch(info.Description); m != nil {
+			info.SoftwareVersion = m[1]
+		}
+	}
+	return info, nil
+}
+
 func (s *Snmp) getChassisID() (string, error) {
 	s.monitor.Tracef("getChassisID")
 	var subtype string
@@ -338,17 +496,41 @@ func (s *Snmp) Alive(ctx context.Context) (bool, error) {
 	return err == nil, err
 }
 
+// MappedModels returns the OpenConfig models the provider has
+// successfully translated SNMP data into. It returns nil until the
+// provider has started polling.
+func (s *Snmp) MappedModels() []snmpoc.MappedModel {
+	s.translatorLock.Lock()
+	translator := s.translator
+	s.translatorLock.Unlock()
+	if translator == nil {
+		return nil
+	}
+	return translator.MappedModels()
+}
+
 func (s *Snmp) stop() {
+	s.connectionLock.Lock()
+	defer s.connectionLock.Unlock()
 	if !s.mock {
 		if s.tgsnmp.Conn != nil {
 			_ = s.tgsnmp.Conn.Close()
+			s.tgsnmp.Conn = nil
 		}
 		if s.gsnmp.Conn != nil {
 			_ = s.gsnmp.Conn.Close()
+			s.gsnmp.Conn = nil
 		}
 	}
 }
 
+// Close closes the provider's connections to the device, which are
+// opened by DeviceID and Alive as well as by Run.
+func (s *Snmp) Close(ctx context.Context) error {
+	s.stop()
+	return nil
+}
+
 var chassisIDSubtypeMacAddress = openconfig.LLDPChassisIDType(4)
 var chassisIDSubtypeNetworkAddress = openconfig.LLDPChassisIDType(5)
 
@@ -378,6 +560,12 @@ func (s *Snmp) Origin() string {
 }
 
 func (s *Snmp) sendUpdates(ctx context.Context) error {
+	// Polls are skipped once the datasource is draining.
+	end, ok := provider.BeginPoll(ctx)
+	if !ok {
+		return nil
+	}
+	defer end()
 	return s.translator.Poll(ctx, s.client, []string{".*"})
 }
 
@@ -413,11 +601,13 @@ func (s *Snmp) Run(ctx context.Context) error {
 		return fmt.Errorf("Failed creating Translator: %v", err)
 	}
 
+	translator.Mock = s.mock
+	translator.Walker = s.walker
+	translator.Getter = s.getter
+	translator.Logger = s.monitor
+	s.translatorLock.Lock()
 	s.translator = translator
-	s.translator.Mock = s.mock
-	s.translator.Walker = s.walker
-	s.translator.Getter = s.getter
-	s.translator.Logger = s.monitor
+	s.translatorLock.Unlock()
 
 	// Do periodic state updates forever.
 	if err := s.sendUpdates(ctx); err != nil && !ignoredError(err) {
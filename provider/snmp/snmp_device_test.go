@@ -8,8 +8,10 @@ import (
 	"bufio"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -19,6 +21,8 @@ import (
 	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
 	"github.com/aristanetworks/cloudvision-go/provider/mock"
 	"github.com/aristanetworks/cloudvision-go/provider/snmp/smi"
+	"github.com/aristanetworks/cloudvision-go/provider/snmp/snmpoc"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
 	"github.com/gosnmp/gosnmp"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"google.golang.org/grpc"
@@ -78,22 +82,49 @@ func walkMapsFromDump(filename string) ([]walkMap, error) {
 			wm = make(walkMap)
 		}
 
-		// Store a pointer to this PDU for each of the valid prefixes
-		// of its OID. So, e.g., a PDU with OID .1.2.3.4 would be
-		// stored in the maps of ".1", ".1.2", ".1.2.3", and
-		// ".1.2.3.4".
-		sections := strings.Split(pdu.Name, ".")[1:]
-		prefix := ""
-		for _, section := range sections {
-			prefix += "." + section
-			wm[prefix] = append(wm[prefix], pdu)
-		}
+		wm.add(pdu)
 	}
 	walkMaps = append(walkMaps, wm)
 
 	return walkMaps, nil
 }
 
+// add stores a pointer to pdu for each of the valid prefixes of its
+// OID. So, e.g., a PDU with OID .1.2.3.4 would be stored in the maps
+// of ".1", ".1.2", ".1.2.3", and ".1.2.3.4".
+func (wm walkMap) add(pdu *gosnmp.SnmpPDU) {
+	sections := strings.Split(pdu.Name, ".")[1:]
+	prefix := ""
+	for _, section := range sections {
+		prefix += "." + section
+		wm[prefix] = append(wm[prefix], pdu)
+	}
+}
+
+// withoutRows returns a copy of wm without the PDUs of the rows of
+// index in the tables of the entry OIDs.
+func (wm walkMap) withoutRows(index string, entries ...string) walkMap {
+	out := make(walkMap)
+	for _, pdu := range wm[".1"] {
+		removed := false
+		for _, entry := range entries {
+			column, ok := strings.CutPrefix(pdu.Name, entry+".")
+			if !ok {
+				continue
+			}
+			_, rowIndex, _ := strings.Cut(column, ".")
+			if rowIndex == index || strings.HasPrefix(rowIndex, index+".") {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			out.add(pdu)
+		}
+	}
+	return out
+}
+
 // testGNMIClient implements gnmi.GNMIClient. All it does is check
 // the provider's SetRequests for the specified paths. When it finds
 // one of the paths it's looking for, it removes that path from
@@ -358,3 +389,125 @@ func TestDevices(t *testing.T) {
 		})
 	}
 }
+
+// TestStaleState polls a translator with the data of a dump, and then
+// with an LLDP neighbor, an entity and an IP address removed from it.
+// It checks that the list elements of those, and only those, are
+// deleted, and that nothing is deleted by polls with failed walks of
+// the mapping groups of the failed walks.
+func TestStaleState(t *testing.T) {
+	walkMaps, err := walkMapsFromDump(
+		"dumps/Arista_DCS-7150S-24_4.21.3F-2GB-INT_20190301.gz")
+	if err != nil {
+		t.Fatalf("Failed processing dump file: %v", err)
+	}
+	mibStore, err := smi.NewStore("smi/mibs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trans, err := snmpoc.NewTranslator(mibStore, &gosnmp.GoSNMP{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wm := walkMaps[0]
+	// walkErr, if set, is returned by walks of lldpRemTable.
+	var walkErr error
+	trans.Mock = true
+	trans.Getter = func(oids []string) (*gosnmp.SnmpPacket, error) {
+		return testget(oids, mibStore, wm)
+	}
+	trans.Walker = func(oid string, walker gosnmp.WalkFunc) error {
+		if o := mibStore.GetObject(oid); walkErr != nil && o != nil &&
+			o.Name == "lldpRemTable" {
+			return walkErr
+		}
+		return testwalk(oid, walker, mibStore, wm)
+	}
+
+	// poll returns the paths deleted and replaced by a poll.
+	poll := func() (map[string]bool, map[string]bool, error) {
+		deleted, replaced := map[string]bool{}, map[string]bool{}
+		client := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+			req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+			for _, p := range req.Delete {
+				deleted[agnmi.StrPath(p)] = true
+			}
+			for _, u := range req.Replace {
+				replaced[agnmi.StrPath(u.Path)] = true
+			}
+			return nil, nil
+		})
+		err := trans.Poll(context.Background(), client, []string{".*"})
+		return deleted, replaced, err
+	}
+	mustPoll := func() (map[string]bool, map[string]bool) {
+		t.Helper()
+		deleted, replaced, err := poll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deleted, replaced
+	}
+
+	// The first poll clears the models, and the next deletes nothing.
+	deleted, _ := mustPoll()
+	if !reflect.DeepEqual(deleted, map[string]bool{
+		"/components": true, "/interfaces": true, "/lldp": true, "/system": true,
+	}) {
+		t.Fatalf("unexpected deletes of first poll: %v", deleted)
+	}
+	deleted, before := mustPoll()
+	if len(deleted) != 0 {
+		t.Fatalf("unexpected deletes of unchanged poll: %v", deleted)
+	}
+
+	wm = wm.withoutRows("0.97.1", ".1.0.8802.1.1.2.1.4.1.1", ".1.0.8802.1.1.2.1.4.2.1")
+	wm = wm.withoutRows("100006001", ".1.3.6.1.2.1.47.1.1.1.1")
+	wm = wm.withoutRows("1.4.172.30.174.29", ".1.3.6.1.2.1.4.34.1")
+
+	// A critical failure of a walk fails the poll, and a partial one
+	// deletes nothing in the interfaces and LLDP models walked along
+	// with lldpRemTable, though the platform model is unaffected.
+	walkErr = errors.New("request timeout")
+	if _, _, err := poll(); err == nil {
+		t.Fatal("expected error from failed poll")
+	}
+	walkErr = errors.New("no such table")
+	partial, _ := mustPoll()
+	for p := range partial {
+		if !strings.HasPrefix(p, "/components/") {
+			t.Fatalf("unexpected delete of partial poll: %s", p)
+		}
+	}
+
+	walkErr = nil
+	deleted, after := mustPoll()
+	for p := range partial {
+		deleted[p] = true
+	}
+	expected := map[string]bool{
+		"/lldp/interfaces/interface[name=Management1]/neighbors/neighbor[id=1]": true,
+		"/components/component[name=100006001]":                                 true,
+		"/interfaces/interface[name=Management1]/subinterfaces/" +
+			"subinterface[index=0]/ipv4/addresses/address[ip=172.30.174.29]": true,
+	}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Fatalf("expected deletes %v, got %v", expected, deleted)
+	}
+	// The deletes cover the paths no longer produced, and only those.
+	for p := range before {
+		covered := false
+		for d := range deleted {
+			covered = covered || strings.HasPrefix(p, d+"/")
+		}
+		if covered == after[p] {
+			t.Errorf("path %s produced: %t, deleted: %t", p, after[p], covered)
+		}
+	}
+
+	// Once deleted, stale paths aren't deleted again.
+	if deleted, _ := mustPoll(); len(deleted) != 0 {
+		t.Fatalf("unexpected deletes of unchanged poll: %v", deleted)
+	}
+}
@@ -297,6 +297,170 @@ func TestDeviceID(t *testing.T) {
 	}
 }
 
+func TestSystemInfo(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		responses map[string][]*gosnmp.SnmpPDU
+		expected  SystemInfo
+	}{
+		{
+			name: "chassisSoftwareRev",
+			responses: map[string][]*gosnmp.SnmpPDU{
+				snmpSysNameInstance: PDUsFromString(
+					".1.3.6.1.2.1.1.5.0 = STRING: dut337-PAN3060"),
+				snmpSysDescrInstance: PDUsFromString(
+					".1.3.6.1.2.1.1.1.0 = STRING: Palo Alto Networks PA-3000 series firewall"),
+				snmpEntPhysicalClass: PDUsFromString(entPhysClassAristaResponse),
+				snmpEntPhysicalMfgName + ".1": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.12.1 = STRING: Palo Alto Networks"),
+				snmpEntPhysicalModelName + ".1": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.13.1 = STRING: PA-3060"),
+				snmpEntPhysicalSerialNum + ".1": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.11.1 = STRING: 010401005047"),
+				snmpEntPhysicalSoftwareRev + ".1": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.10.1 = STRING: 8.1.4"),
+			},
+			expected: SystemInfo{
+				Hostname:        "dut337-PAN3060",
+				Description:     "Palo Alto Networks PA-3000 series firewall",
+				Vendor:          "Palo Alto Networks",
+				Model:           "PA-3060",
+				SerialNumber:    "010401005047",
+				SoftwareVersion: "8.1.4",
+			},
+		},
+		{
+			name: "sysDescrVersion",
+			responses: map[string][]*gosnmp.SnmpPDU{
+				snmpSysNameInstance: PDUsFromString(
+					".1.3.6.1.2.1.1.5.0 = STRING: dut329"),
+				snmpSysDescrInstance: {pdu(snmpSysDescrInstance, gosnmp.OctetString,
+					[]byte("Cisco NX-OS(tm) nxos.7.0.3.I7.1.bin, Software (nxos), "+
+						"Version 7.0(3)I7(1), RELEASE SOFTWARE"))},
+				snmpEntPhysicalClass: PDUsFromString(entPhysClassN9KResponse),
+				snmpEntPhysicalMfgName + ".149": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.12.149 = STRING: Cisco Systems, Inc."),
+				snmpEntPhysicalModelName + ".149": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.13.149 = STRING: N9K-C93128TX"),
+				snmpEntPhysicalSerialNum + ".149": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.11.149 = STRING: SAL1817R822"),
+				snmpEntPhysicalSoftwareRev + ".149": PDUsFromString(
+					".1.3.6.1.2.1.47.1.1.1.1.10.149 = STRING: "),
+			},
+			expected: SystemInfo{
+				Hostname: "dut329",
+				Description: "Cisco NX-OS(tm) nxos.7.0.3.I7.1.bin, Software (nxos), " +
+					"Version 7.0(3)I7(1), RELEASE SOFTWARE",
+				Vendor:          "Cisco Systems, Inc.",
+				Model:           "N9K-C93128TX",
+				SerialNumber:    "SAL1817R822",
+				SoftwareVersion: "7.0(3)I7(1)",
+			},
+		},
+		{
+			name: "noChassis",
+			responses: map[string][]*gosnmp.SnmpPDU{
+				snmpSysNameInstance:  {},
+				snmpSysDescrInstance: {},
+				snmpEntPhysicalClass: {},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Snmp{
+				mock:    true,
+				gsnmp:   &gosnmp.GoSNMP{Target: "1.2.3.4"},
+				monitor: mock.NewMockMonitor(),
+				now:     time.Now,
+				getter: func(oids []string) (*gosnmp.SnmpPacket, error) {
+					return mockget(oids, tc.responses)
+				},
+				walker: func(oid string, walker gosnmp.WalkFunc) error {
+					return mockwalk(oid, walker, tc.responses)
+				},
+			}
+			info, err := s.SystemInfo(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *info != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, *info)
+			}
+		})
+	}
+}
+
+func TestSystemInfoCache(t *testing.T) {
+	responses := map[string][]*gosnmp.SnmpPDU{
+		snmpSysNameInstance: PDUsFromString(
+			".1.3.6.1.2.1.1.5.0 = STRING: dut123"),
+		snmpSysDescrInstance: PDUsFromString(
+			".1.3.6.1.2.1.1.1.0 = STRING: Arista Networks EOS version 4.21.3F"),
+		snmpEntPhysicalClass:     PDUsFromString(entPhysClassAristaResponse),
+		snmpEntPhysicalSerialNum: PDUsFromString(entPhysSerialNumAristaResponse),
+		snmpEntPhysicalMfgName + ".1": PDUsFromString(
+			".1.3.6.1.2.1.47.1.1.1.1.12.1 = STRING: Arista Networks"),
+		snmpEntPhysicalModelName + ".1": PDUsFromString(
+			".1.3.6.1.2.1.47.1.1.1.1.13.1 = STRING: DCS-7504"),
+		snmpEntPhysicalSerialNum + ".1": PDUsFromString(
+			".1.3.6.1.2.1.47.1.1.1.1.11.1 = STRING: JSH11420017"),
+		snmpEntPhysicalSoftwareRev + ".1": PDUsFromString(
+			".1.3.6.1.2.1.47.1.1.1.1.10.1 = STRING: "),
+	}
+	requests := map[string]int{}
+	s := &Snmp{
+		mock:    true,
+		gsnmp:   &gosnmp.GoSNMP{Target: "1.2.3.4"},
+		monitor: mock.NewMockMonitor(),
+		now:     time.Now,
+		getter: func(oids []string) (*gosnmp.SnmpPacket, error) {
+			requests[oids[0]]++
+			return mockget(oids, responses)
+		},
+		walker: func(oid string, walker gosnmp.WalkFunc) error {
+			requests[oid]++
+			return mockwalk(oid, walker, responses)
+		},
+	}
+	check := func(walks, gets int, version string) {
+		t.Helper()
+		info, err := s.SystemInfo(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := SystemInfo{
+			Hostname:        "dut123",
+			Description:     "Arista Networks EOS version " + version,
+			Vendor:          "Arista Networks",
+			Model:           "DCS-7504",
+			SerialNumber:    "JSH11420017",
+			SoftwareVersion: version,
+		}
+		if *info != expected {
+			t.Errorf("expected %+v, got %+v", expected, *info)
+		}
+		if n := requests[snmpEntPhysicalClass]; n != walks {
+			t.Errorf("expected %d walks of entPhysicalClass, got %d", walks, n)
+		}
+		if n := requests[snmpEntPhysicalModelName+".1"]; n != gets {
+			t.Errorf("expected %d gets of entPhysicalModelName, got %d", gets, n)
+		}
+	}
+
+	// SystemInfo uses the chassis index found by DeviceID.
+	if _, err := s.DeviceID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check(1, 1, "4.21.3F")
+	// And then its cached chassis entity.
+	check(1, 1, "4.21.3F")
+	// Until the sysDescr changes.
+	responses[snmpSysDescrInstance] = PDUsFromString(
+		".1.3.6.1.2.1.1.1.0 = STRING: Arista Networks EOS version 4.22.1F")
+	check(2, 2, "4.22.1F")
+	check(2, 2, "4.22.1F")
+}
+
 func TestTestGet(t *testing.T) {
 	s := &Snmp{
 		mock:  true,
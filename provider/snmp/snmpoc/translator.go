@@ -10,14 +10,20 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
+	"github.com/aristanetworks/cloudvision-go/provider"
 	pgnmi "github.com/aristanetworks/cloudvision-go/provider/gnmi"
 	"github.com/aristanetworks/cloudvision-go/provider/snmp/pdu"
 	"github.com/aristanetworks/cloudvision-go/provider/snmp/smi"
+	"github.com/aristanetworks/cloudvision-go/tracing"
+	agnmi "github.com/aristanetworks/goarista/gnmi"
 	"github.com/gosnmp/gosnmp"
 	"github.com/openconfig/gnmi/proto/gnmi"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -39,6 +45,7 @@ func NewTranslator(mibStore smi.Store, gs *gosnmp.GoSNMP) (*Translator, error) {
 		pathsMappingGroups:     make(map[string]map[string]*mappingGroup),
 		pduStore:               ps,
 		pollLock:               &sync.Mutex{},
+		snapshots:              make(map[string]*snapshot),
 		successfulMappings:     make(map[string]Mapper),
 		successfulMappingsLock: &sync.RWMutex{},
 		Walker:                 gs.BulkWalk,
@@ -56,6 +63,7 @@ type Logger interface {
 // we want to produce updates.
 type model struct {
 	name         string
+	ocName       string // name of the OpenConfig model
 	rootPath     string
 	dependencies []string
 	snmpGetOIDs  []string
@@ -65,6 +73,7 @@ type model struct {
 func (m *model) Copy() *model {
 	m2 := &model{
 		name:         m.name,
+		ocName:       m.ocName,
 		rootPath:     m.rootPath,
 		dependencies: make([]string, len(m.dependencies)),
 		snmpGetOIDs:  make([]string, len(m.snmpGetOIDs)),
@@ -84,6 +93,33 @@ type mappingGroup struct {
 	updatePaths map[string][]string
 }
 
+// A snapshot is the set of paths, by string, for which a model
+// produced updates on previous polls.
+type snapshot struct {
+	paths map[string]*gnmi.Path
+	// synced is set once the root path of the model has been deleted
+	// along with a complete poll's updates, so that any later stale
+	// path is in paths.
+	synced bool
+}
+
+// merge returns a snapshot of the paths of both s, which may be nil,
+// and other. It's what's known to have been set when the Sets of other
+// may have failed, or when other comes from a partial poll.
+func (s *snapshot) merge(other *snapshot) *snapshot {
+	merged := &snapshot{paths: make(map[string]*gnmi.Path, len(other.paths))}
+	if s != nil {
+		merged.synced = s.synced
+		for k, p := range s.paths {
+			merged.paths[k] = p
+		}
+	}
+	for k, p := range other.paths {
+		merged.paths[k] = p
+	}
+	return merged
+}
+
 type nonlogger struct{}
 
 func (n *nonlogger) Infof(format string, args ...interface{})  {}
@@ -112,6 +148,10 @@ type Translator struct {
 	// to ensure non-overlapping polls
 	pollLock *sync.Mutex
 
+	// snapshots are the paths produced for each model, by name, so
+	// that stale ones can be deleted. They're protected by pollLock.
+	snapshots map[string]*snapshot
+
 	// logging
 	Logger Logger
 
@@ -127,8 +167,17 @@ type Translator struct {
 // is added to the set of updates to produce. It performs a poll for
 // any required SNMP data and translates that data into gNMI updates,
 // which it then transmits via the provided gNMI client's Set method.
+//
+// The first complete poll of a model deletes its root path before
+// setting its updates. Later ones instead delete the paths produced by
+// the previous polls but no longer produced, or the list elements they
+// were in, such as LLDP neighbors that aged out. A mapping group with failed walks or gets
+// deletes nothing, since the data missing isn't known to be removed.
 func (t *Translator) Poll(ctx context.Context, client gnmi.GNMIClient,
-	paths []string) error {
+	paths []string) (err error) {
+	ctx, span := tracing.StartPoll(ctx, "snmpoc.Translator.Poll",
+		trace.WithAttributes(attribute.StringSlice("snmpoc.paths", paths)))
+	defer func() { tracing.End(span, err) }()
 	t.pollLock.Lock()
 	defer t.pollLock.Unlock()
 
@@ -154,14 +203,22 @@ func (t *Translator) Poll(ctx context.Context, client gnmi.GNMIClient,
 
 	// Produce updates for each mapping group.
 	setReqCh := make(chan *gnmi.SetRequest, len(mappingGroups))
-	errg, ctx := errgroup.WithContext(ctx)
+	errg, gctx := errgroup.WithContext(ctx)
 	var wg sync.WaitGroup
+	var snapshotsLock sync.Mutex
+	snapshots := map[string]*snapshot{}
 	for _, mg := range mappingGroups {
 		mg := mg
 		wg.Add(1)
 		errg.Go(func() error {
 			defer wg.Done()
-			return t.mappingGroupUpdates(ctx, mg, setReqCh)
+			mgSnapshots, err := t.mappingGroupUpdates(gctx, mg, setReqCh)
+			snapshotsLock.Lock()
+			defer snapshotsLock.Unlock()
+			for name, snap := range mgSnapshots {
+				snapshots[name] = snap
+			}
+			return err
 		})
 	}
 	errg.Go(func() error {
@@ -172,19 +229,33 @@ func (t *Translator) Poll(ctx context.Context, client gnmi.GNMIClient,
 	errg.Go(func() error {
 		for {
 			select {
-			case <-ctx.Done():
-				return ctx.Err()
+			case <-gctx.Done():
+				return gctx.Err()
 			case sr, ok := <-setReqCh:
 				if !ok {
 					return nil
 				}
-				if _, err := client.Set(ctx, sr); err != nil {
+				if _, err := client.Set(gctx, sr); err != nil {
 					return err
 				}
 			}
 		}
 	})
-	return errg.Wait()
+	err = errg.Wait()
+	// A batching client may acknowledge Sets before they're applied,
+	// so flush it to know whether they were.
+	if ferr := provider.Flush(ctx, client); err == nil {
+		err = ferr
+	}
+	// If any Set failed, which of the snapshots were set isn't known,
+	// so keep track of the paths of both the old and new snapshots.
+	for name, snap := range snapshots {
+		if err != nil {
+			snap = t.snapshots[name].merge(snap)
+		}
+		t.snapshots[name] = snap
+	}
+	return err
 }
 
 // updates produces updates for the provided set of paths.
@@ -226,20 +297,65 @@ func (t *Translator) updates(paths []string) ([]*gnmi.Update, error) {
 	return updates, nil
 }
 
+// A MappedModel is an OpenConfig model for which the translator has
+// successfully mapped at least one path.
+type MappedModel struct {
+	Name  string
+	Paths []string
+}
+
+// MappedModels returns the models, sorted by name, whose paths the
+// translator has so far been able to produce updates for, along with
+// those paths.
+func (t *Translator) MappedModels() []MappedModel {
+	t.successfulMappingsLock.RLock()
+	paths := make([]string, 0, len(t.successfulMappings))
+	for p := range t.successfulMappings {
+		paths = append(paths, p)
+	}
+	t.successfulMappingsLock.RUnlock()
+	sort.Strings(paths)
+
+	models := map[string]*MappedModel{}
+	for _, p := range paths {
+		for _, m := range supportedModels {
+			if !strings.HasPrefix(p, m.rootPath+"/") {
+				continue
+			}
+			mm, ok := models[m.ocName]
+			if !ok {
+				mm = &MappedModel{Name: m.ocName}
+				models[m.ocName] = mm
+			}
+			mm.Paths = append(mm.Paths, p)
+		}
+	}
+
+	out := make([]MappedModel, 0, len(models))
+	for _, mm := range models {
+		out = append(out, *mm)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
 var supportedModels = map[string]*model{
 	"interfaces": {
 		name:         "interfaces",
+		ocName:       "openconfig-interfaces",
 		rootPath:     "/interfaces",
 		snmpWalkOIDs: []string{"ifTable", "ifXTable", "ipAddressTable"},
 	},
 	"system": {
 		name:     "system",
+		ocName:   "openconfig-system",
 		rootPath: "/system",
 		snmpGetOIDs: []string{"sysName.0", "lldpLocSysName.0", "hrSystemUptime.0",
 			"sysUpTimeInstance"},
 	},
 	"lldp": {
 		name:         "lldp",
+		ocName:       "openconfig-lldp",
 		rootPath:     "/lldp",
 		dependencies: []string{"interfaces"},
 		snmpWalkOIDs: []string{"lldpLocalSystemData", "lldpRemTable", "lldpStatistics",
@@ -247,6 +363,7 @@ var supportedModels = map[string]*model{
 	},
 	"platform": {
 		name:         "platform",
+		ocName:       "openconfig-platform",
 		rootPath:     "/components",
 		snmpWalkOIDs: []string{"entPhysicalEntry"},
 	},
@@ -297,29 +414,33 @@ func criticalSNMPError(err error) bool {
 	return false
 }
 
-func (t *Translator) getSNMPData(mg *mappingGroup) error {
+// getSNMPData gets the SNMP data of the models of mg. It returns
+// whether all of it was retrieved, without non-critical errors.
+func (t *Translator) getSNMPData(mg *mappingGroup) (bool, error) {
 	t.gosnmpLock.Lock()
 	defer t.gosnmpLock.Unlock()
 
 	// Connect to target.
 	if !t.gosnmpConnected && !t.Mock {
 		if err := t.gosnmp.Connect(); err != nil {
-			return fmt.Errorf("connect failed: %w", err)
+			return false, fmt.Errorf("connect failed: %w", err)
 		}
 		t.gosnmpConnected = true
 		t.Logger.Infof("gosnmp.Connect complete")
 	}
 
 	// Get SNMP data for each model in this mappingGroup.
+	complete := true
 	for _, model := range mg.models {
 		// Walk
 		for _, oid := range model.snmpWalkOIDs {
 			t.Logger.Debugf("SNMP Walk (OID = %s)", oid)
 			if err := t.Walker(oid, t.storePDU); err != nil {
 				if criticalSNMPError(err) {
-					return fmt.Errorf("SNMP Walker failed: %w", err)
+					return false, fmt.Errorf("SNMP Walker failed: %w", err)
 				}
 				t.Logger.Infof("Error walking OID %s: %s", oid, err)
+				complete = false
 			} else {
 				t.Logger.Debugf("SNMP Walk complete (OID = %s)", oid)
 			}
@@ -334,14 +455,14 @@ func (t *Translator) getSNMPData(mg *mappingGroup) error {
 		pkt, err := t.Getter(model.snmpGetOIDs)
 		if err != nil {
 			if criticalSNMPError(err) {
-				return fmt.Errorf("SNMP Getter failed: %w", err)
+				return false, fmt.Errorf("SNMP Getter failed: %w", err)
 			}
 			t.Logger.Infof("Error getting OIDs %s: %s",
 				strings.Join(model.snmpGetOIDs, " "), err)
-			return nil
+			return false, nil
 		} else if pkt == nil {
 			t.Logger.Infof("SNMP Get returned nil packet")
-			return nil
+			return false, nil
 		} else {
 			t.Logger.Debugf("SNMP Get complete. pkt = %v, err = %v", pkt, err)
 		}
@@ -353,26 +474,36 @@ func (t *Translator) getSNMPData(mg *mappingGroup) error {
 			}
 			t.Logger.Infof("SNMP Get: Error in packet (%v): %s",
 				pkt, errstr)
+			complete = false
 		}
 
 		for _, pdu := range pkt.Variables {
 			if err = t.storePDU(pdu); err != nil {
 				t.Logger.Infof("Error storing PDU: %s", err)
+				complete = false
 			}
 		}
 	}
 
-	return nil
+	return complete, nil
 }
 
+// mappingGroupUpdates produces the SetRequests of the models of mg, and
+// returns the snapshots of the paths they set, by model name, which
+// are to be diffed against on the next poll.
 func (t *Translator) mappingGroupUpdates(ctx context.Context,
-	mg *mappingGroup, setReqCh chan *gnmi.SetRequest) error {
+	mg *mappingGroup, setReqCh chan *gnmi.SetRequest) (
+	snapshots map[string]*snapshot, err error) {
+	_, span := tracing.Start(ctx, "snmpoc.Translator.mappingGroupUpdates",
+		trace.WithAttributes(attribute.String("snmpoc.mapping-group", mg.name)))
+	defer func() { tracing.End(span, err) }()
 
 	// Get SNMP data.
-	if err := t.getSNMPData(mg); err != nil {
+	complete, err := t.getSNMPData(mg)
+	if err != nil {
 		// Errors only occur on connection issues, so we likely have not retrieved any data
 		// so just return
-		return err
+		return nil, err
 	}
 
 	// Produce updates and hand a SetRequest to the gNMI client.
@@ -382,34 +513,104 @@ func (t *Translator) mappingGroupUpdates(ctx context.Context,
 	setRequests := []*gnmi.SetRequest{}
 	setRequest := new(gnmi.SetRequest)
 	setRequests = append(setRequests, setRequest)
+	snapshots = make(map[string]*snapshot, len(mg.models))
 	for modelName, model := range mg.models {
-		setRequest.Delete = append(setRequest.Delete,
-			pgnmi.PathFromString(model.rootPath))
-		if up, ok := mg.updatePaths[modelName]; ok {
-			updates, err := t.updates(up)
-			if err != nil {
-				return err
-			}
-			if len(setRequest.Replace) > numUpdatesPerSet {
-				setRequest = new(gnmi.SetRequest)
-				setRequests = append(setRequests, setRequest)
-			}
-			setRequest.Replace = append(setRequest.Replace, updates...)
-			t.Logger.Debugf("Replace for mapping group %s, model %s has %d updates",
-				mg.name, modelName, len(setRequest.Replace))
-		} else {
-			return fmt.Errorf("No updatePath entries for model '%s'", modelName)
+		up, ok := mg.updatePaths[modelName]
+		if !ok {
+			return nil, fmt.Errorf("No updatePath entries for model '%s'", modelName)
+		}
+		updates, err := t.updates(up)
+		if err != nil {
+			return nil, err
+		}
+		snap := &snapshot{paths: make(map[string]*gnmi.Path, len(updates)), synced: true}
+		for _, u := range updates {
+			snap.paths[agnmi.StrPath(u.Path)] = u.Path
+		}
+
+		// Data missing from a partial poll may still be there, so
+		// delete nothing, and keep the paths of the previous polls
+		// until a complete one.
+		prev := t.snapshots[modelName]
+		switch {
+		case !complete:
+			snap = prev.merge(snap)
+		case prev == nil || !prev.synced:
+			setRequests[0].Delete = append(setRequests[0].Delete,
+				pgnmi.PathFromString(model.rootPath))
+		default:
+			stale := stalePaths(prev, snap)
+			setRequests[0].Delete = append(setRequests[0].Delete, stale...)
+			t.Logger.Debugf("Mapping group %s, model %s has %d stale paths",
+				mg.name, modelName, len(stale))
 		}
+		snapshots[modelName] = snap
+
+		if len(setRequest.Replace) > numUpdatesPerSet {
+			setRequest = new(gnmi.SetRequest)
+			setRequests = append(setRequests, setRequest)
+		}
+		setRequest.Replace = append(setRequest.Replace, updates...)
+		t.Logger.Debugf("Replace for mapping group %s, model %s has %d updates",
+			mg.name, modelName, len(setRequest.Replace))
 	}
 
+	// Some of the SetRequests may have been sent already if the poll
+	// is canceled, so the snapshots are returned either way.
 	for _, sr := range setRequests {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return snapshots, ctx.Err()
 		case setReqCh <- sr:
 		}
 	}
-	return nil
+	return snapshots, nil
+}
+
+// stalePaths returns the paths to delete for the paths of prev that
+// aren't in cur, sorted. A path under a list element that's no longer
+// in cur, such as an LLDP neighbor that aged out, is deleted along
+// with the whole element, so that it doesn't leave an empty element
+// behind. Other paths are deleted one by one.
+func stalePaths(prev, cur *snapshot) []*gnmi.Path {
+	// present holds the list elements of the paths of cur.
+	present := map[string]bool{}
+	for _, p := range cur.paths {
+		for i, elem := range p.Elem {
+			if len(elem.Key) > 0 {
+				present[agnmi.StrPath(&gnmi.Path{Elem: p.Elem[:i+1]})] = true
+			}
+		}
+	}
+	stale := map[string]*gnmi.Path{}
+	for k, p := range prev.paths {
+		if _, ok := cur.paths[k]; ok {
+			continue
+		}
+		// If the deepest list element of p is in cur, so are the
+		// others.
+		for i := len(p.Elem) - 1; i >= 0; i-- {
+			if len(p.Elem[i].Key) == 0 {
+				continue
+			}
+			elem := &gnmi.Path{Origin: p.Origin, Target: p.Target, Elem: p.Elem[:i+1]}
+			if ek := agnmi.StrPath(elem); !present[ek] {
+				k, p = ek, elem
+			}
+			break
+		}
+		stale[k] = p
+	}
+	keys := make([]string, 0, len(stale))
+	for k := range stale {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	paths := make([]*gnmi.Path, len(keys))
+	for i, k := range keys {
+		paths[i] = stale[k]
+	}
+	return paths
 }
 
 // A mappingGroup is a set of related translations that share dependencies.
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"sync"
 	"testing"
@@ -19,8 +20,11 @@ import (
 	"github.com/aristanetworks/cloudvision-go/provider/openconfig"
 	"github.com/aristanetworks/cloudvision-go/provider/snmp/pdu"
 	"github.com/aristanetworks/cloudvision-go/provider/snmp/smi"
+	"github.com/aristanetworks/cloudvision-go/tracing"
+	"github.com/aristanetworks/cloudvision-go/tracing/tracingtest"
 	"github.com/gosnmp/gosnmp"
 	"github.com/openconfig/gnmi/proto/gnmi"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 var basicEntPhysicalTableResponse = `
@@ -2256,3 +2260,114 @@ func TestBuildIPAddrMap(t *testing.T) {
 		})
 	}
 }
+
+func TestMappedModels(t *testing.T) {
+	mibStore, err := smi.NewStore("../smi/mibs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trans, err := NewTranslator(mibStore, &gosnmp.GoSNMP{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mm := trans.MappedModels(); len(mm) != 0 {
+		t.Fatalf("expected no mapped models before polling, got %v", mm)
+	}
+
+	responses := map[string][]*gosnmp.SnmpPDU{
+		"sysName": {
+			PDU("sysName", octstr, []byte("device123.sjc.aristanetworks.com")),
+		},
+		"hrSystemUptime": {
+			PDU("hrSystemUptime", timeticks, 162275519),
+		},
+		"sysUpTimeInstance": {
+			PDU("sysUpTimeInstance", timeticks, 162261667),
+		},
+	}
+	trans.Mock = true
+	trans.Getter = func(oids []string) (*gosnmp.SnmpPacket, error) {
+		return mockget(oids, responses, mibStore)
+	}
+	trans.Walker = func(oid string, walker gosnmp.WalkFunc) error {
+		return mockwalk(oid, walker, responses, mibStore)
+	}
+	client := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		return nil, nil
+	})
+	if err := trans.Poll(context.Background(), client, []string{"^/system/"}); err != nil {
+		t.Fatalf("Failure in translator.Poll: %v", err)
+	}
+
+	// Only the paths the device returned data for are mapped.
+	expected := []MappedModel{{
+		Name: "openconfig-system",
+		Paths: []string{
+			"/system/state/boot-time",
+			"/system/state/domain-name",
+			"/system/state/hostname",
+		},
+	}}
+	if mm := trans.MappedModels(); !reflect.DeepEqual(mm, expected) {
+		t.Fatalf("expected %v, got %v", expected, mm)
+	}
+}
+
+func TestPollTracing(t *testing.T) {
+	spans, restore := tracingtest.SetupInMemory()
+	defer restore()
+
+	mibStore, err := smi.NewStore("../smi/mibs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trans, err := NewTranslator(mibStore, &gosnmp.GoSNMP{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responses := map[string][]*gosnmp.SnmpPDU{
+		"sysName": {
+			PDU("sysName", octstr, []byte("device123.sjc.aristanetworks.com")),
+		},
+	}
+	trans.Mock = true
+	trans.Getter = func(oids []string) (*gosnmp.SnmpPacket, error) {
+		return mockget(oids, responses, mibStore)
+	}
+	trans.Walker = func(oid string, walker gosnmp.WalkFunc) error {
+		return mockwalk(oid, walker, responses, mibStore)
+	}
+	client := pgnmi.NewSimpleGNMIClient(func(ctx context.Context,
+		req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+		_, span := tracing.StartSet(ctx, "dev1", req)
+		span.End()
+		return nil, nil
+	})
+	ctx := tracing.ContextWithDatasource(context.Background(), "snmp1", "snmp")
+	if err := trans.Poll(ctx, client, []string{"^/system/"}); err != nil {
+		t.Fatalf("Failure in translator.Poll: %v", err)
+	}
+
+	var poll tracetest.SpanStub
+	counts := map[string]int{}
+	for _, span := range spans.GetSpans() {
+		counts[span.Name]++
+		if span.Name == "snmpoc.Translator.Poll" {
+			poll = span
+		}
+		if !slices.Contains(span.Attributes, tracing.DatasourceKey.String("snmp1")) {
+			t.Errorf("expected span %s to be tagged with datasource, got %v",
+				span.Name, span.Attributes)
+		}
+	}
+	if counts["snmpoc.Translator.Poll"] != 1 || counts["CVClient.Set"] == 0 ||
+		counts["snmpoc.Translator.mappingGroupUpdates"] == 0 {
+		t.Fatalf("unexpected spans %v", counts)
+	}
+	for _, span := range spans.GetSpans() {
+		if span.Name != poll.Name && span.Parent.SpanID() != poll.SpanContext.SpanID() {
+			t.Errorf("expected span %s to be a child of the poll span", span.Name)
+		}
+	}
+}
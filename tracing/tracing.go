@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+// Package tracing provides OpenTelemetry tracing for the collector.
+//
+// Spans are started with the global tracer provider, which doesn't
+// record anything until Setup installs one that exports them.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aristanetworks/cloudvision-go/version"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer spans are started with.
+const TracerName = "github.com/aristanetworks/cloudvision-go"
+
+// Span attributes.
+const (
+	// DeviceIDKey is the ID of the device a span is about.
+	DeviceIDKey = attribute.Key("cloudvision.device.id")
+	// DatasourceKey is the name of the datasource a span is about.
+	DatasourceKey = attribute.Key("cloudvision.datasource.name")
+	// DatasourceTypeKey is the type of the datasource a span is about.
+	DatasourceTypeKey = attribute.Key("cloudvision.datasource.type")
+)
+
+type datasourceKey struct{}
+
+// ContextWithDatasource returns a copy of ctx in which spans are
+// tagged with the name and type of a datasource.
+func ContextWithDatasource(ctx context.Context, name, typ string) context.Context {
+	return context.WithValue(ctx, datasourceKey{}, []attribute.KeyValue{
+		DatasourceKey.String(name),
+		DatasourceTypeKey.String(typ),
+	})
+}
+
+func datasourceAttributes(ctx context.Context) []attribute.KeyValue {
+	attrs, _ := ctx.Value(datasourceKey{}).([]attribute.KeyValue)
+	return attrs
+}
+
+// Start starts a span, child of the span in ctx if there's one, and
+// returns it along with a copy of ctx holding it. The span is tagged
+// with the datasource of ctx, if it has one.
+func Start(ctx context.Context, name string,
+	opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if attrs := datasourceAttributes(ctx); attrs != nil {
+		opts = append(opts, trace.WithAttributes(attrs...))
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// StartPoll starts the span of a provider poll. Providers poll for as
+// long as their datasource runs, so each poll gets a trace of its own,
+// linked to the span in ctx, rather than a child span.
+func StartPoll(ctx context.Context, name string,
+	opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	opts = append(opts, trace.WithNewRoot(), trace.WithLinks(trace.LinkFromContext(ctx)))
+	return Start(ctx, name, opts...)
+}
+
+// StartSet starts the span of a SetRequest made for a device.
+func StartSet(ctx context.Context, deviceID string,
+	req *gnmi.SetRequest) (context.Context, trace.Span) {
+	return Start(ctx, "CVClient.Set", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			DeviceIDKey.String(deviceID),
+			attribute.Int("gnmi.set.deletes", len(req.GetDelete())),
+			attribute.Int("gnmi.set.replaces", len(req.GetReplace())),
+			attribute.Int("gnmi.set.updates", len(req.GetUpdate())),
+		))
+}
+
+// End ends span, recording err as its error if it's not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Exporters supported by Setup.
+const (
+	// ExporterOTLP exports spans with OTLP over gRPC.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes spans as JSON, mostly for debugging.
+	ExporterStdout = "stdout"
+)
+
+// Config configures the export of spans.
+type Config struct {
+	// Exporter is ExporterOTLP or ExporterStdout.
+	Exporter string
+	// Endpoint is the host:port of the OTLP collector. If empty, the
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable or the
+	// default OTLP endpoint is used.
+	Endpoint string
+	// Insecure disables TLS to the OTLP collector.
+	Insecure bool
+	// SampleRatio is the ratio of traces sampled, between 0 and 1.
+	SampleRatio float64
+	// Writer is where ExporterStdout writes spans. It's os.Stdout
+	// if nil.
+	Writer io.Writer
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		w := cfg.Writer
+		if w == nil {
+			w = os.Stdout
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	}
+	return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+}
+
+// Setup installs a global tracer provider exporting spans as
+// configured by cfg, and returns a function flushing and shutting it
+// down.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(
+			sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceNameKey.String("cloudvision-collector"),
+			semconv.ServiceVersionKey.String(version.CollectorVersion),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
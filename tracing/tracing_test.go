@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Arista Networks, Inc.
+// Use of this source code is governed by the Apache License 2.0
+// that can be found in the COPYING file.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aristanetworks/cloudvision-go/tracing/tracingtest"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestSpans(t *testing.T) {
+	spans, restore := tracingtest.SetupInMemory()
+	defer restore()
+
+	ctx := ContextWithDatasource(context.Background(), "ds1", "snmp")
+	ctx, run := Start(ctx, "datasource.Run")
+	pollCtx, poll := StartPoll(ctx, "poll")
+	_, set := StartSet(pollCtx, "dev1", &gnmi.SetRequest{Update: []*gnmi.Update{{}}})
+	End(set, errors.New("unavailable"))
+	End(poll, nil)
+	End(run, nil)
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(stubs))
+	}
+	setSpan, pollSpan, runSpan := stubs[0], stubs[1], stubs[2]
+	for _, span := range stubs {
+		if !slices.Contains(span.Attributes, DatasourceKey.String("ds1")) ||
+			!slices.Contains(span.Attributes, DatasourceTypeKey.String("snmp")) {
+			t.Errorf("expected span %s to be tagged with datasource, got %v",
+				span.Name, span.Attributes)
+		}
+	}
+	// Polls get traces of their own, linked to the run.
+	if pollSpan.SpanContext.TraceID() == runSpan.SpanContext.TraceID() ||
+		len(pollSpan.Links) != 1 ||
+		pollSpan.Links[0].SpanContext.SpanID() != runSpan.SpanContext.SpanID() {
+		t.Errorf("expected poll span to be a new trace linked to the run span, got %+v",
+			pollSpan)
+	}
+	if setSpan.Parent.SpanID() != pollSpan.SpanContext.SpanID() {
+		t.Errorf("expected set span to be a child of the poll span")
+	}
+	if !slices.Contains(setSpan.Attributes, DeviceIDKey.String("dev1")) {
+		t.Errorf("expected set span to be tagged with device ID, got %v", setSpan.Attributes)
+	}
+	if setSpan.Status.Code != codes.Error || len(setSpan.Events) != 1 {
+		t.Errorf("expected set span to record its error, got %+v", setSpan.Status)
+	}
+}
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	if _, err := Setup(context.Background(), Config{Exporter: "zipkin"}); err == nil {
+		t.Error("expected error for unknown exporter")
+	}
+
+	var b bytes.Buffer
+	shutdown, err := Setup(context.Background(), Config{
+		Exporter:    ExporterStdout,
+		SampleRatio: 1,
+		Writer:      &b,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := Start(context.Background(), "Sensor.handleConfigUpdate")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `"Name":"Sensor.handleConfigUpdate"`) {
+		t.Errorf("expected span to be written, got %s", b.String())
+	}
+}